@@ -0,0 +1,62 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/zhinea/sylix/internal/common"
+	"github.com/zhinea/sylix/internal/common/config"
+	"github.com/zhinea/sylix/internal/common/logger"
+	"github.com/zhinea/sylix/internal/module/agent"
+	"go.uber.org/zap"
+)
+
+func main() {
+	configPath := flag.String("config", "/etc/sylix-agent/config.yaml", "path of the agent configuration")
+	flag.Parse()
+
+	cfg, err := config.LoadAgentConfig(*configPath)
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	logger.Init(logger.Config{
+		Filename:   cfg.Log.Filename,
+		MaxSize:    cfg.Log.MaxSize,
+		MaxBackups: cfg.Log.MaxBackups,
+		MaxAge:     cfg.Log.MaxAge,
+		Compress:   cfg.Log.Compress,
+		Level:      cfg.Log.Level,
+	})
+	defer logger.Log.Sync()
+
+	server, err := agent.NewServer(cfg)
+	if err != nil {
+		logger.Log.Fatal("Failed to create agent server", zap.Error(err))
+	}
+
+	addr := fmt.Sprintf("%s:%d", cfg.Server.Host, cfg.Server.Port)
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		logger.Log.Fatal("Failed to listen", zap.String("addr", addr), zap.Error(err))
+	}
+
+	// Finish running calls before exiting on systemctl stop
+	stop := make(chan os.Signal, 1)
+	signal.Notify(stop, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-stop
+		logger.Log.Info("Shutting down agent")
+		server.GracefulStop()
+	}()
+
+	logger.Log.Info("Agent started", zap.String("addr", addr), zap.String("version", common.Version))
+	if err := server.Serve(lis); err != nil {
+		logger.Log.Fatal("Failed to serve", zap.Error(err))
+	}
+}
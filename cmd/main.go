@@ -1,27 +1,36 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
+	"os"
+	"path/filepath"
 
 	"github.com/improbable-eng/grpc-web/go/grpcweb"
 	"github.com/joho/godotenv"
 	"github.com/rs/cors"
 	"github.com/zhinea/sylix/internal/common/logger"
+	"github.com/zhinea/sylix/internal/common/util"
+	"github.com/zhinea/sylix/internal/common/workflow"
 	database "github.com/zhinea/sylix/internal/infra/db"
 	pbControlPlane "github.com/zhinea/sylix/internal/infra/proto/controlplane"
 	"github.com/zhinea/sylix/internal/module/controlplane/app"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/nodetype"
 	"github.com/zhinea/sylix/internal/module/controlplane/domain/repository"
 	"github.com/zhinea/sylix/internal/module/controlplane/domain/services"
+	cpWorkflow "github.com/zhinea/sylix/internal/module/controlplane/domain/workflow"
 	grpcServices "github.com/zhinea/sylix/internal/module/controlplane/interface/grpc"
+	"go.uber.org/zap"
 	"google.golang.org/grpc"
 )
 
 func main() {
 	_ = godotenv.Load() // Load .env file if it exists
 
+	appLogFile := "logs/app/file.log"
 	logger.Init(logger.Config{
-		Filename:   "logs/app/file.log",
+		Filename:   appLogFile,
 		MaxSize:    10, // MB
 		MaxBackups: 3,
 		MaxAge:     7, // days
@@ -37,6 +46,40 @@ func main() {
 
 	database.AutoMigrate(db)
 
+	nodeTypes, err := nodetype.Load("nodes.json")
+	if err != nil {
+		panic(err)
+	}
+
+	portRangeStart, portRangeEnd, err := services.ParsePortRange(os.Getenv("SYLIX_PORT_RANGE"))
+	if err != nil {
+		panic(err)
+	}
+
+	wireGuardPool, err := services.ParsePool(os.Getenv("SYLIX_WG_CIDR"))
+	if err != nil {
+		panic(err)
+	}
+
+	agentURL := os.Getenv("SYLIX_AGENT_URL")
+	if agentURL == "" {
+		agentURL = cpWorkflow.AgentDownloadURL(os.Getenv("SYLIX_VERSION"))
+	}
+
+	workflowsDir := os.Getenv("SYLIX_WORKFLOWS_DIR")
+	if workflowsDir == "" {
+		workflowsDir = services.DefaultWorkflowsDir
+	}
+
+	logRetentionPolicy, err := services.ParseLogRetention(
+		os.Getenv("SYLIX_LOG_RETENTION_DAYS"),
+		os.Getenv("SYLIX_LOG_LOCAL_CAP_MB"),
+		os.Getenv("SYLIX_LOG_ARCHIVE_STORAGE"),
+	)
+	if err != nil {
+		panic(err)
+	}
+
 	port := ":8082"
 
 	grpcServer := grpc.NewServer()
@@ -45,25 +88,80 @@ func main() {
 	serverRepo := repository.NewServerRepository(db)
 	monitoringRepo := repository.NewMonitoringRepository(db)
 	backupRepo := repository.NewBackupStorageRepository(db)
+	serviceNodeRepo := repository.NewServiceNodeRepository(db)
+	serviceEdgeRepo := repository.NewServiceEdgeRepository(db)
+	deploymentRepo := repository.NewDeploymentRepository(db)
+	portLeaseRepo := repository.NewPortLeaseRepository(db)
+	ipLeaseRepo := repository.NewIPLeaseRepository(db)
+	meshSyncRepo := repository.NewMeshSyncRepository(db)
+	certRepo := repository.NewCertificateAuthorityRepository(db)
+	workflowRunRepo := repository.NewWorkflowRunRepository(db)
+	logArchiveRepo := repository.NewLogArchiveRepository(db)
+	backupJobRepo := repository.NewBackupJobRepository(db)
+
+	certService := services.NewCertificateService(certRepo)
+	if err := certService.Init(context.Background()); err != nil {
+		panic(err)
+	}
 
+	sshPool := util.NewSSHPool(util.DefaultSSHMaxSessions, util.DefaultSSHMaxDedicated, util.DefaultSSHKeepalive, util.DefaultSSHIdleTimeout)
+	defer sshPool.Close()
+	sshService := services.NewSSHService(serverRepo, sshPool)
+	agentClients := services.NewAgentClientService(certService)
+	defer agentClients.Close()
+	remoteService := services.NewRemoteService(serverRepo, sshService, agentClients)
+	workflow.Register(workflow.ActionAgentRPC, services.NewAgentRPCAction(serverRepo, agentClients))
 	monitoringService := services.NewMonitoringService(monitoringRepo)
-	nodeService := services.NewNodeService(serverRepo)
+	ipamService := services.NewIPAMService(ipLeaseRepo, serverRepo, wireGuardPool)
+	workflowRunService := services.NewWorkflowRunService(workflowRunRepo)
+	logHub := services.NewLogHub()
+	nodeService := services.NewNodeService(serverRepo, meshSyncRepo, backupRepo, ipamService, sshService, certService, agentClients, workflowRunService, logHub, agentURL)
 	backupService := services.NewBackupService(backupRepo, serverRepo)
+	workflowService := services.NewWorkflowService(sshService, workflowRunService, logHub)
+	// Definitions are validated against the registered actions, so they are loaded after them
+	if err := workflowService.Load(workflowsDir); err != nil {
+		panic(err)
+	}
+	containerService := services.NewContainerService(remoteService)
+	topologyService := services.NewTopologyService(serviceNodeRepo, serviceEdgeRepo, serverRepo, nodeTypes)
+	deployService := services.NewDeployService(remoteService)
+	portService := services.NewPortService(portLeaseRepo, remoteService, portRangeStart, portRangeEnd)
 
-	serverUseCase := app.NewServerUseCase(serverRepo, monitoringService, nodeService)
+	serverUseCase := app.NewServerUseCase(serverRepo, monitoringService, nodeService, ipamService, sshService, certService, agentClients)
 	serverService := grpcServices.NewServerService(serverUseCase)
-	backupStorageService := grpcServices.NewBackupStorageService(backupService)
-
-	logsUseCase := app.NewLogsUseCase()
+	backupScheduler := app.NewBackupScheduler(backupJobRepo, serviceNodeRepo, backupRepo, backupService, remoteService)
+	backupScheduler.Start()
+	backupStorageService := grpcServices.NewBackupStorageService(backupService, backupScheduler)
+	workflowGrpcService := grpcServices.NewWorkflowService(workflowService)
+
+	// The controlplane's own log is rotated by lumberjack, its backups are archived like any other file
+	logRetention := services.NewLogRetentionService(logArchiveRepo, backupRepo, logRetentionPolicy, "logs", filepath.Join(os.TempDir(), "sylix-log-archives"), appLogFile)
+	logRetention.Start()
+	logsUseCase := app.NewLogsUseCase(logHub, services.NewLogReader(), logRetention, appLogFile)
 	logsService := grpcServices.NewLogsService(logsUseCase)
 
+	servicesUseCase := app.NewServicesUseCase(serviceNodeRepo, serverRepo, backupRepo, nodeTypes, containerService, topologyService)
+	deploymentOrchestrator := app.NewDeploymentOrchestrator(deploymentRepo, serviceNodeRepo, serverRepo, backupRepo, nodeTypes, topologyService, deployService, portService, logHub)
+	deploymentOrchestrator.Resume(context.Background())
+
+	// Pick up the workflows a restart interrupted, installations without one to resume are failed
+	if err := workflowRunService.Recover(context.Background()); err != nil {
+		logger.Log.Error("Failed to recover workflow runs", zap.Error(err))
+	}
+	if err := nodeService.FailInterruptedInstalls(context.Background()); err != nil {
+		logger.Log.Error("Failed to fail interrupted installations", zap.Error(err))
+	}
+	servicesService := grpcServices.NewServicesService(servicesUseCase, deploymentOrchestrator)
+
 	// Monitoring
-	monitoringWorker := app.NewMonitoringWorker(serverRepo, monitoringRepo)
+	monitoringWorker := app.NewMonitoringWorker(serverRepo, monitoringRepo, remoteService)
 	monitoringWorker.Start()
 
 	pbControlPlane.RegisterServerServiceServer(grpcServer, serverService)
 	pbControlPlane.RegisterLogsServiceServer(grpcServer, logsService)
 	pbControlPlane.RegisterBackupStorageServiceServer(grpcServer, backupStorageService)
+	pbControlPlane.RegisterServicesServiceServer(grpcServer, servicesService)
+	pbControlPlane.RegisterWorkflowServiceServer(grpcServer, workflowGrpcService)
 
 	// Wrap gRPC server for gRPC-Web support
 	wrappedGrpc := grpcweb.WrapServer(grpcServer,
@@ -14,6 +14,7 @@ type AgentConfig struct {
 	Security struct {
 		CertFile string `yaml:"cert_file"`
 		KeyFile  string `yaml:"key_file"`
+		CAFile   string `yaml:"ca_file"` // CA client certificates must be signed by
 	} `yaml:"security"`
 	Log struct {
 		Level      string `yaml:"level"`
@@ -36,17 +37,25 @@ type StorageConfig struct {
 	SecretKey string `yaml:"secret_key"`
 }
 
-func LoadAgentConfig(path string) (*AgentConfig, error) {
-	// Default configuration
+// DefaultAgentConfig returns the configuration the agent runs with when its config file leaves a setting out.
+func DefaultAgentConfig() *AgentConfig {
 	config := &AgentConfig{}
 	config.Server.Port = 8083
 	config.Server.Host = "0.0.0.0"
+	config.Security.CertFile = "/etc/sylix-agent/certs/server.crt"
+	config.Security.KeyFile = "/etc/sylix-agent/certs/server.key"
+	config.Security.CAFile = "/etc/sylix-agent/certs/ca.crt"
 	config.Log.Level = "info"
 	config.Log.Filename = "/etc/sylix-agent/agent.log"
 	config.Log.MaxSize = 10
 	config.Log.MaxBackups = 3
 	config.Log.MaxAge = 28
 	config.Log.Compress = true
+	return config
+}
+
+func LoadAgentConfig(path string) (*AgentConfig, error) {
+	config := DefaultAgentConfig()
 
 	data, err := os.ReadFile(path)
 	if err != nil {
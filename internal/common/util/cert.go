@@ -12,6 +12,10 @@ import (
 	"time"
 )
 
+// ControlplaneCertName is the name in the client certificate the controlplane presents to agents,
+// the only client they accept.
+const ControlplaneCertName = "sylix-controlplane"
+
 // GenerateCA generates a self-signed CA certificate and private key.
 func GenerateCA() (certPEM, keyPEM []byte, err error) {
 	ca := &x509.Certificate{
@@ -56,8 +60,9 @@ func GenerateCA() (certPEM, keyPEM []byte, err error) {
 	return certPEM, keyPEM, nil
 }
 
-// GenerateCert generates a certificate signed by the CA.
-func GenerateCert(caCertPEM, caKeyPEM []byte, ip string) (certPEM, keyPEM []byte, err error) {
+// GenerateCert generates a certificate signed by the CA, only good for usage: servers get
+// x509.ExtKeyUsageServerAuth, clients x509.ExtKeyUsageClientAuth.
+func GenerateCert(caCertPEM, caKeyPEM []byte, ip string, usage x509.ExtKeyUsage) (certPEM, keyPEM []byte, err error) {
 	// Parse CA certificate
 	block, _ := pem.Decode(caCertPEM)
 	if block == nil {
@@ -93,7 +98,7 @@ func GenerateCert(caCertPEM, caKeyPEM []byte, ip string) (certPEM, keyPEM []byte
 		NotBefore:    time.Now(),
 		NotAfter:     time.Now().AddDate(1, 0, 0), // 1 year
 		SubjectKeyId: []byte{1, 2, 3, 4, 6},
-		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
 		KeyUsage:     x509.KeyUsageDigitalSignature,
 	}
 
@@ -0,0 +1,94 @@
+package util
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+var ErrHostKeyMismatch = errors.New("ssh host key mismatch")
+
+// HostKeyMismatchError is returned when a host presents a different key than the one pinned for it.
+type HostKeyMismatchError struct {
+	Host     string
+	Expected string
+	Actual   string
+}
+
+func (e *HostKeyMismatchError) Error() string {
+	return fmt.Sprintf("ssh host key mismatch for %s: expected %s, got %s. If the server was reinstalled, trust the new key explicitly", e.Host, e.Expected, e.Actual)
+}
+
+func (e *HostKeyMismatchError) Is(target error) bool {
+	return target == ErrHostKeyMismatch
+}
+
+// TrustOnFirstUse returns a host key callback for a host whose pinned key is known, in authorized_keys
+// format. When nothing is pinned yet any key is accepted and passed to onFirstUse, which may pin it.
+func TrustOnFirstUse(known string, onFirstUse func(key ssh.PublicKey)) (ssh.HostKeyCallback, error) {
+	if known == "" {
+		return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+			if onFirstUse != nil {
+				onFirstUse(key)
+			}
+			return nil
+		}, nil
+	}
+
+	pinned, _, _, _, err := ssh.ParseAuthorizedKey([]byte(known))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse pinned host key: %w", err)
+	}
+
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		if !bytes.Equal(pinned.Marshal(), key.Marshal()) {
+			return &HostKeyMismatchError{
+				Host:     hostname,
+				Expected: ssh.FingerprintSHA256(pinned),
+				Actual:   ssh.FingerprintSHA256(key),
+			}
+		}
+		return nil
+	}, nil
+}
+
+// TrustFingerprint returns a host key callback accepting only a key with the given SHA256
+// fingerprint, which is passed to onMatch. It is how a changed key is trusted explicitly, so a
+// key presented by someone in the middle is never pinned.
+func TrustFingerprint(fingerprint string, onMatch func(key ssh.PublicKey)) ssh.HostKeyCallback {
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		if actual := ssh.FingerprintSHA256(key); actual != fingerprint {
+			return &HostKeyMismatchError{
+				Host:     hostname,
+				Expected: fingerprint,
+				Actual:   actual,
+			}
+		}
+		if onMatch != nil {
+			onMatch(key)
+		}
+		return nil
+	}
+}
+
+// MarshalHostKey encodes a host key in authorized_keys format, as it is pinned.
+func MarshalHostKey(key ssh.PublicKey) string {
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(key)))
+}
+
+// HostKeyFingerprint returns the SHA256 fingerprint of a key in authorized_keys format, or an empty
+// string when the key is empty or invalid.
+func HostKeyFingerprint(known string) string {
+	if known == "" {
+		return ""
+	}
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(known))
+	if err != nil {
+		return ""
+	}
+	return ssh.FingerprintSHA256(key)
+}
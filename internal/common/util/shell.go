@@ -0,0 +1,8 @@
+package util
+
+import "strings"
+
+// ShellQuote wraps s in single quotes so it is passed to a POSIX shell as one literal word.
+func ShellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -2,6 +2,7 @@ package util
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"os"
@@ -12,9 +13,15 @@ import (
 
 type SSHClient struct {
 	client *ssh.Client
+	// sessions caps the concurrent sessions on the connection, nil when unbounded
+	sessions chan struct{}
+	// release hands a pooled client back to its pool instead of closing the connection
+	release func()
 }
 
-func NewSSHClient(host string, port int, user string, password *string, key *string) (*SSHClient, error) {
+// NewSSHClient dials the host and authenticates with the password and/or private key. hostKey
+// verifies the key the host presents, see TrustOnFirstUse.
+func NewSSHClient(host string, port int, user string, password *string, key *string, hostKey ssh.HostKeyCallback) (*SSHClient, error) {
 	var authMethods []ssh.AuthMethod
 
 	if password != nil && *password != "" {
@@ -36,7 +43,7 @@ func NewSSHClient(host string, port int, user string, password *string, key *str
 	config := &ssh.ClientConfig{
 		User:            user,
 		Auth:            authMethods,
-		HostKeyCallback: ssh.InsecureIgnoreHostKey(), // Note: In production, use known_hosts
+		HostKeyCallback: hostKey,
 		Timeout:         10 * time.Second,
 	}
 
@@ -49,16 +56,48 @@ func NewSSHClient(host string, port int, user string, password *string, key *str
 	return &SSHClient{client: client}, nil
 }
 
+// Close closes the connection, or returns it to its pool when it was leased from one.
 func (s *SSHClient) Close() error {
+	if s.release != nil {
+		s.release()
+		return nil
+	}
 	return s.client.Close()
 }
 
-func (s *SSHClient) RunCommand(cmd string) (string, error) {
+// newSession opens a session once the session cap allows it, or gives up when ctx is done first.
+// done closes the session and frees its slot.
+func (s *SSHClient) newSession(ctx context.Context) (*ssh.Session, func(), error) {
+	if s.sessions != nil {
+		select {
+		case s.sessions <- struct{}{}:
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		}
+	}
+	free := func() {
+		if s.sessions != nil {
+			<-s.sessions
+		}
+	}
+
 	session, err := s.client.NewSession()
+	if err != nil {
+		free()
+		return nil, nil, err
+	}
+	return session, func() {
+		session.Close()
+		free()
+	}, nil
+}
+
+func (s *SSHClient) RunCommand(cmd string) (string, error) {
+	session, done, err := s.newSession(context.Background())
 	if err != nil {
 		return "", fmt.Errorf("failed to create session: %w", err)
 	}
-	defer session.Close()
+	defer done()
 
 	var stdout, stderr bytes.Buffer
 	session.Stdout = &stdout
@@ -72,11 +111,11 @@ func (s *SSHClient) RunCommand(cmd string) (string, error) {
 }
 
 func (s *SSHClient) RunCommandStream(cmd string, stdoutWriter, stderrWriter io.Writer) error {
-	session, err := s.client.NewSession()
+	session, done, err := s.newSession(context.Background())
 	if err != nil {
 		return fmt.Errorf("failed to create session: %w", err)
 	}
-	defer session.Close()
+	defer done()
 
 	session.Stdout = stdoutWriter
 	session.Stderr = stderrWriter
@@ -88,6 +127,39 @@ func (s *SSHClient) RunCommandStream(cmd string, stdoutWriter, stderrWriter io.W
 	return nil
 }
 
+// RunCommandStreamContext is RunCommandStream for commands that may run until ctx is done, such as
+// ones following a log. The command is signalled and its session closed once ctx is done.
+func (s *SSHClient) RunCommandStreamContext(ctx context.Context, cmd string, stdoutWriter, stderrWriter io.Writer) error {
+	session, done, err := s.newSession(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+	defer done()
+
+	session.Stdout = stdoutWriter
+	session.Stderr = stderrWriter
+
+	if err := session.Start(cmd); err != nil {
+		return fmt.Errorf("failed to run command: %s, err: %w", cmd, err)
+	}
+
+	finished := make(chan error, 1)
+	go func() { finished <- session.Wait() }()
+
+	select {
+	case err := <-finished:
+		if err != nil {
+			return fmt.Errorf("failed to run command: %s, err: %w", cmd, err)
+		}
+		return nil
+	case <-ctx.Done():
+		session.Signal(ssh.SIGTERM)
+		session.Close()
+		<-finished
+		return ctx.Err()
+	}
+}
+
 func (s *SSHClient) CopyFile(srcPath, dstPath string) error {
 	srcFile, err := os.Open(srcPath)
 	if err != nil {
@@ -95,11 +167,11 @@ func (s *SSHClient) CopyFile(srcPath, dstPath string) error {
 	}
 	defer srcFile.Close()
 
-	session, err := s.client.NewSession()
+	session, done, err := s.newSession(context.Background())
 	if err != nil {
 		return fmt.Errorf("failed to create session: %w", err)
 	}
-	defer session.Close()
+	defer done()
 
 	w, err := session.StdinPipe()
 	if err != nil {
@@ -122,11 +194,11 @@ func (s *SSHClient) CopyFile(srcPath, dstPath string) error {
 }
 
 func (s *SSHClient) WriteFile(remotePath string, content []byte, mode os.FileMode) error {
-	session, err := s.client.NewSession()
+	session, done, err := s.newSession(context.Background())
 	if err != nil {
 		return fmt.Errorf("failed to create session: %w", err)
 	}
-	defer session.Close()
+	defer done()
 
 	w, err := session.StdinPipe()
 	if err != nil {
@@ -0,0 +1,376 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// DefaultSSHMaxSessions stays below OpenSSH's default MaxSessions of 10 per connection.
+	DefaultSSHMaxSessions = 8
+	DefaultSSHKeepalive   = 30 * time.Second
+	DefaultSSHIdleTimeout = 5 * time.Minute
+
+	// DefaultSSHMaxDedicated caps the connections per server held by commands that run until cancelled.
+	DefaultSSHMaxDedicated = 4
+
+	sshKeepaliveTimeout = 10 * time.Second
+)
+
+var ErrSSHDedicatedExhausted = errors.New("too many dedicated SSH connections open")
+
+// SSHPool keeps one SSH connection per key, usually a server id, and leases it to concurrent users.
+// Connections are kept alive with keepalive requests, dropped when they die or idle too long, and
+// the sessions opened on a connection at the same time are capped. Commands that run until they are
+// cancelled get a dedicated connection instead, see Dedicated.
+type SSHPool struct {
+	maxSessions  int
+	maxDedicated int
+	keepalive    time.Duration
+	idleTimeout  time.Duration
+
+	mu        sync.Mutex
+	conns     map[string]*pooledSSHConn
+	dialing   map[string]*sync.Mutex
+	dedicated map[string]int
+
+	hits              atomic.Int64
+	dials             atomic.Int64
+	dialFailures      atomic.Int64
+	evictions         atomic.Int64
+	keepaliveFailures atomic.Int64
+
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+type pooledSSHConn struct {
+	client *ssh.Client
+	// fingerprint identifies the address and credentials the connection was dialed with
+	fingerprint string
+	sessions    chan struct{}
+	leases      int
+	evicted     bool
+	lastUsed    time.Time
+	lastAlive   time.Time
+}
+
+// SSHPoolStats is a snapshot of the pool and its counters since it was created.
+type SSHPoolStats struct {
+	Connections       int
+	Leases            int
+	Sessions          int
+	Hits              int64
+	Dials             int64
+	DialFailures      int64
+	Evictions         int64
+	KeepaliveFailures int64
+	Hosts             []SSHPoolHostStats
+}
+
+type SSHPoolHostStats struct {
+	Key      string
+	Leases   int
+	Sessions int
+	LastUsed time.Time
+}
+
+func NewSSHPool(maxSessions, maxDedicated int, keepalive, idleTimeout time.Duration) *SSHPool {
+	p := &SSHPool{
+		maxSessions:  maxSessions,
+		maxDedicated: maxDedicated,
+		keepalive:    keepalive,
+		idleTimeout:  idleTimeout,
+		conns:        make(map[string]*pooledSSHConn),
+		dialing:      make(map[string]*sync.Mutex),
+		dedicated:    make(map[string]int),
+		stop:         make(chan struct{}),
+	}
+	go p.maintain()
+	return p
+}
+
+// Get leases the connection pooled under key. dial is used when nothing is pooled yet, the pooled
+// connection is dead, or it was dialed with another fingerprint. Closing the returned client hands
+// the connection back to the pool.
+func (p *SSHPool) Get(key, fingerprint string, dial func() (*SSHClient, error)) (*SSHClient, error) {
+	if conn := p.pooled(key, fingerprint); conn != nil {
+		p.hits.Add(1)
+		return p.lease(conn), nil
+	}
+
+	// One dial per key at a time, callers arriving meanwhile reuse its connection
+	lock := p.dialLock(key)
+	lock.Lock()
+	defer lock.Unlock()
+
+	if conn := p.pooled(key, fingerprint); conn != nil {
+		p.hits.Add(1)
+		return p.lease(conn), nil
+	}
+
+	p.dials.Add(1)
+	client, err := dial()
+	if err != nil {
+		p.dialFailures.Add(1)
+		return nil, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if existing := p.conns[key]; existing != nil {
+		p.evictLocked(key, existing, false)
+	}
+
+	now := time.Now()
+	conn := &pooledSSHConn{
+		client:      client.client,
+		fingerprint: fingerprint,
+		sessions:    make(chan struct{}, p.maxSessions),
+		leases:      1,
+		lastUsed:    now,
+		lastAlive:   now,
+	}
+	p.conns[key] = conn
+	return p.lease(conn), nil
+}
+
+// Dedicated dials a connection of its own for key, outside the pool, for commands that run until
+// they are cancelled such as log follows, which would otherwise hold the sessions of the pooled
+// connection. At most maxDedicated are open per key at a time. Closing the returned client closes
+// the connection.
+func (p *SSHPool) Dedicated(key string, dial func() (*SSHClient, error)) (*SSHClient, error) {
+	p.mu.Lock()
+	if p.dedicated[key] >= p.maxDedicated {
+		p.mu.Unlock()
+		return nil, fmt.Errorf("%w to %s", ErrSSHDedicatedExhausted, key)
+	}
+	p.dedicated[key]++
+	p.mu.Unlock()
+
+	p.dials.Add(1)
+	client, err := dial()
+	if err != nil {
+		p.dialFailures.Add(1)
+		p.releaseDedicated(key)
+		return nil, err
+	}
+
+	var once sync.Once
+	client.release = func() {
+		once.Do(func() {
+			client.client.Close()
+			p.releaseDedicated(key)
+		})
+	}
+	return client, nil
+}
+
+// Evict drops the connection pooled under key. Leases still running keep using it until they are closed.
+func (p *SSHPool) Evict(key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if conn := p.conns[key]; conn != nil {
+		p.evictLocked(key, conn, false)
+	}
+}
+
+func (p *SSHPool) Stats() SSHPoolStats {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stats := SSHPoolStats{
+		Connections:       len(p.conns),
+		Hits:              p.hits.Load(),
+		Dials:             p.dials.Load(),
+		DialFailures:      p.dialFailures.Load(),
+		Evictions:         p.evictions.Load(),
+		KeepaliveFailures: p.keepaliveFailures.Load(),
+	}
+	for key, conn := range p.conns {
+		stats.Leases += conn.leases
+		stats.Sessions += len(conn.sessions)
+		stats.Hosts = append(stats.Hosts, SSHPoolHostStats{
+			Key:      key,
+			Leases:   conn.leases,
+			Sessions: len(conn.sessions),
+			LastUsed: conn.lastUsed,
+		})
+	}
+	sort.Slice(stats.Hosts, func(i, j int) bool {
+		return stats.Hosts[i].Key < stats.Hosts[j].Key
+	})
+	return stats
+}
+
+// Close stops the keepalives and drops every pooled connection.
+func (p *SSHPool) Close() {
+	p.stopOnce.Do(func() {
+		close(p.stop)
+	})
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for key, conn := range p.conns {
+		p.evictLocked(key, conn, false)
+	}
+}
+
+func (p *SSHPool) dialLock(key string) *sync.Mutex {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	lock, ok := p.dialing[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		p.dialing[key] = lock
+	}
+	return lock
+}
+
+// pooled returns the live connection under key with a lease taken on it, or nil.
+func (p *SSHPool) pooled(key, fingerprint string) *pooledSSHConn {
+	p.mu.Lock()
+	conn := p.conns[key]
+	if conn != nil && conn.fingerprint != fingerprint {
+		p.evictLocked(key, conn, false)
+		conn = nil
+	}
+	if conn == nil {
+		p.mu.Unlock()
+		return nil
+	}
+	stale := time.Since(conn.lastAlive) > p.keepalive
+	p.mu.Unlock()
+
+	// A connection not heard from in a while is checked first, it may have died since.
+	alive := !stale || sshAlive(conn.client)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if !alive {
+		p.keepaliveFailures.Add(1)
+		if p.conns[key] == conn {
+			p.evictLocked(key, conn, true)
+		}
+		return nil
+	}
+	if conn.evicted {
+		return nil
+	}
+	conn.lastAlive = time.Now()
+	conn.leases++
+	return conn
+}
+
+// lease wraps the pooled connection in a client whose Close returns it. The lease must already be counted.
+func (p *SSHPool) lease(conn *pooledSSHConn) *SSHClient {
+	var once sync.Once
+	return &SSHClient{
+		client:   conn.client,
+		sessions: conn.sessions,
+		release: func() {
+			once.Do(func() {
+				p.release(conn)
+			})
+		},
+	}
+}
+
+func (p *SSHPool) release(conn *pooledSSHConn) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	conn.leases--
+	conn.lastUsed = time.Now()
+	if conn.evicted && conn.leases == 0 {
+		conn.client.Close()
+	}
+}
+
+func (p *SSHPool) releaseDedicated(key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.dedicated[key]--; p.dedicated[key] <= 0 {
+		delete(p.dedicated, key)
+	}
+}
+
+// evictLocked removes the connection from the pool. It is closed once its last lease is returned,
+// or right away when dead, so commands stuck on it fail instead of hanging.
+func (p *SSHPool) evictLocked(key string, conn *pooledSSHConn, dead bool) {
+	if p.conns[key] == conn {
+		delete(p.conns, key)
+	}
+	if !conn.evicted {
+		conn.evicted = true
+		p.evictions.Add(1)
+	}
+	if dead || conn.leases == 0 {
+		conn.client.Close()
+	}
+}
+
+// maintain sends keepalives on every pooled connection and drops dead and idle ones.
+func (p *SSHPool) maintain() {
+	ticker := time.NewTicker(p.keepalive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		}
+
+		p.mu.Lock()
+		conns := make(map[string]*pooledSSHConn, len(p.conns))
+		for key, conn := range p.conns {
+			if conn.leases == 0 && time.Since(conn.lastUsed) > p.idleTimeout {
+				p.evictLocked(key, conn, false)
+				continue
+			}
+			conns[key] = conn
+		}
+		p.mu.Unlock()
+
+		for key, conn := range conns {
+			alive := sshAlive(conn.client)
+
+			p.mu.Lock()
+			if alive {
+				conn.lastAlive = time.Now()
+			} else {
+				p.keepaliveFailures.Add(1)
+				p.evictLocked(key, conn, true)
+			}
+			p.mu.Unlock()
+		}
+	}
+}
+
+// sshAlive sends a keepalive request and reports whether the server answered in time.
+func sshAlive(client *ssh.Client) bool {
+	done := make(chan error, 1)
+	go func() {
+		_, _, err := client.SendRequest("keepalive@openssh.com", true, nil)
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		return err == nil
+	case <-time.After(sshKeepaliveTimeout):
+		return false
+	}
+}
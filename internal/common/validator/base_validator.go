@@ -39,6 +39,27 @@ func (b *BaseValidator) ValidateStruct(value interface{}) []*pbValidation.Valida
 	return nil
 }
 
+// ValidateVar validates a single value against tag and reports errors under the given field name.
+func (b *BaseValidator) ValidateVar(field string, value interface{}, tag string) []*pbValidation.ValidationError {
+	err := b.validate.Var(value, tag)
+	if err == nil {
+		return nil
+	}
+
+	var errors []*pbValidation.ValidationError
+	if validationErrors, ok := err.(validator.ValidationErrors); ok {
+		for _, fieldErr := range validationErrors {
+			named := namedFieldError{FieldError: fieldErr, name: field}
+			errors = append(errors, &pbValidation.ValidationError{
+				Field:   field,
+				Message: b.getErrorMessage(named),
+			})
+		}
+	}
+
+	return errors
+}
+
 // RegisterValidation exposes validator.RegisterValidation to consumers.
 func (b *BaseValidator) RegisterValidation(tag string, fn validator.Func) error {
 	return b.validate.RegisterValidation(tag, fn)
@@ -73,6 +94,16 @@ func (b *BaseValidator) getErrorMessage(fieldErr validator.FieldError) string {
 	return fmt.Sprintf("%s is invalid", fieldErr.Field())
 }
 
+// namedFieldError overrides the field name of errors produced by validator.Var, which has none.
+type namedFieldError struct {
+	validator.FieldError
+	name string
+}
+
+func (e namedFieldError) Field() string {
+	return e.name
+}
+
 func defaultTagMessages() map[string]messageFunc {
 	return map[string]messageFunc{
 		"required": func(e validator.FieldError) string {
@@ -90,5 +121,11 @@ func defaultTagMessages() map[string]messageFunc {
 		"oneof": func(e validator.FieldError) string {
 			return fmt.Sprintf("%s must be one of: %s", e.Field(), e.Param())
 		},
+		"numeric": func(e validator.FieldError) string {
+			return fmt.Sprintf("%s must be a number", e.Field())
+		},
+		"boolean": func(e validator.FieldError) string {
+			return fmt.Sprintf("%s must be true or false", e.Field())
+		},
 	}
 }
@@ -0,0 +1,134 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zhinea/sylix/internal/common/util"
+)
+
+const (
+	// DefaultCheckTimeout is how long wait_for_port and http_check wait when the step sets no timeout.
+	DefaultCheckTimeout = time.Minute
+
+	checkInterval = 2 * time.Second
+)
+
+func registerBuiltins(r *Registry) {
+	r.Register(ActionCommand, ActionFunc(runCommand))
+	r.Register(ActionWriteFile, ActionFunc(writeFile))
+	r.Register(ActionCopyFile, ActionFunc(copyFile))
+	r.Register(ActionTemplate, ActionFunc(renderTemplate))
+	r.Register(ActionWaitForPort, ActionFunc(waitForPort))
+	r.Register(ActionHTTPCheck, ActionFunc(httpCheck))
+}
+
+func runCommand(ctx context.Context, env *Env, step Step) error {
+	return env.Client.RunCommandStream(step.Command, env.Out, env.Err)
+}
+
+func writeFile(ctx context.Context, env *Env, step Step) error {
+	return env.Client.WriteFile(step.DestPath, []byte(step.Content), fileMode(step))
+}
+
+func copyFile(ctx context.Context, env *Env, step Step) error {
+	if err := env.Client.CopyFile(step.SourcePath, step.DestPath); err != nil {
+		return fmt.Errorf("failed to copy file to remote: %w", err)
+	}
+	return nil
+}
+
+func renderTemplate(ctx context.Context, env *Env, step Step) error {
+	content, err := templateContent(env.Vars, env.Host, step)
+	if err != nil {
+		return err
+	}
+	return env.Client.WriteFile(step.DestPath, []byte(content), fileMode(step))
+}
+
+// templateContent renders the content of a template step, with its params on top of the variables.
+func templateContent(vars *Vars, host string, step Step) (string, error) {
+	data := vars.Data(host)
+	for name, value := range step.Params {
+		data[name] = value
+	}
+	return expand(step.Content, data)
+}
+
+func waitForPort(ctx context.Context, env *Env, step Step) error {
+	host := step.Host
+	if host == "" {
+		host = "127.0.0.1"
+	}
+	probe := fmt.Sprintf("exec 3<>/dev/tcp/%s/%d", host, step.Port)
+
+	return poll(ctx, step, fmt.Sprintf("port %s:%d", host, step.Port), func() (bool, string) {
+		if _, err := env.Client.RunCommand("timeout 5 bash -c " + util.ShellQuote(probe)); err != nil {
+			return false, "not accepting connections"
+		}
+		return true, ""
+	})
+}
+
+func httpCheck(ctx context.Context, env *Env, step Step) error {
+	expect := step.ExpectStatus
+	if expect == 0 {
+		expect = 200
+	}
+	cmd := "curl -s -o /dev/null -w '%{http_code}' --max-time 5 " + util.ShellQuote(step.URL)
+
+	return poll(ctx, step, step.URL, func() (bool, string) {
+		// curl fails on connection errors, its output is 000 then
+		output, _ := env.Client.RunCommand(cmd)
+		status, err := strconv.Atoi(strings.TrimSpace(output))
+		if err != nil || status == 0 {
+			return false, "no response"
+		}
+		if status != expect {
+			return false, fmt.Sprintf("status %d", status)
+		}
+		return true, ""
+	})
+}
+
+// poll calls check until it succeeds or the step's timeout passes. The reason of the last failed
+// check ends up in the error.
+func poll(ctx context.Context, step Step, subject string, check func() (bool, string)) error {
+	timeout := step.Timeout
+	if timeout == 0 {
+		timeout = DefaultCheckTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(checkInterval)
+	defer ticker.Stop()
+
+	for {
+		ok, reason := check()
+		if ok {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				return fmt.Errorf("%s not ready in %s: %s", subject, timeout, reason)
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func fileMode(step Step) os.FileMode {
+	if step.Mode == 0 {
+		return 0644
+	}
+	return step.Mode
+}
@@ -0,0 +1,75 @@
+package workflow
+
+import (
+	"fmt"
+)
+
+// graph returns the indexes of the steps each step of the workflow waits for. It fails on unknown
+// and duplicate step references and on cycles.
+func graph(wf Workflow) ([][]int, error) {
+	deps := make([][]int, len(wf.Steps))
+
+	declared := false
+	for _, step := range wf.Steps {
+		if len(step.DependsOn) > 0 {
+			declared = true
+			break
+		}
+	}
+	// Without declared dependencies every step waits for the one before it
+	if !declared {
+		for i := 1; i < len(wf.Steps); i++ {
+			deps[i] = []int{i - 1}
+		}
+		return deps, nil
+	}
+
+	index := make(map[string]int, len(wf.Steps))
+	for i, step := range wf.Steps {
+		if _, ok := index[step.key()]; ok {
+			return nil, fmt.Errorf("duplicate step id: %s", step.key())
+		}
+		index[step.key()] = i
+	}
+
+	for i, step := range wf.Steps {
+		for _, dep := range step.DependsOn {
+			j, ok := index[dep]
+			if !ok {
+				return nil, fmt.Errorf("step '%s' depends on unknown step: %s", step.Name, dep)
+			}
+			deps[i] = append(deps[i], j)
+		}
+	}
+
+	// Depth-first search for cycles
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make([]int, len(wf.Steps))
+	var visit func(i int) error
+	visit = func(i int) error {
+		switch state[i] {
+		case visiting:
+			return fmt.Errorf("step '%s' depends on itself", wf.Steps[i].Name)
+		case visited:
+			return nil
+		}
+		state[i] = visiting
+		for _, j := range deps[i] {
+			if err := visit(j); err != nil {
+				return err
+			}
+		}
+		state[i] = visited
+		return nil
+	}
+	for i := range wf.Steps {
+		if err := visit(i); err != nil {
+			return nil, err
+		}
+	}
+	return deps, nil
+}
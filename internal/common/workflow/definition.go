@@ -0,0 +1,386 @@
+package workflow
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Definition is a workflow declared in YAML, for recipes operators add without recompiling:
+//
+//	name: postgres-kernel-tuning
+//	description: Tune the kernel of a server running Postgres
+//	params:
+//	  - name: shared_memory
+//	    default: "8589934592"
+//	steps:
+//	  - name: Set shmmax
+//	    action: command
+//	    command: sysctl -w kernel.shmmax={{.shared_memory}}
+type Definition struct {
+	Name        string
+	Description string
+	// Params are the variables a run is given, on top of the vars of the workflow
+	Params []Param
+	// Hosts are the hosts the steps name, each a server a run has to be given
+	Hosts    []string
+	Workflow Workflow
+}
+
+// Param is a variable a run of a definition is given.
+type Param struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Required    bool   `yaml:"required"`
+	Default     string `yaml:"default"`
+	// Secret redacts the value from the logs
+	Secret bool `yaml:"secret"`
+}
+
+type definitionFile struct {
+	Name        string            `yaml:"name"`
+	Description string            `yaml:"description"`
+	Params      []Param           `yaml:"params"`
+	Vars        map[string]string `yaml:"vars"`
+	Steps       []stepFile        `yaml:"steps"`
+}
+
+type stepFile struct {
+	ID           string            `yaml:"id"`
+	Name         string            `yaml:"name"`
+	Action       ActionType        `yaml:"action"`
+	DependsOn    []string          `yaml:"depends_on"`
+	Hosts        []string          `yaml:"hosts"`
+	Command      string            `yaml:"command"`
+	Content      string            `yaml:"content"`
+	Mode         string            `yaml:"mode"`
+	Source       string            `yaml:"source"`
+	Dest         string            `yaml:"dest"`
+	Host         string            `yaml:"host"`
+	Port         int               `yaml:"port"`
+	URL          string            `yaml:"url"`
+	ExpectStatus int               `yaml:"expect_status"`
+	Timeout      string            `yaml:"timeout"`
+	Method       string            `yaml:"method"`
+	Params       map[string]string `yaml:"params"`
+	Output       string            `yaml:"output"`
+	Secret       bool              `yaml:"secret"`
+	Stage        string            `yaml:"stage"`
+	Retry        *retryFile        `yaml:"retry"`
+	Rollback     *stepFile         `yaml:"rollback"`
+	Condition    string            `yaml:"condition"`
+	IgnoreError  bool              `yaml:"ignore_error"`
+}
+
+type retryFile struct {
+	Attempts   int    `yaml:"attempts"`
+	Backoff    string `yaml:"backoff"`
+	MaxBackoff string `yaml:"max_backoff"`
+}
+
+// LoadDefinition reads and validates the definition at path.
+func LoadDefinition(path string, registry *Registry) (*Definition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workflow definition: %w", err)
+	}
+	def, err := ParseDefinition(data, filepath.Dir(path), registry)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return def, nil
+}
+
+// LoadDefinitions reads the definitions in the .yaml and .yml files of dir. A missing dir has none.
+func LoadDefinitions(dir string, registry *Registry) ([]*Definition, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read workflow definitions: %w", err)
+	}
+
+	var defs []*Definition
+	names := make(map[string]string)
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+
+		path := filepath.Join(dir, entry.Name())
+		def, err := LoadDefinition(path, registry)
+		if err != nil {
+			return nil, err
+		}
+		if other, ok := names[def.Name]; ok {
+			return nil, fmt.Errorf("workflow '%s' is defined in both %s and %s", def.Name, other, path)
+		}
+		names[def.Name] = path
+		defs = append(defs, def)
+	}
+	return defs, nil
+}
+
+// ParseDefinition decodes a definition and validates it against its schema and the actions of registry.
+// The sources of copy_file steps are relative to dir and may not leave it, the controlplane's own
+// files being out of reach of the definitions.
+func ParseDefinition(data []byte, dir string, registry *Registry) (*Definition, error) {
+	var file definitionFile
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	// Misspelled keys would silently change what a step does
+	dec.KnownFields(true)
+	if err := dec.Decode(&file); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("workflow definition is empty")
+		}
+		return nil, fmt.Errorf("failed to parse workflow definition: %w", err)
+	}
+
+	if file.Name == "" {
+		return nil, fmt.Errorf("workflow definition: name is required")
+	}
+	if !validName(file.Name) {
+		return nil, fmt.Errorf("workflow definition: invalid name %q, expected letters, digits, '-' and '_'", file.Name)
+	}
+	if len(file.Steps) == 0 {
+		return nil, fmt.Errorf("workflow '%s': no steps", file.Name)
+	}
+
+	def := &Definition{
+		Name:        file.Name,
+		Description: file.Description,
+		Params:      file.Params,
+		Workflow: Workflow{
+			Name: file.Name,
+			Vars: file.Vars,
+		},
+	}
+
+	params := make(map[string]bool, len(file.Params))
+	for _, param := range file.Params {
+		if param.Name == "" {
+			return nil, fmt.Errorf("workflow '%s': param without name", file.Name)
+		}
+		if params[param.Name] {
+			return nil, fmt.Errorf("workflow '%s': duplicate param: %s", file.Name, param.Name)
+		}
+		params[param.Name] = true
+	}
+
+	hosts := make(map[string]bool)
+	for i := range file.Steps {
+		step, err := file.Steps[i].step(dir)
+		if err != nil {
+			return nil, fmt.Errorf("workflow '%s': step %d: %w", file.Name, i+1, err)
+		}
+		for _, host := range step.Hosts {
+			if !hosts[host] {
+				hosts[host] = true
+				def.Hosts = append(def.Hosts, host)
+			}
+		}
+		def.Workflow.Steps = append(def.Workflow.Steps, step)
+	}
+	sort.Strings(def.Hosts)
+
+	if err := registry.Validate(def.Workflow); err != nil {
+		return nil, fmt.Errorf("workflow '%s': %w", file.Name, err)
+	}
+	if _, err := graph(def.Workflow); err != nil {
+		return nil, fmt.Errorf("workflow '%s': %w", file.Name, err)
+	}
+	return def, nil
+}
+
+// Vars returns the variables and secrets a run of the definition starts with, given the values of
+// its params. Unknown and missing required params are errors.
+func (d *Definition) Vars(values map[string]string) (map[string]string, map[string]string, error) {
+	declared := make(map[string]Param, len(d.Params))
+	for _, param := range d.Params {
+		declared[param.Name] = param
+	}
+	for name := range values {
+		if _, ok := declared[name]; !ok {
+			return nil, nil, fmt.Errorf("unknown param: %s", name)
+		}
+	}
+
+	vars := make(map[string]string, len(d.Workflow.Vars)+len(d.Params))
+	for name, value := range d.Workflow.Vars {
+		vars[name] = value
+	}
+	secrets := make(map[string]string)
+	for _, param := range d.Params {
+		value, ok := values[param.Name]
+		if !ok || value == "" {
+			if param.Required {
+				return nil, nil, fmt.Errorf("param %s is required", param.Name)
+			}
+			value = param.Default
+		}
+		if param.Secret {
+			secrets[param.Name] = value
+		} else {
+			vars[param.Name] = value
+		}
+	}
+	return vars, secrets, nil
+}
+
+func (f *stepFile) step(dir string) (Step, error) {
+	if f.Name == "" {
+		return Step{}, fmt.Errorf("name is required")
+	}
+	if f.Action == "" {
+		return Step{}, fmt.Errorf("'%s': action is required", f.Name)
+	}
+	if err := f.validate(); err != nil {
+		return Step{}, fmt.Errorf("'%s': %w", f.Name, err)
+	}
+
+	step := Step{
+		ID:           f.ID,
+		Name:         f.Name,
+		Action:       f.Action,
+		DependsOn:    f.DependsOn,
+		Hosts:        f.Hosts,
+		Command:      f.Command,
+		Content:      f.Content,
+		DestPath:     f.Dest,
+		Host:         f.Host,
+		Port:         f.Port,
+		URL:          f.URL,
+		ExpectStatus: f.ExpectStatus,
+		Method:       f.Method,
+		Params:       f.Params,
+		Output:       f.Output,
+		Secret:       f.Secret,
+		Stage:        f.Stage,
+		Condition:    f.Condition,
+		IgnoreError:  f.IgnoreError,
+	}
+
+	if f.Source != "" {
+		if !filepath.IsLocal(f.Source) {
+			return Step{}, fmt.Errorf("'%s': source %q must be a path inside the workflows directory", f.Name, f.Source)
+		}
+		step.SourcePath = filepath.Join(dir, f.Source)
+	}
+
+	var err error
+	if f.Mode != "" {
+		mode, err := strconv.ParseUint(f.Mode, 8, 32)
+		if err != nil || mode > 0777 {
+			return Step{}, fmt.Errorf("'%s': invalid mode %q, expected octal permissions such as 0644", f.Name, f.Mode)
+		}
+		step.Mode = os.FileMode(mode)
+	}
+	if step.Timeout, err = parseDuration(f.Timeout); err != nil {
+		return Step{}, fmt.Errorf("'%s': timeout: %w", f.Name, err)
+	}
+
+	if f.Retry != nil {
+		if f.Retry.Attempts < 1 {
+			return Step{}, fmt.Errorf("'%s': retry attempts must be positive", f.Name)
+		}
+		step.Retry.Attempts = f.Retry.Attempts
+		if step.Retry.Backoff, err = parseDuration(f.Retry.Backoff); err != nil {
+			return Step{}, fmt.Errorf("'%s': retry backoff: %w", f.Name, err)
+		}
+		if step.Retry.MaxBackoff, err = parseDuration(f.Retry.MaxBackoff); err != nil {
+			return Step{}, fmt.Errorf("'%s': retry max_backoff: %w", f.Name, err)
+		}
+	}
+
+	if f.Rollback != nil {
+		if f.Rollback.Name == "" {
+			f.Rollback.Name = "Undo " + f.Name
+		}
+		if len(f.Rollback.DependsOn) > 0 || len(f.Rollback.Hosts) > 0 || f.Rollback.Rollback != nil {
+			return Step{}, fmt.Errorf("'%s': a rollback runs where its step ran, without depends_on, hosts or rollback", f.Name)
+		}
+		rollback, err := f.Rollback.step(dir)
+		if err != nil {
+			return Step{}, fmt.Errorf("'%s': rollback: %w", f.Name, err)
+		}
+		step.Rollback = &rollback
+	}
+	return step, nil
+}
+
+// validate checks that the step has the fields its action needs. Actions registered by other modules
+// are only checked for existence.
+func (f *stepFile) validate() error {
+	switch f.Action {
+	case ActionCommand:
+		if strings.TrimSpace(f.Command) == "" {
+			return fmt.Errorf("command is required")
+		}
+	case ActionWriteFile, ActionTemplate:
+		if f.Dest == "" {
+			return fmt.Errorf("dest is required")
+		}
+	case ActionCopyFile:
+		if f.Source == "" || f.Dest == "" {
+			return fmt.Errorf("source and dest are required")
+		}
+	case ActionWaitForPort:
+		if f.Port < 1 || f.Port > 65535 {
+			return fmt.Errorf("port must be between 1 and 65535")
+		}
+	case ActionHTTPCheck:
+		if f.URL == "" {
+			return fmt.Errorf("url is required")
+		}
+	case ActionAgentRPC:
+		if f.Method == "" {
+			return fmt.Errorf("method is required")
+		}
+	}
+
+	if f.Secret && f.Output == "" {
+		return fmt.Errorf("secret without output")
+	}
+	for _, host := range f.Hosts {
+		if host == "" || strings.Contains(host, ".") {
+			return fmt.Errorf("invalid host name %q", host)
+		}
+	}
+	return nil
+}
+
+// parseDuration parses durations such as 30s, an empty string being zero.
+func parseDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, err
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("negative duration %s", s)
+	}
+	return d, nil
+}
+
+// validName reports whether name is fit to identify a workflow, also in file names.
+func validName(name string) bool {
+	for _, r := range name {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
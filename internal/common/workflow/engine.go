@@ -0,0 +1,532 @@
+package workflow
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zhinea/sylix/internal/common/logger"
+	"github.com/zhinea/sylix/internal/common/util"
+	"go.uber.org/zap"
+)
+
+// DefaultConcurrency is how many steps an engine runs at the same time unless set otherwise.
+const DefaultConcurrency = 4
+
+type Engine struct {
+	client      *util.SSHClient
+	logWriter   io.Writer
+	logFn       func(string)
+	onStage     func(string)
+	onStep      func(StepEvent)
+	target      string
+	hosts       map[string]host
+	concurrency int
+	registry    *Registry
+	store       Store
+	// vars are the variables of the current or last run
+	vars *Vars
+	// logMu serializes logFn, steps log from several goroutines
+	logMu sync.Mutex
+}
+
+// host is a server steps can run on besides the engine's own.
+type host struct {
+	client *util.SSHClient
+	target string
+}
+
+// execution is a step running on one host.
+type execution struct {
+	step  Step
+	state *StepRun
+	out   *lineWriter
+	err   error
+	// skipped is set when the condition of the step was not met
+	skipped bool
+}
+
+func NewEngine(client *util.SSHClient, logWriter io.Writer, logFn func(string)) *Engine {
+	return &Engine{
+		client:      client,
+		logWriter:   logWriter,
+		logFn:       logFn,
+		hosts:       make(map[string]host),
+		concurrency: DefaultConcurrency,
+		registry:    DefaultRegistry,
+	}
+}
+
+// OnStage registers fn to be called with the stage of a step before the first step of each stage runs.
+func (e *Engine) OnStage(fn func(stage string)) {
+	e.onStage = fn
+}
+
+// OnStep registers fn to be called whenever a step starts, finishes or is rolled back on a host.
+// It is called from one goroutine at a time.
+func (e *Engine) OnStep(fn func(event StepEvent)) {
+	e.onStep = fn
+}
+
+// SetTarget sets what actions reaching the server other than over SSH identify it by.
+func (e *Engine) SetTarget(target string) {
+	e.target = target
+}
+
+// AddHost adds a server steps can name in their Hosts. target is what actions reaching it other
+// than over SSH identify it by.
+func (e *Engine) AddHost(name string, client *util.SSHClient, target string) {
+	e.hosts[name] = host{client: client, target: target}
+}
+
+// SetConcurrency bounds how many steps, counting every host a step runs on, run at the same time.
+func (e *Engine) SetConcurrency(n int) {
+	if n < 1 {
+		n = 1
+	}
+	e.concurrency = n
+}
+
+// SetRegistry makes the engine look actions up in registry instead of the DefaultRegistry.
+func (e *Engine) SetRegistry(registry *Registry) {
+	e.registry = registry
+}
+
+// SetStore makes the engine record its runs and the state of their steps in store as they progress.
+func (e *Engine) SetStore(store Store) {
+	e.store = store
+}
+
+// Var returns the value of a variable at the end of the last run, such as the output of a step.
+func (e *Engine) Var(name string) (string, bool) {
+	if e.vars == nil {
+		return "", false
+	}
+	return e.vars.Get(name)
+}
+
+func (e *Engine) Run(ctx context.Context, wf Workflow) error {
+	return e.execute(ctx, wf, NewRun(wf, e.target))
+}
+
+// Resume continues an interrupted run of the workflow, skipping the steps that were done already.
+// The workflow must have the steps the run was started with.
+func (e *Engine) Resume(ctx context.Context, wf Workflow, run *Run) error {
+	// The steps of the run may come back from the store in any order within a step
+	type key struct {
+		index      int
+		name, host string
+	}
+	expected := make(map[key]bool)
+	for _, state := range NewRun(wf, run.Target).Steps {
+		expected[key{state.Index, state.Name, state.Host}] = true
+	}
+	if len(run.Steps) != len(expected) {
+		return e.finish(ctx, run, fmt.Errorf("workflow '%s' changed since the run started", wf.Name))
+	}
+	for _, state := range run.Steps {
+		if !expected[key{state.Index, state.Name, state.Host}] {
+			return e.finish(ctx, run, fmt.Errorf("workflow '%s' changed since the run started", wf.Name))
+		}
+	}
+
+	run.Status = RunRunning
+	run.Error = ""
+	return e.execute(ctx, wf, run)
+}
+
+func (e *Engine) execute(ctx context.Context, wf Workflow, run *Run) error {
+	e.vars = NewVars()
+	for name, value := range wf.Vars {
+		e.vars.Set(name, value, false)
+	}
+	for name, value := range wf.Secrets {
+		e.vars.Set(name, value, true)
+	}
+	// Outputs of the steps done before the run was interrupted
+	for _, state := range run.Steps {
+		if step := wf.Steps[state.Index]; state.done() && step.Output != "" {
+			e.vars.SetOutput(state.Host, step.Output, state.Output, step.Secret)
+		}
+	}
+
+	// Refuse broken workflows before anything ran on a server
+	deps, err := e.validate(wf)
+	if err != nil {
+		return e.finish(ctx, run, err)
+	}
+	e.saveRun(ctx, run)
+
+	if run.started() {
+		e.log(fmt.Sprintf("Resuming workflow: %s", wf.Name))
+	} else {
+		e.log(fmt.Sprintf("Starting workflow: %s", wf.Name))
+	}
+
+	sink := &sink{w: e.logWriter}
+	states := make([][]*StepRun, len(wf.Steps))
+	for _, state := range run.Steps {
+		states[state.Index] = append(states[state.Index], state)
+	}
+
+	done := func(i int) bool {
+		for _, state := range states[i] {
+			if !state.done() {
+				return false
+			}
+		}
+		return true
+	}
+
+	var (
+		queued    = make([]bool, len(wf.Steps))
+		ready     []*execution
+		results   = make(chan *execution)
+		running   int
+		completed []*execution
+		failure   error
+		stages    = make(map[string]bool)
+	)
+
+	// Steps that succeeded before the run was interrupted are rolled back on a failure as well, in
+	// the order they finished
+	for _, state := range run.Steps {
+		if state.Status == StepSucceeded {
+			completed = append(completed, &execution{step: wf.Steps[state.Index], state: state, out: newLineWriter(sink, e.vars, state.Host)})
+		}
+	}
+	sort.SliceStable(completed, func(i, j int) bool {
+		return completed[i].state.FinishedAt.Before(completed[j].state.FinishedAt)
+	})
+
+	for {
+		if failure == nil && ctx.Err() == nil {
+			// Queue the steps whose dependencies are done
+			for i := range wf.Steps {
+				if queued[i] || done(i) {
+					continue
+				}
+				waiting := false
+				for _, j := range deps[i] {
+					if !done(j) {
+						waiting = true
+						break
+					}
+				}
+				if waiting {
+					continue
+				}
+
+				queued[i] = true
+				for _, state := range states[i] {
+					if !state.done() {
+						ready = append(ready, &execution{step: wf.Steps[i], state: state, out: newLineWriter(sink, e.vars, state.Host)})
+					}
+				}
+			}
+
+			for running < e.concurrency && len(ready) > 0 {
+				ex := ready[0]
+				ready = ready[1:]
+				running++
+
+				if stage := ex.step.Stage; stage != "" && !stages[stage] {
+					stages[stage] = true
+					if e.onStage != nil {
+						e.onStage(stage)
+					}
+				}
+				e.logStep(ex, fmt.Sprintf("[Step %d/%d] %s", ex.state.Index+1, len(wf.Steps), ex.step.Name))
+
+				ex.state.Status = StepRunning
+				ex.state.StartedAt = time.Now()
+				e.saveStep(ctx, run, ex.state)
+				e.emit(ex.state)
+
+				go func() {
+					ex.skipped, ex.err = e.runStep(ctx, e.env(ex), ex.step, func() {
+						ex.state.Attempts++
+						e.saveStep(ctx, run, ex.state)
+					})
+					results <- ex
+				}()
+			}
+		}
+
+		if running == 0 {
+			break
+		}
+
+		ex := <-results
+		running--
+		ex.out.Flush()
+
+		step, state := ex.step, ex.state
+		state.FinishedAt = time.Now()
+		switch {
+		case ex.skipped:
+			e.logStep(ex, fmt.Sprintf("Skipping step '%s' (condition not met)", step.Name))
+			state.Status = StepSkipped
+		case ex.err == nil:
+			state.Status = StepSucceeded
+			if step.Output != "" {
+				state.Output, _ = e.vars.Get(outputName(state.Host, step.Output))
+			}
+			completed = append(completed, ex)
+		case step.IgnoreError:
+			e.logStep(ex, fmt.Sprintf("Step '%s' failed but marked to ignore error: %v", step.Name, ex.err))
+			state.Status = StepIgnored
+			state.Error = e.redact(ex.err).Error()
+		default:
+			state.Status = StepFailed
+			state.Error = e.redact(ex.err).Error()
+			// Steps already running finish, no further step starts
+			if failure == nil {
+				failure = fmt.Errorf("step '%s' failed: %w", step.Name, ex.err)
+				if state.Host != "" {
+					failure = fmt.Errorf("step '%s' failed on %s: %w", step.Name, state.Host, ex.err)
+				}
+			}
+		}
+		e.saveStep(ctx, run, state)
+		e.emit(state)
+	}
+
+	if failure != nil {
+		e.rollback(ctx, run, completed)
+		return e.finish(ctx, run, failure)
+	}
+	if err := ctx.Err(); err != nil {
+		return e.finish(ctx, run, err)
+	}
+
+	e.log(fmt.Sprintf("Workflow '%s' completed successfully.", wf.Name))
+	return e.finish(ctx, run, nil)
+}
+
+// validate checks the actions and hosts of the steps and returns the dependencies of each step.
+func (e *Engine) validate(wf Workflow) ([][]int, error) {
+	if err := e.registry.Validate(wf); err != nil {
+		return nil, err
+	}
+	for _, step := range wf.Steps {
+		seen := make(map[string]bool, len(step.Hosts))
+		for _, name := range step.Hosts {
+			if _, ok := e.hosts[name]; !ok {
+				return nil, fmt.Errorf("step '%s': unknown host: %s", step.Name, name)
+			}
+			if seen[name] {
+				return nil, fmt.Errorf("step '%s': duplicate host: %s", step.Name, name)
+			}
+			seen[name] = true
+		}
+	}
+	return graph(wf)
+}
+
+// env returns what the action of the execution runs with.
+func (e *Engine) env(ex *execution) *Env {
+	h := e.host(ex.state.Host)
+	env := &Env{
+		Host:   ex.state.Host,
+		Client: h.client,
+		Target: h.target,
+		Out:    ex.out,
+		Err:    ex.out,
+		Log: func(msg string) {
+			e.logStep(ex, msg)
+		},
+		Vars: e.vars,
+	}
+	return env
+}
+
+// host returns the host of the given name, the engine's own for "".
+func (e *Engine) host(name string) host {
+	if name == "" {
+		return host{client: e.client, target: e.target}
+	}
+	return e.hosts[name]
+}
+
+// runStep renders the step with the variables, checks its condition and runs its action, again after
+// a backoff as long as its retry policy allows. attempt is called before every attempt. On success
+// the output of the step is stored in its output variable.
+func (e *Engine) runStep(ctx context.Context, env *Env, step Step, attempt func()) (bool, error) {
+	step, err := e.expand(env.Host, step)
+	if err != nil {
+		return false, err
+	}
+
+	// A non-zero exit code of the condition skips the step
+	if step.Condition != "" {
+		if _, err := env.Client.RunCommand(step.Condition); err != nil {
+			return true, nil
+		}
+	}
+
+	action, _ := e.registry.Lookup(step.Action)
+	for n := 1; ; n++ {
+		attempt()
+
+		var stdout bytes.Buffer
+		stepEnv := *env
+		if step.Output != "" {
+			// A secret output never reaches the log, it is only known to be redacted once it is set
+			if step.Secret {
+				stepEnv.Out = &stdout
+			} else {
+				stepEnv.Out = io.MultiWriter(env.Out, &stdout)
+			}
+		}
+
+		err := action.Execute(ctx, &stepEnv, step)
+		if err == nil && step.Output != "" {
+			e.vars.SetOutput(env.Host, step.Output, strings.TrimSpace(stdout.String()), step.Secret)
+		}
+		if err == nil || n >= step.Retry.Attempts {
+			return false, err
+		}
+
+		delay := step.Retry.delay(n)
+		env.Log(fmt.Sprintf("Step '%s' failed (attempt %d/%d), retrying in %s: %v", step.Name, n, step.Retry.Attempts, delay, err))
+
+		select {
+		case <-ctx.Done():
+			return false, err
+		case <-time.After(delay):
+		}
+	}
+}
+
+// expand renders the templated fields of the step as seen from host. The content of a template step
+// is rendered by the step itself, with its params on top of the variables.
+func (e *Engine) expand(host string, step Step) (Step, error) {
+	fields := []*string{&step.Command, &step.DestPath, &step.Condition}
+	if step.Action != ActionTemplate {
+		fields = append(fields, &step.Content)
+	}
+
+	for _, field := range fields {
+		value, err := e.vars.Expand(host, *field)
+		if err != nil {
+			return step, err
+		}
+		*field = value
+	}
+	return step, nil
+}
+
+// rollback runs the rollbacks of the completed executions, the last one completed first. They
+// include the steps that succeeded before the run was resumed.
+func (e *Engine) rollback(ctx context.Context, run *Run, completed []*execution) {
+	for i := len(completed) - 1; i >= 0; i-- {
+		ex := completed[i]
+		if ex.step.Rollback == nil {
+			continue
+		}
+
+		e.logStep(ex, fmt.Sprintf("[Rollback] %s", ex.step.Name))
+		_, err := e.runStep(ctx, e.env(ex), *ex.step.Rollback, func() {})
+		ex.out.Flush()
+		if err != nil {
+			e.logStep(ex, fmt.Sprintf("Rollback of step '%s' failed: %v", ex.step.Name, err))
+			ex.state.Status = StepRollbackFailed
+			ex.state.Error = e.redact(err).Error()
+		} else {
+			ex.state.Status = StepRolledBack
+		}
+		e.saveStep(ctx, run, ex.state)
+		e.emit(ex.state)
+	}
+}
+
+func (e *Engine) finish(ctx context.Context, run *Run, err error) error {
+	err = e.redact(err)
+
+	run.Status = RunSucceeded
+	if err != nil {
+		run.Status = RunFailed
+		run.Error = err.Error()
+	}
+	run.FinishedAt = time.Now()
+	e.saveRun(ctx, run)
+	return err
+}
+
+// emit reports the state of a step to the OnStep callback.
+func (e *Engine) emit(state *StepRun) {
+	if e.onStep == nil {
+		return
+	}
+	e.onStep(StepEvent{
+		Index:  state.Index,
+		Name:   state.Name,
+		Host:   state.Host,
+		Status: state.Status,
+		Error:  state.Error,
+		Time:   time.Now(),
+	})
+}
+
+// saveRun and saveStep record progress in the store. A store failing does not stop the run, it only
+// makes it impossible to resume.
+func (e *Engine) saveRun(ctx context.Context, run *Run) {
+	if e.store == nil {
+		return
+	}
+	if err := e.store.SaveRun(context.WithoutCancel(ctx), run); err != nil {
+		logger.Log.Warn("Failed to save workflow run", zap.String("workflow", run.Name), zap.Error(err))
+	}
+}
+
+func (e *Engine) saveStep(ctx context.Context, run *Run, state *StepRun) {
+	if e.store == nil {
+		return
+	}
+	if err := e.store.SaveStep(context.WithoutCancel(ctx), run, state); err != nil {
+		logger.Log.Warn("Failed to save workflow step", zap.String("workflow", run.Name), zap.String("step", state.Name), zap.Error(err))
+	}
+}
+
+// log writes msg to the log with secrets redacted.
+func (e *Engine) log(msg string) {
+	if e.vars != nil {
+		msg = e.vars.Redact(msg)
+	}
+	e.logMu.Lock()
+	defer e.logMu.Unlock()
+	e.logFn(msg)
+}
+
+// logStep writes msg to the log, prefixed with the host of the execution when it has one.
+func (e *Engine) logStep(ex *execution, msg string) {
+	if ex.state.Host != "" {
+		msg = "[" + ex.state.Host + "] " + msg
+	}
+	e.log(msg)
+}
+
+// redact returns err with secrets redacted from its message, commands in errors may contain them.
+func (e *Engine) redact(err error) error {
+	if err == nil || e.vars == nil {
+		return err
+	}
+	if msg := e.vars.Redact(err.Error()); msg != err.Error() {
+		return &redactedError{msg: msg, err: err}
+	}
+	return err
+}
+
+// outputName returns the variable the output of a step on host is found under.
+func outputName(host, name string) string {
+	if host == "" {
+		return name
+	}
+	return host + "." + name
+}
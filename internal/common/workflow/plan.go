@@ -0,0 +1,221 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Decision tells whether a planned step is going to run.
+type Decision string
+
+const (
+	DecisionRun  Decision = "run"  // the step has no condition or its condition holds now
+	DecisionSkip Decision = "skip" // the condition of the step does not hold now
+	// DecisionUnknown is for conditions that use outputs of earlier steps, which only a run knows
+	DecisionUnknown Decision = "unknown"
+)
+
+// Plan is what a run of a workflow would do, in the order the steps would start.
+type Plan struct {
+	Name  string
+	Steps []*PlannedStep
+}
+
+// PlannedStep is a step on one host as it would run, with its fields rendered and secrets redacted.
+// Outputs of earlier steps show up as <name>, or <host.name> for those of steps on other hosts.
+type PlannedStep struct {
+	Index     int
+	Name      string
+	Host      string
+	Stage     string
+	Action    ActionType
+	DependsOn []string
+	Decision  Decision
+
+	Condition  string
+	Command    string
+	SourcePath string
+	DestPath   string
+	Content    string
+	Mode       string // permissions of written files, in octal
+	// Detail describes what steps without a command or file do, such as the port a check waits for
+	Detail string
+
+	// Rollback is what undoes the step when a later step fails
+	Rollback *PlannedStep
+	// Error is why the step would fail before doing anything, such as a variable that is not set
+	Error string
+}
+
+// Plan renders what a run of the workflow would do without changing anything on the servers. The
+// conditions of the steps are the only commands run, so they must not change the server either.
+// A condition is evaluated against the server as it is now, before any step ran.
+func (e *Engine) Plan(ctx context.Context, wf Workflow) (*Plan, error) {
+	deps, err := e.validate(wf)
+	if err != nil {
+		return nil, err
+	}
+
+	e.vars = NewVars()
+	for name, value := range wf.Vars {
+		e.vars.Set(name, value, false)
+	}
+	for name, value := range wf.Secrets {
+		e.vars.Set(name, value, true)
+	}
+
+	// Outputs are only known once their steps ran
+	var placeholders []string
+	for _, state := range NewRun(wf, e.target).Steps {
+		step := wf.Steps[state.Index]
+		if step.Output == "" {
+			continue
+		}
+		placeholder := "<" + outputName(state.Host, step.Output) + ">"
+		placeholders = append(placeholders, placeholder)
+		e.vars.SetOutput(state.Host, step.Output, placeholder, false)
+		if state.Host != "" {
+			// Without the host the output is that of whichever host set it last
+			e.vars.Set(step.Output, "<"+step.Output+">", false)
+		}
+	}
+
+	plan := &Plan{Name: wf.Name}
+	for _, i := range order(deps) {
+		step := wf.Steps[i]
+		for _, host := range step.hosts() {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			planned := e.planStep(host, step, placeholders)
+			planned.Index = i
+			for _, j := range deps[i] {
+				planned.DependsOn = append(planned.DependsOn, wf.Steps[j].key())
+			}
+			if step.Rollback != nil {
+				planned.Rollback = e.planStep(host, *step.Rollback, placeholders)
+				planned.Rollback.Index = i
+			}
+			plan.Steps = append(plan.Steps, planned)
+		}
+	}
+	return plan, nil
+}
+
+// planStep renders the step as it would run on host and evaluates its condition there.
+func (e *Engine) planStep(host string, step Step, placeholders []string) *PlannedStep {
+	planned := &PlannedStep{
+		Name:     step.Name,
+		Host:     host,
+		Stage:    step.Stage,
+		Action:   step.Action,
+		Decision: DecisionRun,
+	}
+
+	rendered, err := e.expand(host, step)
+	if err == nil && step.Action == ActionTemplate {
+		rendered.Content, err = templateContent(e.vars, host, step)
+	}
+	if err != nil {
+		planned.Error = e.vars.Redact(err.Error())
+		return planned
+	}
+
+	planned.Condition = e.vars.Redact(rendered.Condition)
+	planned.Command = e.vars.Redact(rendered.Command)
+	planned.SourcePath = rendered.SourcePath
+	planned.DestPath = e.vars.Redact(rendered.DestPath)
+	planned.Detail = e.vars.Redact(detail(rendered))
+	switch step.Action {
+	case ActionWriteFile, ActionTemplate:
+		planned.Content = e.vars.Redact(rendered.Content)
+		planned.Mode = fmt.Sprintf("%04o", fileMode(step))
+	}
+
+	if rendered.Condition == "" {
+		return planned
+	}
+	if containsAny(rendered.Condition, placeholders) {
+		planned.Decision = DecisionUnknown
+		return planned
+	}
+	// A non-zero exit code of the condition skips the step, as in a run
+	if _, err := e.host(host).client.RunCommand(rendered.Condition); err != nil {
+		planned.Decision = DecisionSkip
+	}
+	return planned
+}
+
+// detail describes what a step without a command or file does.
+func detail(step Step) string {
+	switch step.Action {
+	case ActionCommand, ActionWriteFile, ActionTemplate, ActionCopyFile:
+		return ""
+	case ActionWaitForPort:
+		host := step.Host
+		if host == "" {
+			host = "127.0.0.1"
+		}
+		return fmt.Sprintf("wait for %s:%d", host, step.Port)
+	case ActionHTTPCheck:
+		expect := step.ExpectStatus
+		if expect == 0 {
+			expect = 200
+		}
+		return fmt.Sprintf("wait for %s to answer %d", step.URL, expect)
+	}
+
+	var b strings.Builder
+	b.WriteString(step.Method)
+	names := make([]string, 0, len(step.Params))
+	for name := range step.Params {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&b, " %s=%s", name, step.Params[name])
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// order returns the steps in the order a run with unbounded concurrency would start them, steps
+// becoming ready at the same time in the order they are declared.
+func order(deps [][]int) []int {
+	done := make([]bool, len(deps))
+	var steps []int
+	for len(steps) < len(deps) {
+		var ready []int
+		for i := range deps {
+			if done[i] {
+				continue
+			}
+			waiting := false
+			for _, j := range deps[i] {
+				if !done[j] {
+					waiting = true
+					break
+				}
+			}
+			if !waiting {
+				ready = append(ready, i)
+			}
+		}
+		for _, i := range ready {
+			done[i] = true
+		}
+		steps = append(steps, ready...)
+	}
+	return steps
+}
+
+func containsAny(s string, subs []string) bool {
+	for _, sub := range subs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
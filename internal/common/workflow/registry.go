@@ -0,0 +1,108 @@
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/zhinea/sylix/internal/common/util"
+)
+
+// Env is what an action gets to carry out a step on the server a workflow runs on.
+type Env struct {
+	// Host is the name of the host the step runs on, empty for the engine's own host
+	Host   string
+	Client *util.SSHClient
+	// Target identifies the server for actions that reach it other than over SSH, usually its id
+	Target string
+	// Out and Err receive the output of the step, Out only stdout when the step has an output
+	Out  io.Writer
+	Err  io.Writer
+	Log  func(string)
+	Vars *Vars
+}
+
+// Action carries out steps of one action type.
+type Action interface {
+	Execute(ctx context.Context, env *Env, step Step) error
+}
+
+// ActionFunc adapts a function to an Action.
+type ActionFunc func(ctx context.Context, env *Env, step Step) error
+
+func (f ActionFunc) Execute(ctx context.Context, env *Env, step Step) error {
+	return f(ctx, env, step)
+}
+
+// Registry maps action types to the actions carrying them out.
+type Registry struct {
+	mu      sync.RWMutex
+	actions map[ActionType]Action
+}
+
+// DefaultRegistry holds the built-in actions and whatever other modules register. Engines use it
+// unless given another registry.
+var DefaultRegistry = NewRegistry()
+
+func init() {
+	registerBuiltins(DefaultRegistry)
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		actions: make(map[ActionType]Action),
+	}
+}
+
+// Register makes action carry out steps of type t, replacing the action registered for it before.
+func (r *Registry) Register(t ActionType, action Action) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.actions[t] = action
+}
+
+func (r *Registry) Lookup(t ActionType) (Action, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	action, ok := r.actions[t]
+	return action, ok
+}
+
+// Types returns the registered action types in order.
+func (r *Registry) Types() []ActionType {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	types := make([]ActionType, 0, len(r.actions))
+	for t := range r.actions {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return types[i] < types[j]
+	})
+	return types
+}
+
+// Validate checks that every step of the workflow and its rollback has a registered action.
+func (r *Registry) Validate(wf Workflow) error {
+	for _, step := range wf.Steps {
+		if _, ok := r.Lookup(step.Action); !ok {
+			return fmt.Errorf("step '%s': unknown action type: %s", step.Name, step.Action)
+		}
+		if step.Rollback == nil {
+			continue
+		}
+		if _, ok := r.Lookup(step.Rollback.Action); !ok {
+			return fmt.Errorf("step '%s': unknown rollback action type: %s", step.Name, step.Rollback.Action)
+		}
+	}
+	return nil
+}
+
+// Register registers action for t in the DefaultRegistry.
+func Register(t ActionType, action Action) {
+	DefaultRegistry.Register(t, action)
+}
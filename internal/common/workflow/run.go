@@ -0,0 +1,110 @@
+package workflow
+
+import (
+	"context"
+	"time"
+)
+
+type RunStatus string
+
+const (
+	RunRunning   RunStatus = "running"
+	RunSucceeded RunStatus = "succeeded"
+	RunFailed    RunStatus = "failed"
+)
+
+type StepStatus string
+
+const (
+	StepPending        StepStatus = "pending"
+	StepRunning        StepStatus = "running"
+	StepSucceeded      StepStatus = "succeeded"
+	StepFailed         StepStatus = "failed"
+	StepSkipped        StepStatus = "skipped" // the condition was not met
+	StepIgnored        StepStatus = "ignored" // failed, but the step ignores errors
+	StepRolledBack     StepStatus = "rolled_back"
+	StepRollbackFailed StepStatus = "rollback_failed"
+)
+
+// Run is the progress of one execution of a workflow, as recorded in a Store.
+type Run struct {
+	ID         string
+	Kind       string
+	Name       string
+	Target     string
+	Status     RunStatus
+	Error      string
+	Steps      []*StepRun
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+type StepRun struct {
+	ID    string
+	Index int
+	Name  string
+	// Host is the host the step runs on, empty for the engine's own
+	Host     string
+	Status   StepStatus
+	Attempts int
+	Error    string
+	// Output is what the step stored in its output variable, restored when the run is resumed
+	Output     string
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+// StepEvent reports a step of a run changing status on a host.
+type StepEvent struct {
+	Index  int
+	Name   string
+	Host   string
+	Status StepStatus
+	Error  string
+	Time   time.Time
+}
+
+// Store records runs so they outlive the process executing them.
+type Store interface {
+	// SaveRun creates the run together with its steps when it has no ID yet, setting their IDs,
+	// and updates the run itself otherwise.
+	SaveRun(ctx context.Context, run *Run) error
+	SaveStep(ctx context.Context, run *Run, step *StepRun) error
+}
+
+// NewRun returns a run of the workflow on target that has not started yet.
+func NewRun(wf Workflow, target string) *Run {
+	run := &Run{
+		Kind:      wf.Kind,
+		Name:      wf.Name,
+		Target:    target,
+		Status:    RunRunning,
+		StartedAt: time.Now(),
+	}
+	for i, step := range wf.Steps {
+		for _, host := range step.hosts() {
+			run.Steps = append(run.Steps, &StepRun{
+				Index:  i,
+				Name:   step.Name,
+				Host:   host,
+				Status: StepPending,
+			})
+		}
+	}
+	return run
+}
+
+// started reports whether any step of the run was attempted.
+func (r *Run) started() bool {
+	for _, step := range r.Steps {
+		if step.Status != StepPending {
+			return true
+		}
+	}
+	return false
+}
+
+// done reports whether the step needs no running when the run is resumed.
+func (s *StepRun) done() bool {
+	return s.Status == StepSucceeded || s.Status == StepSkipped || s.Status == StepIgnored
+}
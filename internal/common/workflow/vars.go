@@ -0,0 +1,224 @@
+package workflow
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// redacted replaces the values of secret variables in logs and errors.
+const redacted = "***"
+
+// Vars are the variables of a run: those the workflow starts with and the outputs of its steps so far.
+type Vars struct {
+	mu      sync.RWMutex
+	values  map[string]string
+	secrets map[string]bool
+}
+
+func NewVars() *Vars {
+	return &Vars{
+		values:  make(map[string]string),
+		secrets: make(map[string]bool),
+	}
+}
+
+// Set sets the variable. The value of a secret variable is redacted wherever it shows up in logs.
+func (v *Vars) Set(name, value string, secret bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.values[name] = value
+	if secret {
+		v.secrets[name] = true
+	}
+}
+
+func (v *Vars) Get(name string) (string, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	value, ok := v.values[name]
+	return value, ok
+}
+
+// Map returns a copy of the variables.
+func (v *Vars) Map() map[string]string {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	values := make(map[string]string, len(v.values))
+	for name, value := range v.values {
+		values[name] = value
+	}
+	return values
+}
+
+// Data returns the variables as seen from host, as data for templates. The outputs of steps that
+// ran on a named host are found under the host, as in {{.web1.public_key}}, and those of host are
+// also available without it.
+func (v *Vars) Data(host string) map[string]any {
+	values := v.Map()
+	data := make(map[string]any, len(values))
+	for name, value := range values {
+		if !strings.Contains(name, ".") {
+			data[name] = value
+		}
+	}
+
+	for name, value := range values {
+		prefix, local, ok := strings.Cut(name, ".")
+		if !ok {
+			continue
+		}
+		if prefix == host {
+			data[local] = value
+		}
+		outputs, ok := data[prefix].(map[string]string)
+		if !ok {
+			if _, taken := data[prefix]; taken {
+				continue
+			}
+			outputs = make(map[string]string)
+			data[prefix] = outputs
+		}
+		outputs[local] = value
+	}
+	return data
+}
+
+// SetOutput stores the output of a step that ran on host.
+func (v *Vars) SetOutput(host, name, value string, secret bool) {
+	if host != "" {
+		v.Set(host+"."+name, value, secret)
+	}
+	v.Set(name, value, secret)
+}
+
+// Redact replaces the values of secret variables in s.
+func (v *Vars) Redact(s string) string {
+	v.mu.RLock()
+	secrets := make([]string, 0, len(v.secrets))
+	for name := range v.secrets {
+		if value := v.values[name]; value != "" {
+			secrets = append(secrets, value)
+		}
+	}
+	v.mu.RUnlock()
+
+	// Longest first, so a secret containing another one is replaced as a whole
+	sort.Slice(secrets, func(i, j int) bool {
+		return len(secrets[i]) > len(secrets[j])
+	})
+	for _, secret := range secrets {
+		s = strings.ReplaceAll(s, secret, redacted)
+	}
+	return s
+}
+
+// Expand renders text as a Go template with the variables as seen from host as data. A variable
+// that is not set is an error.
+func (v *Vars) Expand(host, text string) (string, error) {
+	return expand(text, v.Data(host))
+}
+
+func expand(text string, data map[string]any) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+
+	tmpl, err := template.New("").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var out strings.Builder
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("failed to render template: %w", err)
+	}
+	return out.String(), nil
+}
+
+// lineWriter writes whole lines with secrets redacted, so a secret split over two writes is still
+// caught, and prefixed with the host they come from. Line writers sharing a sink never interleave
+// within a line.
+type lineWriter struct {
+	sink   *sink
+	vars   *Vars
+	prefix string
+
+	mu  sync.Mutex
+	buf []byte
+}
+
+// sink is the log writer shared by the line writers of all hosts.
+type sink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func newLineWriter(sink *sink, vars *Vars, host string) *lineWriter {
+	w := &lineWriter{sink: sink, vars: vars}
+	if host != "" {
+		w.prefix = "[" + host + "] "
+	}
+	return w
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+	i := bytes.LastIndexByte(w.buf, '\n')
+	if i < 0 {
+		return len(p), nil
+	}
+
+	err := w.emit(string(w.buf[:i]))
+	w.buf = append(w.buf[:0], w.buf[i+1:]...)
+	return len(p), err
+}
+
+// Flush writes what is left of an unterminated line.
+func (w *lineWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.buf) == 0 {
+		return nil
+	}
+	err := w.emit(string(w.buf))
+	w.buf = w.buf[:0]
+	return err
+}
+
+func (w *lineWriter) emit(text string) error {
+	var out strings.Builder
+	for _, line := range strings.Split(w.vars.Redact(text), "\n") {
+		out.WriteString(w.prefix)
+		out.WriteString(line)
+		out.WriteByte('\n')
+	}
+
+	w.sink.mu.Lock()
+	defer w.sink.mu.Unlock()
+	_, err := io.WriteString(w.sink.w, out.String())
+	return err
+}
+
+// redactedError carries the message of an error with secrets redacted, and still unwraps to it.
+type redactedError struct {
+	msg string
+	err error
+}
+
+func (e *redactedError) Error() string {
+	return e.msg
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
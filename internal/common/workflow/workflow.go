@@ -1,35 +1,69 @@
 package workflow
 
 import (
-	"context"
-	"fmt"
-	"io"
 	"os"
 	"time"
-
-	"github.com/zhinea/sylix/internal/common/util"
 )
 
 // ActionType defines what a step does
 type ActionType string
 
 const (
-	ActionCommand   ActionType = "command"    // Run a shell command
-	ActionWriteFile ActionType = "write_file" // Write content to a remote file
-	ActionCopyFile  ActionType = "copy_file"  // Copy a local file to remote
+	ActionCommand     ActionType = "command"       // Run a shell command
+	ActionWriteFile   ActionType = "write_file"    // Write content to a remote file
+	ActionCopyFile    ActionType = "copy_file"     // Copy a local file to remote
+	ActionTemplate    ActionType = "template"      // Render Content as a Go template with Params and write it to a remote file
+	ActionWaitForPort ActionType = "wait_for_port" // Wait until Host:Port accepts TCP connections, as seen from the server
+	ActionHTTPCheck   ActionType = "http_check"    // Wait until URL answers with ExpectStatus, as seen from the server
+	ActionAgentRPC    ActionType = "agent_rpc"     // Call Method on the server's agent, registered by the controlplane
 )
 
 type Step struct {
+	// ID is what other steps refer to the step by in DependsOn, the name when unset
+	ID     string
 	Name   string
 	Action ActionType
 
+	// DependsOn lists the steps that must be done before the step starts
+	DependsOn []string
+	// Hosts are the names of the hosts the step runs on, each at the same time. The engine's own
+	// host when empty.
+	Hosts []string
+
 	// Command specific
 	Command string // The shell command to run
 
 	// File specific
-	Content    string // Content for WriteFile
-	SourcePath string // Local path for CopyFile
-	DestPath   string // Remote path
+	Content    string      // Content for WriteFile, template text for Template
+	Mode       os.FileMode // Permissions for WriteFile and Template, 0644 when unset
+	SourcePath string      // Local path for CopyFile
+	DestPath   string      // Remote path
+
+	// Check specific
+	Host         string        // Host for WaitForPort, 127.0.0.1 when unset
+	Port         int           // Port for WaitForPort
+	URL          string        // URL for HTTPCheck
+	ExpectStatus int           // Status code HTTPCheck waits for, 200 when unset
+	Timeout      time.Duration // How long checks wait, DefaultCheckTimeout when unset
+
+	// Custom actions
+	Method string            // Method for AgentRPC
+	Params map[string]string // Data for Template on top of the variables, and arguments of custom actions
+
+	// Output names the variable the trimmed stdout of the step is stored in, for later steps to use.
+	// On a named host the variable is also stored as "<host>.<output>", for steps on other hosts.
+	Output string
+	// Secret keeps the output out of the logs and redacts it wherever it shows up later
+	Secret bool
+
+	// Stage groups consecutive steps into a phase of the workflow, reported through OnStage
+	Stage string
+
+	// Retry reruns the step when it fails
+	Retry RetryPolicy
+	// Rollback undoes the step. When a later step fails, the rollbacks of the steps that succeeded
+	// run in reverse order.
+	Rollback *Step
 
 	// Control flow
 	// Condition is a shell command. If it returns exit code 0, the step runs.
@@ -38,95 +72,56 @@ type Step struct {
 	IgnoreError bool // If true, continues even if the step fails
 }
 
-type Workflow struct {
-	Name  string
-	Steps []Step
+// RetryPolicy tells how often a failed step is attempted again and how long to wait in between.
+// The wait starts at Backoff and doubles after every attempt, up to MaxBackoff when set.
+type RetryPolicy struct {
+	Attempts   int // attempts in total, the step runs once when below 2
+	Backoff    time.Duration
+	MaxBackoff time.Duration
 }
 
-type Engine struct {
-	client    *util.SSHClient
-	logWriter io.Writer
-	logFn     func(string)
+// delay returns how long to wait after the attempt-th attempt failed.
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	d := p.Backoff
+	for i := 1; i < attempt && (p.MaxBackoff == 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	return d
 }
 
-func NewEngine(client *util.SSHClient, logWriter io.Writer, logFn func(string)) *Engine {
-	return &Engine{
-		client:    client,
-		logWriter: logWriter,
-		logFn:     logFn,
-	}
+// Workflow is a list of steps. Without any DependsOn the steps run one after the other in order.
+// Once a step declares dependencies the workflow is a graph: every step starts as soon as the steps
+// it depends on are done, as many at a time as the engine's concurrency allows.
+//
+// Command, Content, DestPath and Condition of a step are Go templates rendered with the variables
+// of the run, such as {{.private_key}}, right before the step runs.
+type Workflow struct {
+	Name string
+	// Kind identifies the definition the workflow was built from, so an interrupted run can be
+	// rebuilt and resumed
+	Kind  string
+	Steps []Step
+
+	// Vars and Secrets are the variables the run starts with. Secrets are redacted from the logs.
+	Vars    map[string]string
+	Secrets map[string]string
 }
 
-func (e *Engine) Run(ctx context.Context, wf Workflow) error {
-	e.logFn(fmt.Sprintf("Starting workflow: %s", wf.Name))
-
-	for i, step := range wf.Steps {
-		select {
-		case <-ctx.Done():
-			return ctx.Err()
-		default:
-		}
-
-		e.logFn(fmt.Sprintf("[Step %d/%d] %s", i+1, len(wf.Steps), step.Name))
-
-		// Check condition if present
-		if step.Condition != "" {
-			// We use RunCommand (not stream) to check condition silently,
-			// but maybe we want to log it?
-			// For now, let's run it silently to check exit code.
-			// If we want to log the check, we can use RunCommandStream but discard output if successful?
-			// The requirement says "if Docker is not installed...".
-			// Usually condition checks are silent unless they fail or we want debug info.
-			// Let's run it. If it fails (non-zero exit code), we skip the step.
-			// Wait, the requirement says: "if Docker is not installed, the installation should continue"
-			// So if Condition is "check if docker missing", and it returns true (0), we run the step.
-
-			// We can use RunCommand. If it returns error, it means exit code != 0.
-			_, err := e.client.RunCommand(step.Condition)
-			if err != nil {
-				e.logFn(fmt.Sprintf("Skipping step '%s' (condition not met)", step.Name))
-				continue
-			}
-		}
-
-		if err := e.executeStep(step); err != nil {
-			if step.IgnoreError {
-				e.logFn(fmt.Sprintf("Step '%s' failed but marked to ignore error: %v", step.Name, err))
-				continue
-			}
-			return fmt.Errorf("step '%s' failed: %w", step.Name, err)
-		}
+// key returns what other steps refer to the step by.
+func (s Step) key() string {
+	if s.ID != "" {
+		return s.ID
 	}
-
-	e.logFn(fmt.Sprintf("Workflow '%s' completed successfully.", wf.Name))
-	return nil
+	return s.Name
 }
 
-func (e *Engine) executeStep(step Step) error {
-	switch step.Action {
-	case ActionCommand:
-		return e.client.RunCommandStream(step.Command, e.logWriter, e.logWriter)
-
-	case ActionWriteFile:
-		// Create a temporary file locally
-		tmpFile := fmt.Sprintf("workflow_tmp_%d", time.Now().UnixNano())
-		if err := os.WriteFile(tmpFile, []byte(step.Content), 0644); err != nil {
-			return fmt.Errorf("failed to write temp file: %w", err)
-		}
-		defer os.Remove(tmpFile)
-
-		if err := e.client.CopyFile(tmpFile, step.DestPath); err != nil {
-			return fmt.Errorf("failed to copy file to remote: %w", err)
-		}
-		return nil
-
-	case ActionCopyFile:
-		if err := e.client.CopyFile(step.SourcePath, step.DestPath); err != nil {
-			return fmt.Errorf("failed to copy file to remote: %w", err)
-		}
-		return nil
-
-	default:
-		return fmt.Errorf("unknown action type: %s", step.Action)
+// hosts returns the hosts the step runs on, "" standing for the engine's own host.
+func (s Step) hosts() []string {
+	if len(s.Hosts) == 0 {
+		return []string{""}
 	}
+	return s.Hosts
 }
@@ -6,5 +6,5 @@ import (
 )
 
 func NewDB() (*gorm.DB, error) {
-	return gorm.Open(sqlite.Open("sylix.db"), &gorm.Config{})
+	return gorm.Open(sqlite.Open("sylix.db"), &gorm.Config{TranslateError: true})
 }
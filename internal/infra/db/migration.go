@@ -18,11 +18,39 @@ func AutoMigrate(db *gorm.DB) error {
 		&entity.ServerPing{},
 		&entity.ServerStat{},
 
+		&entity.IPLease{},
+		&entity.MeshSyncReport{},
+		&entity.MeshSyncResult{},
+		&entity.CertificateAuthority{},
+		&entity.WorkflowRun{},
+		&entity.WorkflowStepRun{},
+
 		&entity.BackupStorage{},
+		&entity.LogArchive{},
+		&entity.BackupJob{},
+
+		&entity.ServiceNode{},
+		&entity.ServiceNodeField{},
+		&entity.ServicePort{},
+		&entity.ServiceEdge{},
+		&entity.PortLease{},
+
+		&entity.Deployment{},
+		&entity.DeploymentStep{},
 	); err != nil {
 		return err
 	}
 
+	// Number the nodes created before ordinals were stored the way their ids used to be derived:
+	// by their position among the nodes of their type in the cluster
+	if err := db.Exec(`UPDATE service_nodes SET ordinal = (
+		SELECT COUNT(*) FROM service_nodes AS s
+		WHERE s.parent_id = service_nodes.parent_id AND s.app_service = service_nodes.app_service AND s.deleted_at IS NULL
+			AND (s.created_at < service_nodes.created_at OR (s.created_at = service_nodes.created_at AND s.id <= service_nodes.id))
+	) WHERE parent_id IS NOT NULL AND ordinal = 0 AND deleted_at IS NULL`).Error; err != nil {
+		return err
+	}
+
 	// Migrate credential_ca_cert to agent_cert
 	if db.Migrator().HasColumn(&entity.Server{}, "credential_ca_cert") {
 		if err := db.Exec("UPDATE servers SET agent_cert = credential_ca_cert WHERE (agent_cert IS NULL OR agent_cert = '') AND credential_ca_cert IS NOT NULL").Error; err != nil {
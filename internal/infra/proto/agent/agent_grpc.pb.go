@@ -0,0 +1,367 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v3.12.4
+// source: agent/agent.proto
+
+package agent
+
+import (
+	context "context"
+	common "github.com/zhinea/sylix/internal/infra/proto/common"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	AgentService_Health_FullMethodName        = "/agent.AgentService/Health"
+	AgentService_SystemInfo_FullMethodName    = "/agent.AgentService/SystemInfo"
+	AgentService_DeployCompose_FullMethodName = "/agent.AgentService/DeployCompose"
+	AgentService_StopCompose_FullMethodName   = "/agent.AgentService/StopCompose"
+	AgentService_RemoveCompose_FullMethodName = "/agent.AgentService/RemoveCompose"
+	AgentService_ContainerLogs_FullMethodName = "/agent.AgentService/ContainerLogs"
+	AgentService_Exec_FullMethodName          = "/agent.AgentService/Exec"
+)
+
+// AgentServiceClient is the client API for AgentService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// AgentService runs on every managed server and is only reachable with a client certificate
+// issued by the controlplane CA.
+type AgentServiceClient interface {
+	Health(ctx context.Context, in *common.Empty, opts ...grpc.CallOption) (*HealthResponse, error)
+	SystemInfo(ctx context.Context, in *common.Empty, opts ...grpc.CallOption) (*SystemInfoResponse, error)
+	// Writes the compose file of the project and starts it, streaming the docker compose output
+	DeployCompose(ctx context.Context, in *DeployComposeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OutputLine], error)
+	StopCompose(ctx context.Context, in *ComposeProject, opts ...grpc.CallOption) (*CommandResult, error)
+	RemoveCompose(ctx context.Context, in *RemoveComposeRequest, opts ...grpc.CallOption) (*CommandResult, error)
+	ContainerLogs(ctx context.Context, in *ContainerLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OutputLine], error)
+	// Runs a command in a container, or on the host when no container is given
+	Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (*ExecResponse, error)
+}
+
+type agentServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAgentServiceClient(cc grpc.ClientConnInterface) AgentServiceClient {
+	return &agentServiceClient{cc}
+}
+
+func (c *agentServiceClient) Health(ctx context.Context, in *common.Empty, opts ...grpc.CallOption) (*HealthResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(HealthResponse)
+	err := c.cc.Invoke(ctx, AgentService_Health_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) SystemInfo(ctx context.Context, in *common.Empty, opts ...grpc.CallOption) (*SystemInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SystemInfoResponse)
+	err := c.cc.Invoke(ctx, AgentService_SystemInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) DeployCompose(ctx context.Context, in *DeployComposeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OutputLine], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AgentService_ServiceDesc.Streams[0], AgentService_DeployCompose_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[DeployComposeRequest, OutputLine]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentService_DeployComposeClient = grpc.ServerStreamingClient[OutputLine]
+
+func (c *agentServiceClient) StopCompose(ctx context.Context, in *ComposeProject, opts ...grpc.CallOption) (*CommandResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CommandResult)
+	err := c.cc.Invoke(ctx, AgentService_StopCompose_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) RemoveCompose(ctx context.Context, in *RemoveComposeRequest, opts ...grpc.CallOption) (*CommandResult, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CommandResult)
+	err := c.cc.Invoke(ctx, AgentService_RemoveCompose_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *agentServiceClient) ContainerLogs(ctx context.Context, in *ContainerLogsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OutputLine], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AgentService_ServiceDesc.Streams[1], AgentService_ContainerLogs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ContainerLogsRequest, OutputLine]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentService_ContainerLogsClient = grpc.ServerStreamingClient[OutputLine]
+
+func (c *agentServiceClient) Exec(ctx context.Context, in *ExecRequest, opts ...grpc.CallOption) (*ExecResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ExecResponse)
+	err := c.cc.Invoke(ctx, AgentService_Exec_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AgentServiceServer is the server API for AgentService service.
+// All implementations must embed UnimplementedAgentServiceServer
+// for forward compatibility.
+//
+// AgentService runs on every managed server and is only reachable with a client certificate
+// issued by the controlplane CA.
+type AgentServiceServer interface {
+	Health(context.Context, *common.Empty) (*HealthResponse, error)
+	SystemInfo(context.Context, *common.Empty) (*SystemInfoResponse, error)
+	// Writes the compose file of the project and starts it, streaming the docker compose output
+	DeployCompose(*DeployComposeRequest, grpc.ServerStreamingServer[OutputLine]) error
+	StopCompose(context.Context, *ComposeProject) (*CommandResult, error)
+	RemoveCompose(context.Context, *RemoveComposeRequest) (*CommandResult, error)
+	ContainerLogs(*ContainerLogsRequest, grpc.ServerStreamingServer[OutputLine]) error
+	// Runs a command in a container, or on the host when no container is given
+	Exec(context.Context, *ExecRequest) (*ExecResponse, error)
+	mustEmbedUnimplementedAgentServiceServer()
+}
+
+// UnimplementedAgentServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAgentServiceServer struct{}
+
+func (UnimplementedAgentServiceServer) Health(context.Context, *common.Empty) (*HealthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Health not implemented")
+}
+func (UnimplementedAgentServiceServer) SystemInfo(context.Context, *common.Empty) (*SystemInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SystemInfo not implemented")
+}
+func (UnimplementedAgentServiceServer) DeployCompose(*DeployComposeRequest, grpc.ServerStreamingServer[OutputLine]) error {
+	return status.Errorf(codes.Unimplemented, "method DeployCompose not implemented")
+}
+func (UnimplementedAgentServiceServer) StopCompose(context.Context, *ComposeProject) (*CommandResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopCompose not implemented")
+}
+func (UnimplementedAgentServiceServer) RemoveCompose(context.Context, *RemoveComposeRequest) (*CommandResult, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveCompose not implemented")
+}
+func (UnimplementedAgentServiceServer) ContainerLogs(*ContainerLogsRequest, grpc.ServerStreamingServer[OutputLine]) error {
+	return status.Errorf(codes.Unimplemented, "method ContainerLogs not implemented")
+}
+func (UnimplementedAgentServiceServer) Exec(context.Context, *ExecRequest) (*ExecResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Exec not implemented")
+}
+func (UnimplementedAgentServiceServer) mustEmbedUnimplementedAgentServiceServer() {}
+func (UnimplementedAgentServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeAgentServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AgentServiceServer will
+// result in compilation errors.
+type UnsafeAgentServiceServer interface {
+	mustEmbedUnimplementedAgentServiceServer()
+}
+
+func RegisterAgentServiceServer(s grpc.ServiceRegistrar, srv AgentServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAgentServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AgentService_ServiceDesc, srv)
+}
+
+func _AgentService_Health_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).Health(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_Health_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).Health(ctx, req.(*common.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_SystemInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).SystemInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_SystemInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).SystemInfo(ctx, req.(*common.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_DeployCompose_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DeployComposeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AgentServiceServer).DeployCompose(m, &grpc.GenericServerStream[DeployComposeRequest, OutputLine]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentService_DeployComposeServer = grpc.ServerStreamingServer[OutputLine]
+
+func _AgentService_StopCompose_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ComposeProject)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).StopCompose(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_StopCompose_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).StopCompose(ctx, req.(*ComposeProject))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_RemoveCompose_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveComposeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).RemoveCompose(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_RemoveCompose_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).RemoveCompose(ctx, req.(*RemoveComposeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AgentService_ContainerLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ContainerLogsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AgentServiceServer).ContainerLogs(m, &grpc.GenericServerStream[ContainerLogsRequest, OutputLine]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AgentService_ContainerLogsServer = grpc.ServerStreamingServer[OutputLine]
+
+func _AgentService_Exec_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AgentServiceServer).Exec(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AgentService_Exec_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AgentServiceServer).Exec(ctx, req.(*ExecRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AgentService_ServiceDesc is the grpc.ServiceDesc for AgentService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AgentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "agent.AgentService",
+	HandlerType: (*AgentServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Health",
+			Handler:    _AgentService_Health_Handler,
+		},
+		{
+			MethodName: "SystemInfo",
+			Handler:    _AgentService_SystemInfo_Handler,
+		},
+		{
+			MethodName: "StopCompose",
+			Handler:    _AgentService_StopCompose_Handler,
+		},
+		{
+			MethodName: "RemoveCompose",
+			Handler:    _AgentService_RemoveCompose_Handler,
+		},
+		{
+			MethodName: "Exec",
+			Handler:    _AgentService_Exec_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "DeployCompose",
+			Handler:       _AgentService_DeployCompose_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ContainerLogs",
+			Handler:       _AgentService_ContainerLogs_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "agent/agent.proto",
+}
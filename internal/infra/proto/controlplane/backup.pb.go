@@ -80,6 +80,55 @@ func (BackupStatusCode) EnumDescriptor() ([]byte, []int) {
 	return file_controlplane_backup_proto_rawDescGZIP(), []int{0}
 }
 
+type BackupJobStatus int32
+
+const (
+	BackupJobStatus_BACKUP_JOB_RUNNING   BackupJobStatus = 0
+	BackupJobStatus_BACKUP_JOB_SUCCEEDED BackupJobStatus = 1
+	BackupJobStatus_BACKUP_JOB_FAILED    BackupJobStatus = 2
+)
+
+// Enum value maps for BackupJobStatus.
+var (
+	BackupJobStatus_name = map[int32]string{
+		0: "BACKUP_JOB_RUNNING",
+		1: "BACKUP_JOB_SUCCEEDED",
+		2: "BACKUP_JOB_FAILED",
+	}
+	BackupJobStatus_value = map[string]int32{
+		"BACKUP_JOB_RUNNING":   0,
+		"BACKUP_JOB_SUCCEEDED": 1,
+		"BACKUP_JOB_FAILED":    2,
+	}
+)
+
+func (x BackupJobStatus) Enum() *BackupJobStatus {
+	p := new(BackupJobStatus)
+	*p = x
+	return p
+}
+
+func (x BackupJobStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BackupJobStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_controlplane_backup_proto_enumTypes[1].Descriptor()
+}
+
+func (BackupJobStatus) Type() protoreflect.EnumType {
+	return &file_controlplane_backup_proto_enumTypes[1]
+}
+
+func (x BackupJobStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BackupJobStatus.Descriptor instead.
+func (BackupJobStatus) EnumDescriptor() ([]byte, []int) {
+	return file_controlplane_backup_proto_rawDescGZIP(), []int{1}
+}
+
 type BackupStorageId struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -412,6 +461,395 @@ func (x *BackupStoragesResponse) GetStatus() BackupStatusCode {
 	return BackupStatusCode_BACKUP_UNSPECIFIED
 }
 
+type BackupJob struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ServiceId     string                 `protobuf:"bytes,2,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	StorageId     string                 `protobuf:"bytes,3,opt,name=storage_id,json=storageId,proto3" json:"storage_id,omitempty"`
+	Kind          string                 `protobuf:"bytes,4,opt,name=kind,proto3" json:"kind,omitempty"`       // wal, layers or basebackup
+	Trigger       string                 `protobuf:"bytes,5,opt,name=trigger,proto3" json:"trigger,omitempty"` // schedule or manual
+	Status        BackupJobStatus        `protobuf:"varint,6,opt,name=status,proto3,enum=controlplane.BackupJobStatus" json:"status,omitempty"`
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	Bucket        string                 `protobuf:"bytes,8,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Key           string                 `protobuf:"bytes,9,opt,name=key,proto3" json:"key,omitempty"`
+	Size          int64                  `protobuf:"varint,10,opt,name=size,proto3" json:"size,omitempty"`        // bytes
+	Checksum      string                 `protobuf:"bytes,11,opt,name=checksum,proto3" json:"checksum,omitempty"` // SHA-256 of the object, hex encoded
+	DurationMs    int64                  `protobuf:"varint,12,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	StartedAt     string                 `protobuf:"bytes,13,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt    string                 `protobuf:"bytes,14,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackupJob) Reset() {
+	*x = BackupJob{}
+	mi := &file_controlplane_backup_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackupJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupJob) ProtoMessage() {}
+
+func (x *BackupJob) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_backup_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupJob.ProtoReflect.Descriptor instead.
+func (*BackupJob) Descriptor() ([]byte, []int) {
+	return file_controlplane_backup_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *BackupJob) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BackupJob) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+func (x *BackupJob) GetStorageId() string {
+	if x != nil {
+		return x.StorageId
+	}
+	return ""
+}
+
+func (x *BackupJob) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *BackupJob) GetTrigger() string {
+	if x != nil {
+		return x.Trigger
+	}
+	return ""
+}
+
+func (x *BackupJob) GetStatus() BackupJobStatus {
+	if x != nil {
+		return x.Status
+	}
+	return BackupJobStatus_BACKUP_JOB_RUNNING
+}
+
+func (x *BackupJob) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *BackupJob) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *BackupJob) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *BackupJob) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *BackupJob) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+func (x *BackupJob) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *BackupJob) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+func (x *BackupJob) GetFinishedAt() string {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return ""
+}
+
+type ListBackupJobsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Both optional, jobs of every service and storage when empty
+	StorageId     string `protobuf:"bytes,1,opt,name=storage_id,json=storageId,proto3" json:"storage_id,omitempty"`
+	ServiceId     string `protobuf:"bytes,2,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	Page          int32  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
+	Limit         int32  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBackupJobsRequest) Reset() {
+	*x = ListBackupJobsRequest{}
+	mi := &file_controlplane_backup_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBackupJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBackupJobsRequest) ProtoMessage() {}
+
+func (x *ListBackupJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_backup_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBackupJobsRequest.ProtoReflect.Descriptor instead.
+func (*ListBackupJobsRequest) Descriptor() ([]byte, []int) {
+	return file_controlplane_backup_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListBackupJobsRequest) GetStorageId() string {
+	if x != nil {
+		return x.StorageId
+	}
+	return ""
+}
+
+func (x *ListBackupJobsRequest) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+func (x *ListBackupJobsRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *ListBackupJobsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type BackupJobsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []*BackupJob           `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+	Status        BackupStatusCode       `protobuf:"varint,2,opt,name=status,proto3,enum=controlplane.BackupStatusCode" json:"status,omitempty"`
+	Total         int64                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	Error         *string                `protobuf:"bytes,4,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackupJobsResponse) Reset() {
+	*x = BackupJobsResponse{}
+	mi := &file_controlplane_backup_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackupJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupJobsResponse) ProtoMessage() {}
+
+func (x *BackupJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_backup_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupJobsResponse.ProtoReflect.Descriptor instead.
+func (*BackupJobsResponse) Descriptor() ([]byte, []int) {
+	return file_controlplane_backup_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *BackupJobsResponse) GetData() []*BackupJob {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *BackupJobsResponse) GetStatus() BackupStatusCode {
+	if x != nil {
+		return x.Status
+	}
+	return BackupStatusCode_BACKUP_UNSPECIFIED
+}
+
+func (x *BackupJobsResponse) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *BackupJobsResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+type TriggerBackupJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServiceId     string                 `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TriggerBackupJobRequest) Reset() {
+	*x = TriggerBackupJobRequest{}
+	mi := &file_controlplane_backup_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TriggerBackupJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TriggerBackupJobRequest) ProtoMessage() {}
+
+func (x *TriggerBackupJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_backup_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TriggerBackupJobRequest.ProtoReflect.Descriptor instead.
+func (*TriggerBackupJobRequest) Descriptor() ([]byte, []int) {
+	return file_controlplane_backup_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TriggerBackupJobRequest) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+type BackupJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          *BackupJob             `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Status        BackupStatusCode       `protobuf:"varint,2,opt,name=status,proto3,enum=controlplane.BackupStatusCode" json:"status,omitempty"`
+	Error         *string                `protobuf:"bytes,3,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BackupJobResponse) Reset() {
+	*x = BackupJobResponse{}
+	mi := &file_controlplane_backup_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BackupJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackupJobResponse) ProtoMessage() {}
+
+func (x *BackupJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_backup_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackupJobResponse.ProtoReflect.Descriptor instead.
+func (*BackupJobResponse) Descriptor() ([]byte, []int) {
+	return file_controlplane_backup_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BackupJobResponse) GetData() *BackupJob {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *BackupJobResponse) GetStatus() BackupStatusCode {
+	if x != nil {
+		return x.Status
+	}
+	return BackupStatusCode_BACKUP_UNSPECIFIED
+}
+
+func (x *BackupJobResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
 var File_controlplane_backup_proto protoreflect.FileDescriptor
 
 const file_controlplane_backup_proto_rawDesc = "" +
@@ -445,21 +883,70 @@ const file_controlplane_backup_proto_rawDesc = "" +
 	"\x06_error\"\x81\x01\n" +
 	"\x16BackupStoragesResponse\x12/\n" +
 	"\x04data\x18\x01 \x03(\v2\x1b.controlplane.BackupStorageR\x04data\x126\n" +
-	"\x06status\x18\x02 \x01(\x0e2\x1e.controlplane.BackupStatusCodeR\x06status*\x9b\x01\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1e.controlplane.BackupStatusCodeR\x06status\"\x8f\x03\n" +
+	"\tBackupJob\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x02 \x01(\tR\tserviceId\x12\x1d\n" +
+	"\n" +
+	"storage_id\x18\x03 \x01(\tR\tstorageId\x12\x12\n" +
+	"\x04kind\x18\x04 \x01(\tR\x04kind\x12\x18\n" +
+	"\atrigger\x18\x05 \x01(\tR\atrigger\x125\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x1d.controlplane.BackupJobStatusR\x06status\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x12\x16\n" +
+	"\x06bucket\x18\b \x01(\tR\x06bucket\x12\x10\n" +
+	"\x03key\x18\t \x01(\tR\x03key\x12\x12\n" +
+	"\x04size\x18\n" +
+	" \x01(\x03R\x04size\x12\x1a\n" +
+	"\bchecksum\x18\v \x01(\tR\bchecksum\x12\x1f\n" +
+	"\vduration_ms\x18\f \x01(\x03R\n" +
+	"durationMs\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\r \x01(\tR\tstartedAt\x12\x1f\n" +
+	"\vfinished_at\x18\x0e \x01(\tR\n" +
+	"finishedAt\"\x7f\n" +
+	"\x15ListBackupJobsRequest\x12\x1d\n" +
+	"\n" +
+	"storage_id\x18\x01 \x01(\tR\tstorageId\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x02 \x01(\tR\tserviceId\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"\xb4\x01\n" +
+	"\x12BackupJobsResponse\x12+\n" +
+	"\x04data\x18\x01 \x03(\v2\x17.controlplane.BackupJobR\x04data\x126\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1e.controlplane.BackupStatusCodeR\x06status\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x03R\x05total\x12\x19\n" +
+	"\x05error\x18\x04 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
+	"\x06_error\"8\n" +
+	"\x17TriggerBackupJobRequest\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x01 \x01(\tR\tserviceId\"\x9d\x01\n" +
+	"\x11BackupJobResponse\x12+\n" +
+	"\x04data\x18\x01 \x01(\v2\x17.controlplane.BackupJobR\x04data\x126\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1e.controlplane.BackupStatusCodeR\x06status\x12\x19\n" +
+	"\x05error\x18\x03 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
+	"\x06_error*\x9b\x01\n" +
 	"\x10BackupStatusCode\x12\x16\n" +
 	"\x12BACKUP_UNSPECIFIED\x10\x00\x12\x0e\n" +
 	"\tBACKUP_OK\x10\xc8\x01\x12\x13\n" +
 	"\x0eBACKUP_CREATED\x10\xc9\x01\x12\x15\n" +
 	"\x10BACKUP_NOT_FOUND\x10\x94\x03\x12\x1a\n" +
 	"\x15BACKUP_INTERNAL_ERROR\x10\xf4\x03\x12\x17\n" +
-	"\x12BACKUP_BAD_REQUEST\x10\x90\x032\xd7\x03\n" +
+	"\x12BACKUP_BAD_REQUEST\x10\x90\x03*Z\n" +
+	"\x0fBackupJobStatus\x12\x16\n" +
+	"\x12BACKUP_JOB_RUNNING\x10\x00\x12\x18\n" +
+	"\x14BACKUP_JOB_SUCCEEDED\x10\x01\x12\x15\n" +
+	"\x11BACKUP_JOB_FAILED\x10\x022\x80\x05\n" +
 	"\x14BackupStorageService\x12J\n" +
 	"\x06Create\x12\x1b.controlplane.BackupStorage\x1a#.controlplane.BackupStorageResponse\x12I\n" +
 	"\x03Get\x12\x1d.controlplane.BackupStorageId\x1a#.controlplane.BackupStorageResponse\x12:\n" +
 	"\x03All\x12\r.common.Empty\x1a$.controlplane.BackupStoragesResponse\x12J\n" +
 	"\x06Update\x12\x1b.controlplane.BackupStorage\x1a#.controlplane.BackupStorageResponse\x12L\n" +
 	"\x06Delete\x12\x1d.controlplane.BackupStorageId\x1a#.controlplane.BackupMessageResponse\x12R\n" +
-	"\x0eTestConnection\x12\x1b.controlplane.BackupStorage\x1a#.controlplane.BackupMessageResponseB;Z9github.com/zhinea/sylix/internal/infra/proto/controlplaneb\x06proto3"
+	"\x0eTestConnection\x12\x1b.controlplane.BackupStorage\x1a#.controlplane.BackupMessageResponse\x12Q\n" +
+	"\bListJobs\x12#.controlplane.ListBackupJobsRequest\x1a .controlplane.BackupJobsResponse\x12T\n" +
+	"\n" +
+	"TriggerJob\x12%.controlplane.TriggerBackupJobRequest\x1a\x1f.controlplane.BackupJobResponseB;Z9github.com/zhinea/sylix/internal/infra/proto/controlplaneb\x06proto3"
 
 var (
 	file_controlplane_backup_proto_rawDescOnce sync.Once
@@ -473,42 +960,57 @@ func file_controlplane_backup_proto_rawDescGZIP() []byte {
 	return file_controlplane_backup_proto_rawDescData
 }
 
-var file_controlplane_backup_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_controlplane_backup_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_controlplane_backup_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_controlplane_backup_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_controlplane_backup_proto_goTypes = []any{
-	(BackupStatusCode)(0),          // 0: controlplane.BackupStatusCode
-	(*BackupStorageId)(nil),        // 1: controlplane.BackupStorageId
-	(*BackupMessageResponse)(nil),  // 2: controlplane.BackupMessageResponse
-	(*BackupStorage)(nil),          // 3: controlplane.BackupStorage
-	(*BackupStorageResponse)(nil),  // 4: controlplane.BackupStorageResponse
-	(*BackupStoragesResponse)(nil), // 5: controlplane.BackupStoragesResponse
-	(*common.ValidationError)(nil), // 6: common.ValidationError
-	(*common.Empty)(nil),           // 7: common.Empty
+	(BackupStatusCode)(0),           // 0: controlplane.BackupStatusCode
+	(BackupJobStatus)(0),            // 1: controlplane.BackupJobStatus
+	(*BackupStorageId)(nil),         // 2: controlplane.BackupStorageId
+	(*BackupMessageResponse)(nil),   // 3: controlplane.BackupMessageResponse
+	(*BackupStorage)(nil),           // 4: controlplane.BackupStorage
+	(*BackupStorageResponse)(nil),   // 5: controlplane.BackupStorageResponse
+	(*BackupStoragesResponse)(nil),  // 6: controlplane.BackupStoragesResponse
+	(*BackupJob)(nil),               // 7: controlplane.BackupJob
+	(*ListBackupJobsRequest)(nil),   // 8: controlplane.ListBackupJobsRequest
+	(*BackupJobsResponse)(nil),      // 9: controlplane.BackupJobsResponse
+	(*TriggerBackupJobRequest)(nil), // 10: controlplane.TriggerBackupJobRequest
+	(*BackupJobResponse)(nil),       // 11: controlplane.BackupJobResponse
+	(*common.ValidationError)(nil),  // 12: common.ValidationError
+	(*common.Empty)(nil),            // 13: common.Empty
 }
 var file_controlplane_backup_proto_depIdxs = []int32{
 	0,  // 0: controlplane.BackupMessageResponse.status:type_name -> controlplane.BackupStatusCode
-	3,  // 1: controlplane.BackupStorageResponse.data:type_name -> controlplane.BackupStorage
+	4,  // 1: controlplane.BackupStorageResponse.data:type_name -> controlplane.BackupStorage
 	0,  // 2: controlplane.BackupStorageResponse.status:type_name -> controlplane.BackupStatusCode
-	6,  // 3: controlplane.BackupStorageResponse.errors:type_name -> common.ValidationError
-	3,  // 4: controlplane.BackupStoragesResponse.data:type_name -> controlplane.BackupStorage
+	12, // 3: controlplane.BackupStorageResponse.errors:type_name -> common.ValidationError
+	4,  // 4: controlplane.BackupStoragesResponse.data:type_name -> controlplane.BackupStorage
 	0,  // 5: controlplane.BackupStoragesResponse.status:type_name -> controlplane.BackupStatusCode
-	3,  // 6: controlplane.BackupStorageService.Create:input_type -> controlplane.BackupStorage
-	1,  // 7: controlplane.BackupStorageService.Get:input_type -> controlplane.BackupStorageId
-	7,  // 8: controlplane.BackupStorageService.All:input_type -> common.Empty
-	3,  // 9: controlplane.BackupStorageService.Update:input_type -> controlplane.BackupStorage
-	1,  // 10: controlplane.BackupStorageService.Delete:input_type -> controlplane.BackupStorageId
-	3,  // 11: controlplane.BackupStorageService.TestConnection:input_type -> controlplane.BackupStorage
-	4,  // 12: controlplane.BackupStorageService.Create:output_type -> controlplane.BackupStorageResponse
-	4,  // 13: controlplane.BackupStorageService.Get:output_type -> controlplane.BackupStorageResponse
-	5,  // 14: controlplane.BackupStorageService.All:output_type -> controlplane.BackupStoragesResponse
-	4,  // 15: controlplane.BackupStorageService.Update:output_type -> controlplane.BackupStorageResponse
-	2,  // 16: controlplane.BackupStorageService.Delete:output_type -> controlplane.BackupMessageResponse
-	2,  // 17: controlplane.BackupStorageService.TestConnection:output_type -> controlplane.BackupMessageResponse
-	12, // [12:18] is the sub-list for method output_type
-	6,  // [6:12] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	1,  // 6: controlplane.BackupJob.status:type_name -> controlplane.BackupJobStatus
+	7,  // 7: controlplane.BackupJobsResponse.data:type_name -> controlplane.BackupJob
+	0,  // 8: controlplane.BackupJobsResponse.status:type_name -> controlplane.BackupStatusCode
+	7,  // 9: controlplane.BackupJobResponse.data:type_name -> controlplane.BackupJob
+	0,  // 10: controlplane.BackupJobResponse.status:type_name -> controlplane.BackupStatusCode
+	4,  // 11: controlplane.BackupStorageService.Create:input_type -> controlplane.BackupStorage
+	2,  // 12: controlplane.BackupStorageService.Get:input_type -> controlplane.BackupStorageId
+	13, // 13: controlplane.BackupStorageService.All:input_type -> common.Empty
+	4,  // 14: controlplane.BackupStorageService.Update:input_type -> controlplane.BackupStorage
+	2,  // 15: controlplane.BackupStorageService.Delete:input_type -> controlplane.BackupStorageId
+	4,  // 16: controlplane.BackupStorageService.TestConnection:input_type -> controlplane.BackupStorage
+	8,  // 17: controlplane.BackupStorageService.ListJobs:input_type -> controlplane.ListBackupJobsRequest
+	10, // 18: controlplane.BackupStorageService.TriggerJob:input_type -> controlplane.TriggerBackupJobRequest
+	5,  // 19: controlplane.BackupStorageService.Create:output_type -> controlplane.BackupStorageResponse
+	5,  // 20: controlplane.BackupStorageService.Get:output_type -> controlplane.BackupStorageResponse
+	6,  // 21: controlplane.BackupStorageService.All:output_type -> controlplane.BackupStoragesResponse
+	5,  // 22: controlplane.BackupStorageService.Update:output_type -> controlplane.BackupStorageResponse
+	3,  // 23: controlplane.BackupStorageService.Delete:output_type -> controlplane.BackupMessageResponse
+	3,  // 24: controlplane.BackupStorageService.TestConnection:output_type -> controlplane.BackupMessageResponse
+	9,  // 25: controlplane.BackupStorageService.ListJobs:output_type -> controlplane.BackupJobsResponse
+	11, // 26: controlplane.BackupStorageService.TriggerJob:output_type -> controlplane.BackupJobResponse
+	19, // [19:27] is the sub-list for method output_type
+	11, // [11:19] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_controlplane_backup_proto_init() }
@@ -517,13 +1019,15 @@ func file_controlplane_backup_proto_init() {
 		return
 	}
 	file_controlplane_backup_proto_msgTypes[3].OneofWrappers = []any{}
+	file_controlplane_backup_proto_msgTypes[7].OneofWrappers = []any{}
+	file_controlplane_backup_proto_msgTypes[9].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_controlplane_backup_proto_rawDesc), len(file_controlplane_backup_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   5,
+			NumEnums:      2,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
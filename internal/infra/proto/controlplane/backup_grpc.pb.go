@@ -26,6 +26,8 @@ const (
 	BackupStorageService_Update_FullMethodName         = "/controlplane.BackupStorageService/Update"
 	BackupStorageService_Delete_FullMethodName         = "/controlplane.BackupStorageService/Delete"
 	BackupStorageService_TestConnection_FullMethodName = "/controlplane.BackupStorageService/TestConnection"
+	BackupStorageService_ListJobs_FullMethodName       = "/controlplane.BackupStorageService/ListJobs"
+	BackupStorageService_TriggerJob_FullMethodName     = "/controlplane.BackupStorageService/TriggerJob"
 )
 
 // BackupStorageServiceClient is the client API for BackupStorageService service.
@@ -38,6 +40,10 @@ type BackupStorageServiceClient interface {
 	Update(ctx context.Context, in *BackupStorage, opts ...grpc.CallOption) (*BackupStorageResponse, error)
 	Delete(ctx context.Context, in *BackupStorageId, opts ...grpc.CallOption) (*BackupMessageResponse, error)
 	TestConnection(ctx context.Context, in *BackupStorage, opts ...grpc.CallOption) (*BackupMessageResponse, error)
+	// Lists the backups taken of services, latest first
+	ListJobs(ctx context.Context, in *ListBackupJobsRequest, opts ...grpc.CallOption) (*BackupJobsResponse, error)
+	// Starts a backup of a service now, the job is returned while it runs
+	TriggerJob(ctx context.Context, in *TriggerBackupJobRequest, opts ...grpc.CallOption) (*BackupJobResponse, error)
 }
 
 type backupStorageServiceClient struct {
@@ -108,6 +114,26 @@ func (c *backupStorageServiceClient) TestConnection(ctx context.Context, in *Bac
 	return out, nil
 }
 
+func (c *backupStorageServiceClient) ListJobs(ctx context.Context, in *ListBackupJobsRequest, opts ...grpc.CallOption) (*BackupJobsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BackupJobsResponse)
+	err := c.cc.Invoke(ctx, BackupStorageService_ListJobs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *backupStorageServiceClient) TriggerJob(ctx context.Context, in *TriggerBackupJobRequest, opts ...grpc.CallOption) (*BackupJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BackupJobResponse)
+	err := c.cc.Invoke(ctx, BackupStorageService_TriggerJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // BackupStorageServiceServer is the server API for BackupStorageService service.
 // All implementations must embed UnimplementedBackupStorageServiceServer
 // for forward compatibility.
@@ -118,6 +144,10 @@ type BackupStorageServiceServer interface {
 	Update(context.Context, *BackupStorage) (*BackupStorageResponse, error)
 	Delete(context.Context, *BackupStorageId) (*BackupMessageResponse, error)
 	TestConnection(context.Context, *BackupStorage) (*BackupMessageResponse, error)
+	// Lists the backups taken of services, latest first
+	ListJobs(context.Context, *ListBackupJobsRequest) (*BackupJobsResponse, error)
+	// Starts a backup of a service now, the job is returned while it runs
+	TriggerJob(context.Context, *TriggerBackupJobRequest) (*BackupJobResponse, error)
 	mustEmbedUnimplementedBackupStorageServiceServer()
 }
 
@@ -146,6 +176,12 @@ func (UnimplementedBackupStorageServiceServer) Delete(context.Context, *BackupSt
 func (UnimplementedBackupStorageServiceServer) TestConnection(context.Context, *BackupStorage) (*BackupMessageResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TestConnection not implemented")
 }
+func (UnimplementedBackupStorageServiceServer) ListJobs(context.Context, *ListBackupJobsRequest) (*BackupJobsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJobs not implemented")
+}
+func (UnimplementedBackupStorageServiceServer) TriggerJob(context.Context, *TriggerBackupJobRequest) (*BackupJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TriggerJob not implemented")
+}
 func (UnimplementedBackupStorageServiceServer) mustEmbedUnimplementedBackupStorageServiceServer() {}
 func (UnimplementedBackupStorageServiceServer) testEmbeddedByValue()                              {}
 
@@ -275,6 +311,42 @@ func _BackupStorageService_TestConnection_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _BackupStorageService_ListJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBackupJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackupStorageServiceServer).ListJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BackupStorageService_ListJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackupStorageServiceServer).ListJobs(ctx, req.(*ListBackupJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BackupStorageService_TriggerJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TriggerBackupJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackupStorageServiceServer).TriggerJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BackupStorageService_TriggerJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackupStorageServiceServer).TriggerJob(ctx, req.(*TriggerBackupJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // BackupStorageService_ServiceDesc is the grpc.ServiceDesc for BackupStorageService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -306,6 +378,14 @@ var BackupStorageService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "TestConnection",
 			Handler:    _BackupStorageService_TestConnection_Handler,
 		},
+		{
+			MethodName: "ListJobs",
+			Handler:    _BackupStorageService_ListJobs_Handler,
+		},
+		{
+			MethodName: "TriggerJob",
+			Handler:    _BackupStorageService_TriggerJob_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controlplane/backup.proto",
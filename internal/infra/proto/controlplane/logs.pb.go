@@ -27,6 +27,7 @@ type LogFile struct {
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
 	LastModified  string                 `protobuf:"bytes,3,opt,name=last_modified,json=lastModified,proto3" json:"last_modified,omitempty"`
+	Archived      bool                   `protobuf:"varint,4,opt,name=archived,proto3" json:"archived,omitempty"` // only left in backup storage, read from there
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -82,6 +83,13 @@ func (x *LogFile) GetLastModified() string {
 	return ""
 }
 
+func (x *LogFile) GetArchived() bool {
+	if x != nil {
+		return x.Archived
+	}
+	return false
+}
+
 type GetSystemLogsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Files         []*LogFile             `protobuf:"bytes,1,rep,name=files,proto3" json:"files,omitempty"`
@@ -131,6 +139,7 @@ type ReadSystemLogRequest struct {
 	Filename      string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"`
 	Page          int32                  `protobuf:"varint,2,opt,name=page,proto3" json:"page,omitempty"`
 	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Reverse       bool                   `protobuf:"varint,4,opt,name=reverse,proto3" json:"reverse,omitempty"` // pages count from the end of the file, page 1 holds the latest lines
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -186,6 +195,13 @@ func (x *ReadSystemLogRequest) GetPageSize() int32 {
 	return 0
 }
 
+func (x *ReadSystemLogRequest) GetReverse() bool {
+	if x != nil {
+		return x.Reverse
+	}
+	return false
+}
+
 type ReadSystemLogResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Lines         []string               `protobuf:"bytes,1,rep,name=lines,proto3" json:"lines,omitempty"`
@@ -348,6 +364,7 @@ type ReadServerLogRequest struct {
 	Filename      string                 `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
 	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`
 	PageSize      int32                  `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Reverse       bool                   `protobuf:"varint,5,opt,name=reverse,proto3" json:"reverse,omitempty"` // pages count from the end of the file, page 1 holds the latest lines
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -410,6 +427,13 @@ func (x *ReadServerLogRequest) GetPageSize() int32 {
 	return 0
 }
 
+func (x *ReadServerLogRequest) GetReverse() bool {
+	if x != nil {
+		return x.Reverse
+	}
+	return false
+}
+
 type ReadServerLogResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Lines         []string               `protobuf:"bytes,1,rep,name=lines,proto3" json:"lines,omitempty"`
@@ -478,96 +502,1183 @@ func (x *ReadServerLogResponse) GetTotalPages() int32 {
 	return 0
 }
 
-var File_controlplane_logs_proto protoreflect.FileDescriptor
+type SearchLogRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Filename       string                 `protobuf:"bytes,1,opt,name=filename,proto3" json:"filename,omitempty"` // path of the file under logs/, as listed by GetSystemLogs
+	Query          string                 `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"`
+	Regex          bool                   `protobuf:"varint,3,opt,name=regex,proto3" json:"regex,omitempty"` // query is a regular expression
+	IgnoreCase     bool                   `protobuf:"varint,4,opt,name=ignore_case,json=ignoreCase,proto3" json:"ignore_case,omitempty"`
+	Level          string                 `protobuf:"bytes,5,opt,name=level,proto3" json:"level,omitempty"`                                          // lowest level of the JSON records to match, such as warn
+	IncludeRotated bool                   `protobuf:"varint,6,opt,name=include_rotated,json=includeRotated,proto3" json:"include_rotated,omitempty"` // also search the rotated backups of the file, oldest first
+	Page           int32                  `protobuf:"varint,7,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize       int32                  `protobuf:"varint,8,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Reverse        bool                   `protobuf:"varint,9,opt,name=reverse,proto3" json:"reverse,omitempty"` // pages count from the last match, page 1 holds the latest matches
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *SearchLogRequest) Reset() {
+	*x = SearchLogRequest{}
+	mi := &file_controlplane_logs_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-const file_controlplane_logs_proto_rawDesc = "" +
-	"\n" +
-	"\x17controlplane/logs.proto\x12\fcontrolplane\x1a\x13common/common.proto\"V\n" +
-	"\aLogFile\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
-	"\x04size\x18\x02 \x01(\x03R\x04size\x12#\n" +
-	"\rlast_modified\x18\x03 \x01(\tR\flastModified\"D\n" +
-	"\x15GetSystemLogsResponse\x12+\n" +
-	"\x05files\x18\x01 \x03(\v2\x15.controlplane.LogFileR\x05files\"c\n" +
-	"\x14ReadSystemLogRequest\x12\x1a\n" +
-	"\bfilename\x18\x01 \x01(\tR\bfilename\x12\x12\n" +
-	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\"\x92\x01\n" +
-	"\x15ReadSystemLogResponse\x12\x14\n" +
-	"\x05lines\x18\x01 \x03(\tR\x05lines\x12\x1f\n" +
-	"\vtotal_lines\x18\x02 \x01(\x05R\n" +
-	"totalLines\x12!\n" +
-	"\fcurrent_page\x18\x03 \x01(\x05R\vcurrentPage\x12\x1f\n" +
-	"\vtotal_pages\x18\x04 \x01(\x05R\n" +
-	"totalPages\"3\n" +
-	"\x14GetServerLogsRequest\x12\x1b\n" +
-	"\tserver_id\x18\x01 \x01(\tR\bserverId\"D\n" +
-	"\x15GetServerLogsResponse\x12+\n" +
-	"\x05files\x18\x01 \x03(\v2\x15.controlplane.LogFileR\x05files\"\x80\x01\n" +
-	"\x14ReadServerLogRequest\x12\x1b\n" +
-	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x1a\n" +
-	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x12\n" +
-	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
-	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\"\x92\x01\n" +
-	"\x15ReadServerLogResponse\x12\x14\n" +
-	"\x05lines\x18\x01 \x03(\tR\x05lines\x12\x1f\n" +
-	"\vtotal_lines\x18\x02 \x01(\x05R\n" +
-	"totalLines\x12!\n" +
-	"\fcurrent_page\x18\x03 \x01(\x05R\vcurrentPage\x12\x1f\n" +
-	"\vtotal_pages\x18\x04 \x01(\x05R\n" +
-	"totalPages2\xe0\x02\n" +
-	"\vLogsService\x12C\n" +
-	"\rGetSystemLogs\x12\r.common.Empty\x1a#.controlplane.GetSystemLogsResponse\x12X\n" +
-	"\rReadSystemLog\x12\".controlplane.ReadSystemLogRequest\x1a#.controlplane.ReadSystemLogResponse\x12X\n" +
-	"\rGetServerLogs\x12\".controlplane.GetServerLogsRequest\x1a#.controlplane.GetServerLogsResponse\x12X\n" +
-	"\rReadServerLog\x12\".controlplane.ReadServerLogRequest\x1a#.controlplane.ReadServerLogResponseB;Z9github.com/zhinea/sylix/internal/infra/proto/controlplaneb\x06proto3"
+func (x *SearchLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-var (
-	file_controlplane_logs_proto_rawDescOnce sync.Once
-	file_controlplane_logs_proto_rawDescData []byte
-)
+func (*SearchLogRequest) ProtoMessage() {}
 
-func file_controlplane_logs_proto_rawDescGZIP() []byte {
-	file_controlplane_logs_proto_rawDescOnce.Do(func() {
-		file_controlplane_logs_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_controlplane_logs_proto_rawDesc), len(file_controlplane_logs_proto_rawDesc)))
-	})
-	return file_controlplane_logs_proto_rawDescData
+func (x *SearchLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_logs_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var file_controlplane_logs_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
-var file_controlplane_logs_proto_goTypes = []any{
-	(*LogFile)(nil),               // 0: controlplane.LogFile
-	(*GetSystemLogsResponse)(nil), // 1: controlplane.GetSystemLogsResponse
-	(*ReadSystemLogRequest)(nil),  // 2: controlplane.ReadSystemLogRequest
-	(*ReadSystemLogResponse)(nil), // 3: controlplane.ReadSystemLogResponse
-	(*GetServerLogsRequest)(nil),  // 4: controlplane.GetServerLogsRequest
-	(*GetServerLogsResponse)(nil), // 5: controlplane.GetServerLogsResponse
-	(*ReadServerLogRequest)(nil),  // 6: controlplane.ReadServerLogRequest
-	(*ReadServerLogResponse)(nil), // 7: controlplane.ReadServerLogResponse
-	(*common.Empty)(nil),          // 8: common.Empty
+// Deprecated: Use SearchLogRequest.ProtoReflect.Descriptor instead.
+func (*SearchLogRequest) Descriptor() ([]byte, []int) {
+	return file_controlplane_logs_proto_rawDescGZIP(), []int{8}
 }
-var file_controlplane_logs_proto_depIdxs = []int32{
-	0, // 0: controlplane.GetSystemLogsResponse.files:type_name -> controlplane.LogFile
-	0, // 1: controlplane.GetServerLogsResponse.files:type_name -> controlplane.LogFile
-	8, // 2: controlplane.LogsService.GetSystemLogs:input_type -> common.Empty
-	2, // 3: controlplane.LogsService.ReadSystemLog:input_type -> controlplane.ReadSystemLogRequest
-	4, // 4: controlplane.LogsService.GetServerLogs:input_type -> controlplane.GetServerLogsRequest
-	6, // 5: controlplane.LogsService.ReadServerLog:input_type -> controlplane.ReadServerLogRequest
-	1, // 6: controlplane.LogsService.GetSystemLogs:output_type -> controlplane.GetSystemLogsResponse
-	3, // 7: controlplane.LogsService.ReadSystemLog:output_type -> controlplane.ReadSystemLogResponse
-	5, // 8: controlplane.LogsService.GetServerLogs:output_type -> controlplane.GetServerLogsResponse
-	7, // 9: controlplane.LogsService.ReadServerLog:output_type -> controlplane.ReadServerLogResponse
-	6, // [6:10] is the sub-list for method output_type
-	2, // [2:6] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+
+func (x *SearchLogRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
 }
 
-func init() { file_controlplane_logs_proto_init() }
-func file_controlplane_logs_proto_init() {
-	if File_controlplane_logs_proto != nil {
-		return
+func (x *SearchLogRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchLogRequest) GetRegex() bool {
+	if x != nil {
+		return x.Regex
+	}
+	return false
+}
+
+func (x *SearchLogRequest) GetIgnoreCase() bool {
+	if x != nil {
+		return x.IgnoreCase
+	}
+	return false
+}
+
+func (x *SearchLogRequest) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *SearchLogRequest) GetIncludeRotated() bool {
+	if x != nil {
+		return x.IncludeRotated
+	}
+	return false
+}
+
+func (x *SearchLogRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *SearchLogRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchLogRequest) GetReverse() bool {
+	if x != nil {
+		return x.Reverse
+	}
+	return false
+}
+
+type LogMatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	File          string                 `protobuf:"bytes,1,opt,name=file,proto3" json:"file,omitempty"`
+	Line          int32                  `protobuf:"varint,2,opt,name=line,proto3" json:"line,omitempty"` // counting from 1
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogMatch) Reset() {
+	*x = LogMatch{}
+	mi := &file_controlplane_logs_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogMatch) ProtoMessage() {}
+
+func (x *LogMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_logs_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogMatch.ProtoReflect.Descriptor instead.
+func (*LogMatch) Descriptor() ([]byte, []int) {
+	return file_controlplane_logs_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *LogMatch) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *LogMatch) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+func (x *LogMatch) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type SearchLogResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Matches       []*LogMatch            `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+	TotalMatches  int32                  `protobuf:"varint,2,opt,name=total_matches,json=totalMatches,proto3" json:"total_matches,omitempty"`
+	CurrentPage   int32                  `protobuf:"varint,3,opt,name=current_page,json=currentPage,proto3" json:"current_page,omitempty"`
+	TotalPages    int32                  `protobuf:"varint,4,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchLogResponse) Reset() {
+	*x = SearchLogResponse{}
+	mi := &file_controlplane_logs_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchLogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchLogResponse) ProtoMessage() {}
+
+func (x *SearchLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_logs_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchLogResponse.ProtoReflect.Descriptor instead.
+func (*SearchLogResponse) Descriptor() ([]byte, []int) {
+	return file_controlplane_logs_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SearchLogResponse) GetMatches() []*LogMatch {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *SearchLogResponse) GetTotalMatches() int32 {
+	if x != nil {
+		return x.TotalMatches
+	}
+	return 0
+}
+
+func (x *SearchLogResponse) GetCurrentPage() int32 {
+	if x != nil {
+		return x.CurrentPage
+	}
+	return 0
+}
+
+func (x *SearchLogResponse) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+type QueryAppLogRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         string                 `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`                                                                             // lowest level of the records, such as warn
+	Since         string                 `protobuf:"bytes,2,opt,name=since,proto3" json:"since,omitempty"`                                                                             // RFC 3339
+	Until         string                 `protobuf:"bytes,3,opt,name=until,proto3" json:"until,omitempty"`                                                                             // RFC 3339, excluded
+	Fields        map[string]string      `protobuf:"bytes,4,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // fields the records must have, such as server_id
+	Message       string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`                                                                         // text the message contains, whatever its case
+	Page          int32                  `protobuf:"varint,6,opt,name=page,proto3" json:"page,omitempty"`
+	PageSize      int32                  `protobuf:"varint,7,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	Reverse       bool                   `protobuf:"varint,8,opt,name=reverse,proto3" json:"reverse,omitempty"` // pages count from the latest record
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryAppLogRequest) Reset() {
+	*x = QueryAppLogRequest{}
+	mi := &file_controlplane_logs_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryAppLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryAppLogRequest) ProtoMessage() {}
+
+func (x *QueryAppLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_logs_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryAppLogRequest.ProtoReflect.Descriptor instead.
+func (*QueryAppLogRequest) Descriptor() ([]byte, []int) {
+	return file_controlplane_logs_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *QueryAppLogRequest) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *QueryAppLogRequest) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+func (x *QueryAppLogRequest) GetUntil() string {
+	if x != nil {
+		return x.Until
+	}
+	return ""
+}
+
+func (x *QueryAppLogRequest) GetFields() map[string]string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *QueryAppLogRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *QueryAppLogRequest) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *QueryAppLogRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *QueryAppLogRequest) GetReverse() bool {
+	if x != nil {
+		return x.Reverse
+	}
+	return false
+}
+
+type AppLogEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Time          string                 `protobuf:"bytes,1,opt,name=time,proto3" json:"time,omitempty"` // RFC 3339 with nanoseconds
+	Level         string                 `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	Logger        string                 `protobuf:"bytes,4,opt,name=logger,proto3" json:"logger,omitempty"`
+	Caller        string                 `protobuf:"bytes,5,opt,name=caller,proto3" json:"caller,omitempty"`
+	Stacktrace    string                 `protobuf:"bytes,6,opt,name=stacktrace,proto3" json:"stacktrace,omitempty"`
+	Fields        map[string]string      `protobuf:"bytes,7,rep,name=fields,proto3" json:"fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // values that are not strings are JSON
+	File          string                 `protobuf:"bytes,8,opt,name=file,proto3" json:"file,omitempty"`
+	Line          int32                  `protobuf:"varint,9,opt,name=line,proto3" json:"line,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AppLogEntry) Reset() {
+	*x = AppLogEntry{}
+	mi := &file_controlplane_logs_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppLogEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppLogEntry) ProtoMessage() {}
+
+func (x *AppLogEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_logs_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppLogEntry.ProtoReflect.Descriptor instead.
+func (*AppLogEntry) Descriptor() ([]byte, []int) {
+	return file_controlplane_logs_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *AppLogEntry) GetTime() string {
+	if x != nil {
+		return x.Time
+	}
+	return ""
+}
+
+func (x *AppLogEntry) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *AppLogEntry) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *AppLogEntry) GetLogger() string {
+	if x != nil {
+		return x.Logger
+	}
+	return ""
+}
+
+func (x *AppLogEntry) GetCaller() string {
+	if x != nil {
+		return x.Caller
+	}
+	return ""
+}
+
+func (x *AppLogEntry) GetStacktrace() string {
+	if x != nil {
+		return x.Stacktrace
+	}
+	return ""
+}
+
+func (x *AppLogEntry) GetFields() map[string]string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *AppLogEntry) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+func (x *AppLogEntry) GetLine() int32 {
+	if x != nil {
+		return x.Line
+	}
+	return 0
+}
+
+type QueryAppLogResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*AppLogEntry         `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"` // oldest first
+	TotalEntries  int32                  `protobuf:"varint,2,opt,name=total_entries,json=totalEntries,proto3" json:"total_entries,omitempty"`
+	CurrentPage   int32                  `protobuf:"varint,3,opt,name=current_page,json=currentPage,proto3" json:"current_page,omitempty"`
+	TotalPages    int32                  `protobuf:"varint,4,opt,name=total_pages,json=totalPages,proto3" json:"total_pages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryAppLogResponse) Reset() {
+	*x = QueryAppLogResponse{}
+	mi := &file_controlplane_logs_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryAppLogResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryAppLogResponse) ProtoMessage() {}
+
+func (x *QueryAppLogResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_logs_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryAppLogResponse.ProtoReflect.Descriptor instead.
+func (*QueryAppLogResponse) Descriptor() ([]byte, []int) {
+	return file_controlplane_logs_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *QueryAppLogResponse) GetEntries() []*AppLogEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *QueryAppLogResponse) GetTotalEntries() int32 {
+	if x != nil {
+		return x.TotalEntries
+	}
+	return 0
+}
+
+func (x *QueryAppLogResponse) GetCurrentPage() int32 {
+	if x != nil {
+		return x.CurrentPage
+	}
+	return 0
+}
+
+func (x *QueryAppLogResponse) GetTotalPages() int32 {
+	if x != nil {
+		return x.TotalPages
+	}
+	return 0
+}
+
+type TailLogRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One of server_id or deployment_id
+	ServerId      string `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	DeploymentId  string `protobuf:"bytes,2,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	Filename      string `protobuf:"bytes,3,opt,name=filename,proto3" json:"filename,omitempty"` // file of the server's logs, provisioning.log when empty
+	Offset        int64  `protobuf:"varint,4,opt,name=offset,proto3" json:"offset,omitempty"`    // byte offset of the first line to send, negative to only send new lines
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TailLogRequest) Reset() {
+	*x = TailLogRequest{}
+	mi := &file_controlplane_logs_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TailLogRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TailLogRequest) ProtoMessage() {}
+
+func (x *TailLogRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_logs_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TailLogRequest.ProtoReflect.Descriptor instead.
+func (*TailLogRequest) Descriptor() ([]byte, []int) {
+	return file_controlplane_logs_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *TailLogRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *TailLogRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *TailLogRequest) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *TailLogRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type LogStepEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Host          string                 `protobuf:"bytes,3,opt,name=host,proto3" json:"host,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"` // pending, running, succeeded, failed, skipped, ignored, rolled_back, rollback_failed or cancelled
+	Error         string                 `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`
+	Time          string                 `protobuf:"bytes,6,opt,name=time,proto3" json:"time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogStepEvent) Reset() {
+	*x = LogStepEvent{}
+	mi := &file_controlplane_logs_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogStepEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogStepEvent) ProtoMessage() {}
+
+func (x *LogStepEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_logs_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogStepEvent.ProtoReflect.Descriptor instead.
+func (*LogStepEvent) Descriptor() ([]byte, []int) {
+	return file_controlplane_logs_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *LogStepEvent) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *LogStepEvent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *LogStepEvent) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *LogStepEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *LogStepEvent) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *LogStepEvent) GetTime() string {
+	if x != nil {
+		return x.Time
+	}
+	return ""
+}
+
+type TailLogEvent struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Offset int64                  `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"` // where the next line starts, to tail again from after a disconnect
+	// Types that are valid to be assigned to Event:
+	//
+	//	*TailLogEvent_Line
+	//	*TailLogEvent_Step
+	//	*TailLogEvent_Reset_
+	Event         isTailLogEvent_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TailLogEvent) Reset() {
+	*x = TailLogEvent{}
+	mi := &file_controlplane_logs_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TailLogEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TailLogEvent) ProtoMessage() {}
+
+func (x *TailLogEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_logs_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TailLogEvent.ProtoReflect.Descriptor instead.
+func (*TailLogEvent) Descriptor() ([]byte, []int) {
+	return file_controlplane_logs_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *TailLogEvent) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *TailLogEvent) GetEvent() isTailLogEvent_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *TailLogEvent) GetLine() string {
+	if x != nil {
+		if x, ok := x.Event.(*TailLogEvent_Line); ok {
+			return x.Line
+		}
+	}
+	return ""
+}
+
+func (x *TailLogEvent) GetStep() *LogStepEvent {
+	if x != nil {
+		if x, ok := x.Event.(*TailLogEvent_Step); ok {
+			return x.Step
+		}
+	}
+	return nil
+}
+
+func (x *TailLogEvent) GetReset_() bool {
+	if x != nil {
+		if x, ok := x.Event.(*TailLogEvent_Reset_); ok {
+			return x.Reset_
+		}
+	}
+	return false
+}
+
+type isTailLogEvent_Event interface {
+	isTailLogEvent_Event()
+}
+
+type TailLogEvent_Line struct {
+	Line string `protobuf:"bytes,2,opt,name=line,proto3,oneof"`
+}
+
+type TailLogEvent_Step struct {
+	Step *LogStepEvent `protobuf:"bytes,3,opt,name=step,proto3,oneof"`
+}
+
+type TailLogEvent_Reset_ struct {
+	Reset_ bool `protobuf:"varint,4,opt,name=reset,proto3,oneof"` // the file was truncated, the lines that follow start from its beginning
+}
+
+func (*TailLogEvent_Line) isTailLogEvent_Event() {}
+
+func (*TailLogEvent_Step) isTailLogEvent_Event() {}
+
+func (*TailLogEvent_Reset_) isTailLogEvent_Event() {}
+
+type ListArchivedLogsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"` // logs of the server, every log when empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListArchivedLogsRequest) Reset() {
+	*x = ListArchivedLogsRequest{}
+	mi := &file_controlplane_logs_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListArchivedLogsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListArchivedLogsRequest) ProtoMessage() {}
+
+func (x *ListArchivedLogsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_logs_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListArchivedLogsRequest.ProtoReflect.Descriptor instead.
+func (*ListArchivedLogsRequest) Descriptor() ([]byte, []int) {
+	return file_controlplane_logs_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ListArchivedLogsRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+type ArchivedLog struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	StorageId     string                 `protobuf:"bytes,2,opt,name=storage_id,json=storageId,proto3" json:"storage_id,omitempty"`
+	Bucket        string                 `protobuf:"bytes,3,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Key           string                 `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
+	Size          int64                  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`
+	Local         bool                   `protobuf:"varint,6,opt,name=local,proto3" json:"local,omitempty"` // the local copy is kept too
+	ModifiedAt    string                 `protobuf:"bytes,7,opt,name=modified_at,json=modifiedAt,proto3" json:"modified_at,omitempty"`
+	ArchivedAt    string                 `protobuf:"bytes,8,opt,name=archived_at,json=archivedAt,proto3" json:"archived_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArchivedLog) Reset() {
+	*x = ArchivedLog{}
+	mi := &file_controlplane_logs_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchivedLog) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchivedLog) ProtoMessage() {}
+
+func (x *ArchivedLog) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_logs_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchivedLog.ProtoReflect.Descriptor instead.
+func (*ArchivedLog) Descriptor() ([]byte, []int) {
+	return file_controlplane_logs_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ArchivedLog) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ArchivedLog) GetStorageId() string {
+	if x != nil {
+		return x.StorageId
+	}
+	return ""
+}
+
+func (x *ArchivedLog) GetBucket() string {
+	if x != nil {
+		return x.Bucket
+	}
+	return ""
+}
+
+func (x *ArchivedLog) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ArchivedLog) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *ArchivedLog) GetLocal() bool {
+	if x != nil {
+		return x.Local
+	}
+	return false
+}
+
+func (x *ArchivedLog) GetModifiedAt() string {
+	if x != nil {
+		return x.ModifiedAt
+	}
+	return ""
+}
+
+func (x *ArchivedLog) GetArchivedAt() string {
+	if x != nil {
+		return x.ArchivedAt
+	}
+	return ""
+}
+
+type ListArchivedLogsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Logs          []*ArchivedLog         `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"` // oldest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListArchivedLogsResponse) Reset() {
+	*x = ListArchivedLogsResponse{}
+	mi := &file_controlplane_logs_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListArchivedLogsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListArchivedLogsResponse) ProtoMessage() {}
+
+func (x *ListArchivedLogsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_logs_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListArchivedLogsResponse.ProtoReflect.Descriptor instead.
+func (*ListArchivedLogsResponse) Descriptor() ([]byte, []int) {
+	return file_controlplane_logs_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListArchivedLogsResponse) GetLogs() []*ArchivedLog {
+	if x != nil {
+		return x.Logs
+	}
+	return nil
+}
+
+var File_controlplane_logs_proto protoreflect.FileDescriptor
+
+const file_controlplane_logs_proto_rawDesc = "" +
+	"\n" +
+	"\x17controlplane/logs.proto\x12\fcontrolplane\x1a\x13common/common.proto\"r\n" +
+	"\aLogFile\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12#\n" +
+	"\rlast_modified\x18\x03 \x01(\tR\flastModified\x12\x1a\n" +
+	"\barchived\x18\x04 \x01(\bR\barchived\"D\n" +
+	"\x15GetSystemLogsResponse\x12+\n" +
+	"\x05files\x18\x01 \x03(\v2\x15.controlplane.LogFileR\x05files\"}\n" +
+	"\x14ReadSystemLogRequest\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12\x12\n" +
+	"\x04page\x18\x02 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x18\n" +
+	"\areverse\x18\x04 \x01(\bR\areverse\"\x92\x01\n" +
+	"\x15ReadSystemLogResponse\x12\x14\n" +
+	"\x05lines\x18\x01 \x03(\tR\x05lines\x12\x1f\n" +
+	"\vtotal_lines\x18\x02 \x01(\x05R\n" +
+	"totalLines\x12!\n" +
+	"\fcurrent_page\x18\x03 \x01(\x05R\vcurrentPage\x12\x1f\n" +
+	"\vtotal_pages\x18\x04 \x01(\x05R\n" +
+	"totalPages\"3\n" +
+	"\x14GetServerLogsRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\"D\n" +
+	"\x15GetServerLogsResponse\x12+\n" +
+	"\x05files\x18\x01 \x03(\v2\x15.controlplane.LogFileR\x05files\"\x9a\x01\n" +
+	"\x14ReadServerLogRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x1a\n" +
+	"\bfilename\x18\x02 \x01(\tR\bfilename\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\x04 \x01(\x05R\bpageSize\x12\x18\n" +
+	"\areverse\x18\x05 \x01(\bR\areverse\"\x92\x01\n" +
+	"\x15ReadServerLogResponse\x12\x14\n" +
+	"\x05lines\x18\x01 \x03(\tR\x05lines\x12\x1f\n" +
+	"\vtotal_lines\x18\x02 \x01(\x05R\n" +
+	"totalLines\x12!\n" +
+	"\fcurrent_page\x18\x03 \x01(\x05R\vcurrentPage\x12\x1f\n" +
+	"\vtotal_pages\x18\x04 \x01(\x05R\n" +
+	"totalPages\"\x85\x02\n" +
+	"\x10SearchLogRequest\x12\x1a\n" +
+	"\bfilename\x18\x01 \x01(\tR\bfilename\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\x12\x14\n" +
+	"\x05regex\x18\x03 \x01(\bR\x05regex\x12\x1f\n" +
+	"\vignore_case\x18\x04 \x01(\bR\n" +
+	"ignoreCase\x12\x14\n" +
+	"\x05level\x18\x05 \x01(\tR\x05level\x12'\n" +
+	"\x0finclude_rotated\x18\x06 \x01(\bR\x0eincludeRotated\x12\x12\n" +
+	"\x04page\x18\a \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\b \x01(\x05R\bpageSize\x12\x18\n" +
+	"\areverse\x18\t \x01(\bR\areverse\"F\n" +
+	"\bLogMatch\x12\x12\n" +
+	"\x04file\x18\x01 \x01(\tR\x04file\x12\x12\n" +
+	"\x04line\x18\x02 \x01(\x05R\x04line\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\"\xae\x01\n" +
+	"\x11SearchLogResponse\x120\n" +
+	"\amatches\x18\x01 \x03(\v2\x16.controlplane.LogMatchR\amatches\x12#\n" +
+	"\rtotal_matches\x18\x02 \x01(\x05R\ftotalMatches\x12!\n" +
+	"\fcurrent_page\x18\x03 \x01(\x05R\vcurrentPage\x12\x1f\n" +
+	"\vtotal_pages\x18\x04 \x01(\x05R\n" +
+	"totalPages\"\xbc\x02\n" +
+	"\x12QueryAppLogRequest\x12\x14\n" +
+	"\x05level\x18\x01 \x01(\tR\x05level\x12\x14\n" +
+	"\x05since\x18\x02 \x01(\tR\x05since\x12\x14\n" +
+	"\x05until\x18\x03 \x01(\tR\x05until\x12D\n" +
+	"\x06fields\x18\x04 \x03(\v2,.controlplane.QueryAppLogRequest.FieldsEntryR\x06fields\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\x12\x12\n" +
+	"\x04page\x18\x06 \x01(\x05R\x04page\x12\x1b\n" +
+	"\tpage_size\x18\a \x01(\x05R\bpageSize\x12\x18\n" +
+	"\areverse\x18\b \x01(\bR\areverse\x1a9\n" +
+	"\vFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc3\x02\n" +
+	"\vAppLogEntry\x12\x12\n" +
+	"\x04time\x18\x01 \x01(\tR\x04time\x12\x14\n" +
+	"\x05level\x18\x02 \x01(\tR\x05level\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x16\n" +
+	"\x06logger\x18\x04 \x01(\tR\x06logger\x12\x16\n" +
+	"\x06caller\x18\x05 \x01(\tR\x06caller\x12\x1e\n" +
+	"\n" +
+	"stacktrace\x18\x06 \x01(\tR\n" +
+	"stacktrace\x12=\n" +
+	"\x06fields\x18\a \x03(\v2%.controlplane.AppLogEntry.FieldsEntryR\x06fields\x12\x12\n" +
+	"\x04file\x18\b \x01(\tR\x04file\x12\x12\n" +
+	"\x04line\x18\t \x01(\x05R\x04line\x1a9\n" +
+	"\vFieldsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xb3\x01\n" +
+	"\x13QueryAppLogResponse\x123\n" +
+	"\aentries\x18\x01 \x03(\v2\x19.controlplane.AppLogEntryR\aentries\x12#\n" +
+	"\rtotal_entries\x18\x02 \x01(\x05R\ftotalEntries\x12!\n" +
+	"\fcurrent_page\x18\x03 \x01(\x05R\vcurrentPage\x12\x1f\n" +
+	"\vtotal_pages\x18\x04 \x01(\x05R\n" +
+	"totalPages\"\x86\x01\n" +
+	"\x0eTailLogRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12#\n" +
+	"\rdeployment_id\x18\x02 \x01(\tR\fdeploymentId\x12\x1a\n" +
+	"\bfilename\x18\x03 \x01(\tR\bfilename\x12\x16\n" +
+	"\x06offset\x18\x04 \x01(\x03R\x06offset\"\x8e\x01\n" +
+	"\fLogStepEvent\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04host\x18\x03 \x01(\tR\x04host\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\x12\x14\n" +
+	"\x05error\x18\x05 \x01(\tR\x05error\x12\x12\n" +
+	"\x04time\x18\x06 \x01(\tR\x04time\"\x8f\x01\n" +
+	"\fTailLogEvent\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x03R\x06offset\x12\x14\n" +
+	"\x04line\x18\x02 \x01(\tH\x00R\x04line\x120\n" +
+	"\x04step\x18\x03 \x01(\v2\x1a.controlplane.LogStepEventH\x00R\x04step\x12\x16\n" +
+	"\x05reset\x18\x04 \x01(\bH\x00R\x05resetB\a\n" +
+	"\x05event\"6\n" +
+	"\x17ListArchivedLogsRequest\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\"\xd6\x01\n" +
+	"\vArchivedLog\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x1d\n" +
+	"\n" +
+	"storage_id\x18\x02 \x01(\tR\tstorageId\x12\x16\n" +
+	"\x06bucket\x18\x03 \x01(\tR\x06bucket\x12\x10\n" +
+	"\x03key\x18\x04 \x01(\tR\x03key\x12\x12\n" +
+	"\x04size\x18\x05 \x01(\x03R\x04size\x12\x14\n" +
+	"\x05local\x18\x06 \x01(\bR\x05local\x12\x1f\n" +
+	"\vmodified_at\x18\a \x01(\tR\n" +
+	"modifiedAt\x12\x1f\n" +
+	"\varchived_at\x18\b \x01(\tR\n" +
+	"archivedAt\"I\n" +
+	"\x18ListArchivedLogsResponse\x12-\n" +
+	"\x04logs\x18\x01 \x03(\v2\x19.controlplane.ArchivedLogR\x04logs2\xac\x05\n" +
+	"\vLogsService\x12C\n" +
+	"\rGetSystemLogs\x12\r.common.Empty\x1a#.controlplane.GetSystemLogsResponse\x12X\n" +
+	"\rReadSystemLog\x12\".controlplane.ReadSystemLogRequest\x1a#.controlplane.ReadSystemLogResponse\x12X\n" +
+	"\rGetServerLogs\x12\".controlplane.GetServerLogsRequest\x1a#.controlplane.GetServerLogsResponse\x12X\n" +
+	"\rReadServerLog\x12\".controlplane.ReadServerLogRequest\x1a#.controlplane.ReadServerLogResponse\x12L\n" +
+	"\tSearchLog\x12\x1e.controlplane.SearchLogRequest\x1a\x1f.controlplane.SearchLogResponse\x12R\n" +
+	"\vQueryAppLog\x12 .controlplane.QueryAppLogRequest\x1a!.controlplane.QueryAppLogResponse\x12E\n" +
+	"\aTailLog\x12\x1c.controlplane.TailLogRequest\x1a\x1a.controlplane.TailLogEvent0\x01\x12a\n" +
+	"\x10ListArchivedLogs\x12%.controlplane.ListArchivedLogsRequest\x1a&.controlplane.ListArchivedLogsResponseB;Z9github.com/zhinea/sylix/internal/infra/proto/controlplaneb\x06proto3"
+
+var (
+	file_controlplane_logs_proto_rawDescOnce sync.Once
+	file_controlplane_logs_proto_rawDescData []byte
+)
+
+func file_controlplane_logs_proto_rawDescGZIP() []byte {
+	file_controlplane_logs_proto_rawDescOnce.Do(func() {
+		file_controlplane_logs_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_controlplane_logs_proto_rawDesc), len(file_controlplane_logs_proto_rawDesc)))
+	})
+	return file_controlplane_logs_proto_rawDescData
+}
+
+var file_controlplane_logs_proto_msgTypes = make([]protoimpl.MessageInfo, 22)
+var file_controlplane_logs_proto_goTypes = []any{
+	(*LogFile)(nil),                  // 0: controlplane.LogFile
+	(*GetSystemLogsResponse)(nil),    // 1: controlplane.GetSystemLogsResponse
+	(*ReadSystemLogRequest)(nil),     // 2: controlplane.ReadSystemLogRequest
+	(*ReadSystemLogResponse)(nil),    // 3: controlplane.ReadSystemLogResponse
+	(*GetServerLogsRequest)(nil),     // 4: controlplane.GetServerLogsRequest
+	(*GetServerLogsResponse)(nil),    // 5: controlplane.GetServerLogsResponse
+	(*ReadServerLogRequest)(nil),     // 6: controlplane.ReadServerLogRequest
+	(*ReadServerLogResponse)(nil),    // 7: controlplane.ReadServerLogResponse
+	(*SearchLogRequest)(nil),         // 8: controlplane.SearchLogRequest
+	(*LogMatch)(nil),                 // 9: controlplane.LogMatch
+	(*SearchLogResponse)(nil),        // 10: controlplane.SearchLogResponse
+	(*QueryAppLogRequest)(nil),       // 11: controlplane.QueryAppLogRequest
+	(*AppLogEntry)(nil),              // 12: controlplane.AppLogEntry
+	(*QueryAppLogResponse)(nil),      // 13: controlplane.QueryAppLogResponse
+	(*TailLogRequest)(nil),           // 14: controlplane.TailLogRequest
+	(*LogStepEvent)(nil),             // 15: controlplane.LogStepEvent
+	(*TailLogEvent)(nil),             // 16: controlplane.TailLogEvent
+	(*ListArchivedLogsRequest)(nil),  // 17: controlplane.ListArchivedLogsRequest
+	(*ArchivedLog)(nil),              // 18: controlplane.ArchivedLog
+	(*ListArchivedLogsResponse)(nil), // 19: controlplane.ListArchivedLogsResponse
+	nil,                              // 20: controlplane.QueryAppLogRequest.FieldsEntry
+	nil,                              // 21: controlplane.AppLogEntry.FieldsEntry
+	(*common.Empty)(nil),             // 22: common.Empty
+}
+var file_controlplane_logs_proto_depIdxs = []int32{
+	0,  // 0: controlplane.GetSystemLogsResponse.files:type_name -> controlplane.LogFile
+	0,  // 1: controlplane.GetServerLogsResponse.files:type_name -> controlplane.LogFile
+	9,  // 2: controlplane.SearchLogResponse.matches:type_name -> controlplane.LogMatch
+	20, // 3: controlplane.QueryAppLogRequest.fields:type_name -> controlplane.QueryAppLogRequest.FieldsEntry
+	21, // 4: controlplane.AppLogEntry.fields:type_name -> controlplane.AppLogEntry.FieldsEntry
+	12, // 5: controlplane.QueryAppLogResponse.entries:type_name -> controlplane.AppLogEntry
+	15, // 6: controlplane.TailLogEvent.step:type_name -> controlplane.LogStepEvent
+	18, // 7: controlplane.ListArchivedLogsResponse.logs:type_name -> controlplane.ArchivedLog
+	22, // 8: controlplane.LogsService.GetSystemLogs:input_type -> common.Empty
+	2,  // 9: controlplane.LogsService.ReadSystemLog:input_type -> controlplane.ReadSystemLogRequest
+	4,  // 10: controlplane.LogsService.GetServerLogs:input_type -> controlplane.GetServerLogsRequest
+	6,  // 11: controlplane.LogsService.ReadServerLog:input_type -> controlplane.ReadServerLogRequest
+	8,  // 12: controlplane.LogsService.SearchLog:input_type -> controlplane.SearchLogRequest
+	11, // 13: controlplane.LogsService.QueryAppLog:input_type -> controlplane.QueryAppLogRequest
+	14, // 14: controlplane.LogsService.TailLog:input_type -> controlplane.TailLogRequest
+	17, // 15: controlplane.LogsService.ListArchivedLogs:input_type -> controlplane.ListArchivedLogsRequest
+	1,  // 16: controlplane.LogsService.GetSystemLogs:output_type -> controlplane.GetSystemLogsResponse
+	3,  // 17: controlplane.LogsService.ReadSystemLog:output_type -> controlplane.ReadSystemLogResponse
+	5,  // 18: controlplane.LogsService.GetServerLogs:output_type -> controlplane.GetServerLogsResponse
+	7,  // 19: controlplane.LogsService.ReadServerLog:output_type -> controlplane.ReadServerLogResponse
+	10, // 20: controlplane.LogsService.SearchLog:output_type -> controlplane.SearchLogResponse
+	13, // 21: controlplane.LogsService.QueryAppLog:output_type -> controlplane.QueryAppLogResponse
+	16, // 22: controlplane.LogsService.TailLog:output_type -> controlplane.TailLogEvent
+	19, // 23: controlplane.LogsService.ListArchivedLogs:output_type -> controlplane.ListArchivedLogsResponse
+	16, // [16:24] is the sub-list for method output_type
+	8,  // [8:16] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_controlplane_logs_proto_init() }
+func file_controlplane_logs_proto_init() {
+	if File_controlplane_logs_proto != nil {
+		return
+	}
+	file_controlplane_logs_proto_msgTypes[16].OneofWrappers = []any{
+		(*TailLogEvent_Line)(nil),
+		(*TailLogEvent_Step)(nil),
+		(*TailLogEvent_Reset_)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -575,7 +1686,7 @@ func file_controlplane_logs_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_controlplane_logs_proto_rawDesc), len(file_controlplane_logs_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   22,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,10 +20,14 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	LogsService_GetSystemLogs_FullMethodName = "/controlplane.LogsService/GetSystemLogs"
-	LogsService_ReadSystemLog_FullMethodName = "/controlplane.LogsService/ReadSystemLog"
-	LogsService_GetServerLogs_FullMethodName = "/controlplane.LogsService/GetServerLogs"
-	LogsService_ReadServerLog_FullMethodName = "/controlplane.LogsService/ReadServerLog"
+	LogsService_GetSystemLogs_FullMethodName    = "/controlplane.LogsService/GetSystemLogs"
+	LogsService_ReadSystemLog_FullMethodName    = "/controlplane.LogsService/ReadSystemLog"
+	LogsService_GetServerLogs_FullMethodName    = "/controlplane.LogsService/GetServerLogs"
+	LogsService_ReadServerLog_FullMethodName    = "/controlplane.LogsService/ReadServerLog"
+	LogsService_SearchLog_FullMethodName        = "/controlplane.LogsService/SearchLog"
+	LogsService_QueryAppLog_FullMethodName      = "/controlplane.LogsService/QueryAppLog"
+	LogsService_TailLog_FullMethodName          = "/controlplane.LogsService/TailLog"
+	LogsService_ListArchivedLogs_FullMethodName = "/controlplane.LogsService/ListArchivedLogs"
 )
 
 // LogsServiceClient is the client API for LogsService service.
@@ -34,6 +38,14 @@ type LogsServiceClient interface {
 	ReadSystemLog(ctx context.Context, in *ReadSystemLogRequest, opts ...grpc.CallOption) (*ReadSystemLogResponse, error)
 	GetServerLogs(ctx context.Context, in *GetServerLogsRequest, opts ...grpc.CallOption) (*GetServerLogsResponse, error)
 	ReadServerLog(ctx context.Context, in *ReadServerLogRequest, opts ...grpc.CallOption) (*ReadServerLogResponse, error)
+	// Finds the lines of a log file matching a text or regular expression, and a level for JSON logs
+	SearchLog(ctx context.Context, in *SearchLogRequest, opts ...grpc.CallOption) (*SearchLogResponse, error)
+	// Queries the records of the controlplane's own JSON log and its rotated backups
+	QueryAppLog(ctx context.Context, in *QueryAppLogRequest, opts ...grpc.CallOption) (*QueryAppLogResponse, error)
+	// Streams a server or deployment log as it is written, from the given offset
+	TailLog(ctx context.Context, in *TailLogRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TailLogEvent], error)
+	// Lists the log files uploaded to backup storage by the retention policy
+	ListArchivedLogs(ctx context.Context, in *ListArchivedLogsRequest, opts ...grpc.CallOption) (*ListArchivedLogsResponse, error)
 }
 
 type logsServiceClient struct {
@@ -84,6 +96,55 @@ func (c *logsServiceClient) ReadServerLog(ctx context.Context, in *ReadServerLog
 	return out, nil
 }
 
+func (c *logsServiceClient) SearchLog(ctx context.Context, in *SearchLogRequest, opts ...grpc.CallOption) (*SearchLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchLogResponse)
+	err := c.cc.Invoke(ctx, LogsService_SearchLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logsServiceClient) QueryAppLog(ctx context.Context, in *QueryAppLogRequest, opts ...grpc.CallOption) (*QueryAppLogResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryAppLogResponse)
+	err := c.cc.Invoke(ctx, LogsService_QueryAppLog_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logsServiceClient) TailLog(ctx context.Context, in *TailLogRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[TailLogEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &LogsService_ServiceDesc.Streams[0], LogsService_TailLog_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[TailLogRequest, TailLogEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LogsService_TailLogClient = grpc.ServerStreamingClient[TailLogEvent]
+
+func (c *logsServiceClient) ListArchivedLogs(ctx context.Context, in *ListArchivedLogsRequest, opts ...grpc.CallOption) (*ListArchivedLogsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListArchivedLogsResponse)
+	err := c.cc.Invoke(ctx, LogsService_ListArchivedLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LogsServiceServer is the server API for LogsService service.
 // All implementations must embed UnimplementedLogsServiceServer
 // for forward compatibility.
@@ -92,6 +153,14 @@ type LogsServiceServer interface {
 	ReadSystemLog(context.Context, *ReadSystemLogRequest) (*ReadSystemLogResponse, error)
 	GetServerLogs(context.Context, *GetServerLogsRequest) (*GetServerLogsResponse, error)
 	ReadServerLog(context.Context, *ReadServerLogRequest) (*ReadServerLogResponse, error)
+	// Finds the lines of a log file matching a text or regular expression, and a level for JSON logs
+	SearchLog(context.Context, *SearchLogRequest) (*SearchLogResponse, error)
+	// Queries the records of the controlplane's own JSON log and its rotated backups
+	QueryAppLog(context.Context, *QueryAppLogRequest) (*QueryAppLogResponse, error)
+	// Streams a server or deployment log as it is written, from the given offset
+	TailLog(*TailLogRequest, grpc.ServerStreamingServer[TailLogEvent]) error
+	// Lists the log files uploaded to backup storage by the retention policy
+	ListArchivedLogs(context.Context, *ListArchivedLogsRequest) (*ListArchivedLogsResponse, error)
 	mustEmbedUnimplementedLogsServiceServer()
 }
 
@@ -114,6 +183,18 @@ func (UnimplementedLogsServiceServer) GetServerLogs(context.Context, *GetServerL
 func (UnimplementedLogsServiceServer) ReadServerLog(context.Context, *ReadServerLogRequest) (*ReadServerLogResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReadServerLog not implemented")
 }
+func (UnimplementedLogsServiceServer) SearchLog(context.Context, *SearchLogRequest) (*SearchLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchLog not implemented")
+}
+func (UnimplementedLogsServiceServer) QueryAppLog(context.Context, *QueryAppLogRequest) (*QueryAppLogResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method QueryAppLog not implemented")
+}
+func (UnimplementedLogsServiceServer) TailLog(*TailLogRequest, grpc.ServerStreamingServer[TailLogEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method TailLog not implemented")
+}
+func (UnimplementedLogsServiceServer) ListArchivedLogs(context.Context, *ListArchivedLogsRequest) (*ListArchivedLogsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListArchivedLogs not implemented")
+}
 func (UnimplementedLogsServiceServer) mustEmbedUnimplementedLogsServiceServer() {}
 func (UnimplementedLogsServiceServer) testEmbeddedByValue()                     {}
 
@@ -207,6 +288,71 @@ func _LogsService_ReadServerLog_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LogsService_SearchLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogsServiceServer).SearchLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogsService_SearchLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogsServiceServer).SearchLog(ctx, req.(*SearchLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogsService_QueryAppLog_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryAppLogRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogsServiceServer).QueryAppLog(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogsService_QueryAppLog_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogsServiceServer).QueryAppLog(ctx, req.(*QueryAppLogRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogsService_TailLog_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TailLogRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(LogsServiceServer).TailLog(m, &grpc.GenericServerStream[TailLogRequest, TailLogEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type LogsService_TailLogServer = grpc.ServerStreamingServer[TailLogEvent]
+
+func _LogsService_ListArchivedLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListArchivedLogsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogsServiceServer).ListArchivedLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogsService_ListArchivedLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogsServiceServer).ListArchivedLogs(ctx, req.(*ListArchivedLogsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // LogsService_ServiceDesc is the grpc.ServiceDesc for LogsService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -230,7 +376,25 @@ var LogsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ReadServerLog",
 			Handler:    _LogsService_ReadServerLog_Handler,
 		},
+		{
+			MethodName: "SearchLog",
+			Handler:    _LogsService_SearchLog_Handler,
+		},
+		{
+			MethodName: "QueryAppLog",
+			Handler:    _LogsService_QueryAppLog_Handler,
+		},
+		{
+			MethodName: "ListArchivedLogs",
+			Handler:    _LogsService_ListArchivedLogs_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "TailLog",
+			Handler:       _LogsService_TailLog_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "controlplane/logs.proto",
 }
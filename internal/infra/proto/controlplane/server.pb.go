@@ -191,6 +191,55 @@ func (AgentStatusServer) EnumDescriptor() ([]byte, []int) {
 	return file_controlplane_server_proto_rawDescGZIP(), []int{2}
 }
 
+type MeshSyncStatus int32
+
+const (
+	MeshSyncStatus_MESH_SYNC_SUCCEEDED MeshSyncStatus = 0
+	MeshSyncStatus_MESH_SYNC_PARTIAL   MeshSyncStatus = 1
+	MeshSyncStatus_MESH_SYNC_FAILED    MeshSyncStatus = 2
+)
+
+// Enum value maps for MeshSyncStatus.
+var (
+	MeshSyncStatus_name = map[int32]string{
+		0: "MESH_SYNC_SUCCEEDED",
+		1: "MESH_SYNC_PARTIAL",
+		2: "MESH_SYNC_FAILED",
+	}
+	MeshSyncStatus_value = map[string]int32{
+		"MESH_SYNC_SUCCEEDED": 0,
+		"MESH_SYNC_PARTIAL":   1,
+		"MESH_SYNC_FAILED":    2,
+	}
+)
+
+func (x MeshSyncStatus) Enum() *MeshSyncStatus {
+	p := new(MeshSyncStatus)
+	*p = x
+	return p
+}
+
+func (x MeshSyncStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MeshSyncStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_controlplane_server_proto_enumTypes[3].Descriptor()
+}
+
+func (MeshSyncStatus) Type() protoreflect.EnumType {
+	return &file_controlplane_server_proto_enumTypes[3]
+}
+
+func (x MeshSyncStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MeshSyncStatus.Descriptor instead.
+func (MeshSyncStatus) EnumDescriptor() ([]byte, []int) {
+	return file_controlplane_server_proto_rawDescGZIP(), []int{3}
+}
+
 type GetRealtimeStatsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
@@ -371,6 +420,59 @@ func (x *GetRealtimeStatsResponse) GetPings() []*ServerPing {
 	return nil
 }
 
+type TrustHostKeyRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// SHA256 fingerprint of the new key, as reported by the host key mismatch
+	Fingerprint   string `protobuf:"bytes,2,opt,name=fingerprint,proto3" json:"fingerprint,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrustHostKeyRequest) Reset() {
+	*x = TrustHostKeyRequest{}
+	mi := &file_controlplane_server_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrustHostKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrustHostKeyRequest) ProtoMessage() {}
+
+func (x *TrustHostKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_server_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrustHostKeyRequest.ProtoReflect.Descriptor instead.
+func (*TrustHostKeyRequest) Descriptor() ([]byte, []int) {
+	return file_controlplane_server_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TrustHostKeyRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TrustHostKeyRequest) GetFingerprint() string {
+	if x != nil {
+		return x.Fingerprint
+	}
+	return ""
+}
+
 type GetStatsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ServerId      string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
@@ -380,7 +482,7 @@ type GetStatsRequest struct {
 
 func (x *GetStatsRequest) Reset() {
 	*x = GetStatsRequest{}
-	mi := &file_controlplane_server_proto_msgTypes[3]
+	mi := &file_controlplane_server_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -392,7 +494,7 @@ func (x *GetStatsRequest) String() string {
 func (*GetStatsRequest) ProtoMessage() {}
 
 func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_controlplane_server_proto_msgTypes[3]
+	mi := &file_controlplane_server_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -405,7 +507,7 @@ func (x *GetStatsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetStatsRequest.ProtoReflect.Descriptor instead.
 func (*GetStatsRequest) Descriptor() ([]byte, []int) {
-	return file_controlplane_server_proto_rawDescGZIP(), []int{3}
+	return file_controlplane_server_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetStatsRequest) GetServerId() string {
@@ -431,7 +533,7 @@ type ServerStat struct {
 
 func (x *ServerStat) Reset() {
 	*x = ServerStat{}
-	mi := &file_controlplane_server_proto_msgTypes[4]
+	mi := &file_controlplane_server_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -443,7 +545,7 @@ func (x *ServerStat) String() string {
 func (*ServerStat) ProtoMessage() {}
 
 func (x *ServerStat) ProtoReflect() protoreflect.Message {
-	mi := &file_controlplane_server_proto_msgTypes[4]
+	mi := &file_controlplane_server_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -456,7 +558,7 @@ func (x *ServerStat) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServerStat.ProtoReflect.Descriptor instead.
 func (*ServerStat) Descriptor() ([]byte, []int) {
-	return file_controlplane_server_proto_rawDescGZIP(), []int{4}
+	return file_controlplane_server_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ServerStat) GetId() string {
@@ -524,7 +626,7 @@ type GetStatsResponse struct {
 
 func (x *GetStatsResponse) Reset() {
 	*x = GetStatsResponse{}
-	mi := &file_controlplane_server_proto_msgTypes[5]
+	mi := &file_controlplane_server_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -536,7 +638,7 @@ func (x *GetStatsResponse) String() string {
 func (*GetStatsResponse) ProtoMessage() {}
 
 func (x *GetStatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_controlplane_server_proto_msgTypes[5]
+	mi := &file_controlplane_server_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -549,7 +651,7 @@ func (x *GetStatsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetStatsResponse.ProtoReflect.Descriptor instead.
 func (*GetStatsResponse) Descriptor() ([]byte, []int) {
-	return file_controlplane_server_proto_rawDescGZIP(), []int{5}
+	return file_controlplane_server_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetStatsResponse) GetStats() []*ServerStat {
@@ -568,7 +670,7 @@ type Id struct {
 
 func (x *Id) Reset() {
 	*x = Id{}
-	mi := &file_controlplane_server_proto_msgTypes[6]
+	mi := &file_controlplane_server_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -580,7 +682,7 @@ func (x *Id) String() string {
 func (*Id) ProtoMessage() {}
 
 func (x *Id) ProtoReflect() protoreflect.Message {
-	mi := &file_controlplane_server_proto_msgTypes[6]
+	mi := &file_controlplane_server_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -593,7 +695,7 @@ func (x *Id) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Id.ProtoReflect.Descriptor instead.
 func (*Id) Descriptor() ([]byte, []int) {
-	return file_controlplane_server_proto_rawDescGZIP(), []int{6}
+	return file_controlplane_server_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *Id) GetId() string {
@@ -615,7 +717,7 @@ type ServerResponse struct {
 
 func (x *ServerResponse) Reset() {
 	*x = ServerResponse{}
-	mi := &file_controlplane_server_proto_msgTypes[7]
+	mi := &file_controlplane_server_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -627,7 +729,7 @@ func (x *ServerResponse) String() string {
 func (*ServerResponse) ProtoMessage() {}
 
 func (x *ServerResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_controlplane_server_proto_msgTypes[7]
+	mi := &file_controlplane_server_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -640,7 +742,7 @@ func (x *ServerResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServerResponse.ProtoReflect.Descriptor instead.
 func (*ServerResponse) Descriptor() ([]byte, []int) {
-	return file_controlplane_server_proto_rawDescGZIP(), []int{7}
+	return file_controlplane_server_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ServerResponse) GetStatus() StatusCode {
@@ -683,7 +785,7 @@ type ServersResponse struct {
 
 func (x *ServersResponse) Reset() {
 	*x = ServersResponse{}
-	mi := &file_controlplane_server_proto_msgTypes[8]
+	mi := &file_controlplane_server_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -695,7 +797,7 @@ func (x *ServersResponse) String() string {
 func (*ServersResponse) ProtoMessage() {}
 
 func (x *ServersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_controlplane_server_proto_msgTypes[8]
+	mi := &file_controlplane_server_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -708,7 +810,7 @@ func (x *ServersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServersResponse.ProtoReflect.Descriptor instead.
 func (*ServersResponse) Descriptor() ([]byte, []int) {
-	return file_controlplane_server_proto_rawDescGZIP(), []int{8}
+	return file_controlplane_server_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ServersResponse) GetStatus() StatusCode {
@@ -740,23 +842,25 @@ func (x *ServersResponse) GetError() string {
 }
 
 type Server struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	IpAddress     string                 `protobuf:"bytes,3,opt,name=ipAddress,proto3" json:"ipAddress,omitempty"`
-	Port          int32                  `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
-	Protocol      string                 `protobuf:"bytes,5,opt,name=protocol,proto3" json:"protocol,omitempty"`
-	Credential    *ServerCredential      `protobuf:"bytes,6,opt,name=credential,proto3" json:"credential,omitempty"`
-	IsRoot        int32                  `protobuf:"varint,7,opt,name=isRoot,proto3" json:"isRoot,omitempty"`
-	Status        StatusServer           `protobuf:"varint,8,opt,name=status,proto3,enum=controlplane.StatusServer" json:"status,omitempty"`
-	Agent         *ServerAgent           `protobuf:"bytes,9,opt,name=agent,proto3" json:"agent,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	Id         string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name       string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	IpAddress  string                 `protobuf:"bytes,3,opt,name=ipAddress,proto3" json:"ipAddress,omitempty"`
+	Port       int32                  `protobuf:"varint,4,opt,name=port,proto3" json:"port,omitempty"`
+	Protocol   string                 `protobuf:"bytes,5,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	Credential *ServerCredential      `protobuf:"bytes,6,opt,name=credential,proto3" json:"credential,omitempty"`
+	IsRoot     int32                  `protobuf:"varint,7,opt,name=isRoot,proto3" json:"isRoot,omitempty"`
+	Status     StatusServer           `protobuf:"varint,8,opt,name=status,proto3,enum=controlplane.StatusServer" json:"status,omitempty"`
+	Agent      *ServerAgent           `protobuf:"bytes,9,opt,name=agent,proto3" json:"agent,omitempty"`
+	// SHA256 fingerprint of the pinned SSH host key, read only
+	HostKeyFingerprint string `protobuf:"bytes,10,opt,name=host_key_fingerprint,json=hostKeyFingerprint,proto3" json:"host_key_fingerprint,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *Server) Reset() {
 	*x = Server{}
-	mi := &file_controlplane_server_proto_msgTypes[9]
+	mi := &file_controlplane_server_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -768,7 +872,7 @@ func (x *Server) String() string {
 func (*Server) ProtoMessage() {}
 
 func (x *Server) ProtoReflect() protoreflect.Message {
-	mi := &file_controlplane_server_proto_msgTypes[9]
+	mi := &file_controlplane_server_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -781,7 +885,7 @@ func (x *Server) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Server.ProtoReflect.Descriptor instead.
 func (*Server) Descriptor() ([]byte, []int) {
-	return file_controlplane_server_proto_rawDescGZIP(), []int{9}
+	return file_controlplane_server_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *Server) GetId() string {
@@ -847,6 +951,13 @@ func (x *Server) GetAgent() *ServerAgent {
 	return nil
 }
 
+func (x *Server) GetHostKeyFingerprint() string {
+	if x != nil {
+		return x.HostKeyFingerprint
+	}
+	return ""
+}
+
 type ServerAgent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Port          int32                  `protobuf:"varint,1,opt,name=port,proto3" json:"port,omitempty"`
@@ -858,7 +969,7 @@ type ServerAgent struct {
 
 func (x *ServerAgent) Reset() {
 	*x = ServerAgent{}
-	mi := &file_controlplane_server_proto_msgTypes[10]
+	mi := &file_controlplane_server_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -870,7 +981,7 @@ func (x *ServerAgent) String() string {
 func (*ServerAgent) ProtoMessage() {}
 
 func (x *ServerAgent) ProtoReflect() protoreflect.Message {
-	mi := &file_controlplane_server_proto_msgTypes[10]
+	mi := &file_controlplane_server_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -883,7 +994,7 @@ func (x *ServerAgent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServerAgent.ProtoReflect.Descriptor instead.
 func (*ServerAgent) Descriptor() ([]byte, []int) {
-	return file_controlplane_server_proto_rawDescGZIP(), []int{10}
+	return file_controlplane_server_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ServerAgent) GetPort() int32 {
@@ -918,7 +1029,7 @@ type ServerCredential struct {
 
 func (x *ServerCredential) Reset() {
 	*x = ServerCredential{}
-	mi := &file_controlplane_server_proto_msgTypes[11]
+	mi := &file_controlplane_server_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -930,7 +1041,7 @@ func (x *ServerCredential) String() string {
 func (*ServerCredential) ProtoMessage() {}
 
 func (x *ServerCredential) ProtoReflect() protoreflect.Message {
-	mi := &file_controlplane_server_proto_msgTypes[11]
+	mi := &file_controlplane_server_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -943,7 +1054,7 @@ func (x *ServerCredential) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServerCredential.ProtoReflect.Descriptor instead.
 func (*ServerCredential) Descriptor() ([]byte, []int) {
-	return file_controlplane_server_proto_rawDescGZIP(), []int{11}
+	return file_controlplane_server_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ServerCredential) GetUsername() string {
@@ -977,7 +1088,7 @@ type MessageResponse struct {
 
 func (x *MessageResponse) Reset() {
 	*x = MessageResponse{}
-	mi := &file_controlplane_server_proto_msgTypes[12]
+	mi := &file_controlplane_server_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -989,7 +1100,7 @@ func (x *MessageResponse) String() string {
 func (*MessageResponse) ProtoMessage() {}
 
 func (x *MessageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_controlplane_server_proto_msgTypes[12]
+	mi := &file_controlplane_server_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1002,7 +1113,7 @@ func (x *MessageResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MessageResponse.ProtoReflect.Descriptor instead.
 func (*MessageResponse) Descriptor() ([]byte, []int) {
-	return file_controlplane_server_proto_rawDescGZIP(), []int{12}
+	return file_controlplane_server_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *MessageResponse) GetStatus() StatusCode {
@@ -1019,11 +1130,477 @@ func (x *MessageResponse) GetMessage() string {
 	return ""
 }
 
+type MeshSyncResult struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ServerId   string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	ServerName string                 `protobuf:"bytes,2,opt,name=server_name,json=serverName,proto3" json:"server_name,omitempty"`
+	// unchanged, synced, restarted or failed
+	Action        string `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	PeersAdded    int32  `protobuf:"varint,4,opt,name=peers_added,json=peersAdded,proto3" json:"peers_added,omitempty"`
+	PeersRemoved  int32  `protobuf:"varint,5,opt,name=peers_removed,json=peersRemoved,proto3" json:"peers_removed,omitempty"`
+	PeersUpdated  int32  `protobuf:"varint,6,opt,name=peers_updated,json=peersUpdated,proto3" json:"peers_updated,omitempty"`
+	Error         string `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	DurationMs    int64  `protobuf:"varint,8,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MeshSyncResult) Reset() {
+	*x = MeshSyncResult{}
+	mi := &file_controlplane_server_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MeshSyncResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MeshSyncResult) ProtoMessage() {}
+
+func (x *MeshSyncResult) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_server_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MeshSyncResult.ProtoReflect.Descriptor instead.
+func (*MeshSyncResult) Descriptor() ([]byte, []int) {
+	return file_controlplane_server_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *MeshSyncResult) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *MeshSyncResult) GetServerName() string {
+	if x != nil {
+		return x.ServerName
+	}
+	return ""
+}
+
+func (x *MeshSyncResult) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *MeshSyncResult) GetPeersAdded() int32 {
+	if x != nil {
+		return x.PeersAdded
+	}
+	return 0
+}
+
+func (x *MeshSyncResult) GetPeersRemoved() int32 {
+	if x != nil {
+		return x.PeersRemoved
+	}
+	return 0
+}
+
+func (x *MeshSyncResult) GetPeersUpdated() int32 {
+	if x != nil {
+		return x.PeersUpdated
+	}
+	return 0
+}
+
+func (x *MeshSyncResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *MeshSyncResult) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+type MeshSyncReport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status        MeshSyncStatus         `protobuf:"varint,2,opt,name=status,proto3,enum=controlplane.MeshSyncStatus" json:"status,omitempty"`
+	Results       []*MeshSyncResult      `protobuf:"bytes,3,rep,name=results,proto3" json:"results,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	FinishedAt    string                 `protobuf:"bytes,5,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MeshSyncReport) Reset() {
+	*x = MeshSyncReport{}
+	mi := &file_controlplane_server_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MeshSyncReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MeshSyncReport) ProtoMessage() {}
+
+func (x *MeshSyncReport) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_server_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MeshSyncReport.ProtoReflect.Descriptor instead.
+func (*MeshSyncReport) Descriptor() ([]byte, []int) {
+	return file_controlplane_server_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *MeshSyncReport) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MeshSyncReport) GetStatus() MeshSyncStatus {
+	if x != nil {
+		return x.Status
+	}
+	return MeshSyncStatus_MESH_SYNC_SUCCEEDED
+}
+
+func (x *MeshSyncReport) GetResults() []*MeshSyncResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *MeshSyncReport) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *MeshSyncReport) GetFinishedAt() string {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return ""
+}
+
+type MeshSyncResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        StatusCode             `protobuf:"varint,1,opt,name=status,proto3,enum=controlplane.StatusCode" json:"status,omitempty"`
+	Report        *MeshSyncReport        `protobuf:"bytes,2,opt,name=report,proto3" json:"report,omitempty"`
+	Error         *string                `protobuf:"bytes,3,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MeshSyncResponse) Reset() {
+	*x = MeshSyncResponse{}
+	mi := &file_controlplane_server_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MeshSyncResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MeshSyncResponse) ProtoMessage() {}
+
+func (x *MeshSyncResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_server_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MeshSyncResponse.ProtoReflect.Descriptor instead.
+func (*MeshSyncResponse) Descriptor() ([]byte, []int) {
+	return file_controlplane_server_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *MeshSyncResponse) GetStatus() StatusCode {
+	if x != nil {
+		return x.Status
+	}
+	return StatusCode_UNSPECIFIED
+}
+
+func (x *MeshSyncResponse) GetReport() *MeshSyncReport {
+	if x != nil {
+		return x.Report
+	}
+	return nil
+}
+
+func (x *MeshSyncResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+type ConnectionPoolHost struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ServerId string                 `protobuf:"bytes,1,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	Leases   int32                  `protobuf:"varint,2,opt,name=leases,proto3" json:"leases,omitempty"`
+	// sessions currently open on the connection
+	Sessions      int32  `protobuf:"varint,3,opt,name=sessions,proto3" json:"sessions,omitempty"`
+	LastUsed      string `protobuf:"bytes,4,opt,name=last_used,json=lastUsed,proto3" json:"last_used,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConnectionPoolHost) Reset() {
+	*x = ConnectionPoolHost{}
+	mi := &file_controlplane_server_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConnectionPoolHost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectionPoolHost) ProtoMessage() {}
+
+func (x *ConnectionPoolHost) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_server_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectionPoolHost.ProtoReflect.Descriptor instead.
+func (*ConnectionPoolHost) Descriptor() ([]byte, []int) {
+	return file_controlplane_server_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ConnectionPoolHost) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *ConnectionPoolHost) GetLeases() int32 {
+	if x != nil {
+		return x.Leases
+	}
+	return 0
+}
+
+func (x *ConnectionPoolHost) GetSessions() int32 {
+	if x != nil {
+		return x.Sessions
+	}
+	return 0
+}
+
+func (x *ConnectionPoolHost) GetLastUsed() string {
+	if x != nil {
+		return x.LastUsed
+	}
+	return ""
+}
+
+type ConnectionPoolStats struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Connections       int32                  `protobuf:"varint,1,opt,name=connections,proto3" json:"connections,omitempty"`
+	Leases            int32                  `protobuf:"varint,2,opt,name=leases,proto3" json:"leases,omitempty"`
+	Sessions          int32                  `protobuf:"varint,3,opt,name=sessions,proto3" json:"sessions,omitempty"`
+	Hits              int64                  `protobuf:"varint,4,opt,name=hits,proto3" json:"hits,omitempty"`
+	Dials             int64                  `protobuf:"varint,5,opt,name=dials,proto3" json:"dials,omitempty"`
+	DialFailures      int64                  `protobuf:"varint,6,opt,name=dial_failures,json=dialFailures,proto3" json:"dial_failures,omitempty"`
+	Evictions         int64                  `protobuf:"varint,7,opt,name=evictions,proto3" json:"evictions,omitempty"`
+	KeepaliveFailures int64                  `protobuf:"varint,8,opt,name=keepalive_failures,json=keepaliveFailures,proto3" json:"keepalive_failures,omitempty"`
+	Hosts             []*ConnectionPoolHost  `protobuf:"bytes,9,rep,name=hosts,proto3" json:"hosts,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *ConnectionPoolStats) Reset() {
+	*x = ConnectionPoolStats{}
+	mi := &file_controlplane_server_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConnectionPoolStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectionPoolStats) ProtoMessage() {}
+
+func (x *ConnectionPoolStats) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_server_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectionPoolStats.ProtoReflect.Descriptor instead.
+func (*ConnectionPoolStats) Descriptor() ([]byte, []int) {
+	return file_controlplane_server_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ConnectionPoolStats) GetConnections() int32 {
+	if x != nil {
+		return x.Connections
+	}
+	return 0
+}
+
+func (x *ConnectionPoolStats) GetLeases() int32 {
+	if x != nil {
+		return x.Leases
+	}
+	return 0
+}
+
+func (x *ConnectionPoolStats) GetSessions() int32 {
+	if x != nil {
+		return x.Sessions
+	}
+	return 0
+}
+
+func (x *ConnectionPoolStats) GetHits() int64 {
+	if x != nil {
+		return x.Hits
+	}
+	return 0
+}
+
+func (x *ConnectionPoolStats) GetDials() int64 {
+	if x != nil {
+		return x.Dials
+	}
+	return 0
+}
+
+func (x *ConnectionPoolStats) GetDialFailures() int64 {
+	if x != nil {
+		return x.DialFailures
+	}
+	return 0
+}
+
+func (x *ConnectionPoolStats) GetEvictions() int64 {
+	if x != nil {
+		return x.Evictions
+	}
+	return 0
+}
+
+func (x *ConnectionPoolStats) GetKeepaliveFailures() int64 {
+	if x != nil {
+		return x.KeepaliveFailures
+	}
+	return 0
+}
+
+func (x *ConnectionPoolStats) GetHosts() []*ConnectionPoolHost {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+type ConnectionPoolStatsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        StatusCode             `protobuf:"varint,1,opt,name=status,proto3,enum=controlplane.StatusCode" json:"status,omitempty"`
+	Stats         *ConnectionPoolStats   `protobuf:"bytes,2,opt,name=stats,proto3" json:"stats,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConnectionPoolStatsResponse) Reset() {
+	*x = ConnectionPoolStatsResponse{}
+	mi := &file_controlplane_server_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConnectionPoolStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectionPoolStatsResponse) ProtoMessage() {}
+
+func (x *ConnectionPoolStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_server_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectionPoolStatsResponse.ProtoReflect.Descriptor instead.
+func (*ConnectionPoolStatsResponse) Descriptor() ([]byte, []int) {
+	return file_controlplane_server_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ConnectionPoolStatsResponse) GetStatus() StatusCode {
+	if x != nil {
+		return x.Status
+	}
+	return StatusCode_UNSPECIFIED
+}
+
+func (x *ConnectionPoolStatsResponse) GetStats() *ConnectionPoolStats {
+	if x != nil {
+		return x.Stats
+	}
+	return nil
+}
+
 var File_controlplane_server_proto protoreflect.FileDescriptor
 
 const file_controlplane_server_proto_rawDesc = "" +
 	"\n" +
-	"\x19controlplane/server.proto\x12\fcontrolplane\x1a\x17common/validation.proto\x1a\x13common/common.proto\"L\n" +
+	"\x19controlplane/server.proto\x12\fcontrolplane\x1a\x17common/validation.proto\x1a\x13common/common.proto\x1a\x1bcontrolplane/workflow.proto\"L\n" +
 	"\x17GetRealtimeStatsRequest\x12\x1b\n" +
 	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x14\n" +
 	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\xab\x01\n" +
@@ -1037,7 +1614,10 @@ const file_controlplane_server_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\x06 \x01(\tR\tcreatedAt\"J\n" +
 	"\x18GetRealtimeStatsResponse\x12.\n" +
-	"\x05pings\x18\x01 \x03(\v2\x18.controlplane.ServerPingR\x05pings\".\n" +
+	"\x05pings\x18\x01 \x03(\v2\x18.controlplane.ServerPingR\x05pings\"G\n" +
+	"\x13TrustHostKeyRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12 \n" +
+	"\vfingerprint\x18\x02 \x01(\tR\vfingerprint\".\n" +
 	"\x0fGetStatsRequest\x12\x1b\n" +
 	"\tserver_id\x18\x01 \x01(\tR\bserverId\"\xa5\x02\n" +
 	"\n" +
@@ -1066,7 +1646,7 @@ const file_controlplane_server_proto_rawDesc = "" +
 	"\aservers\x18\x02 \x03(\v2\x14.controlplane.ServerR\aservers\x12/\n" +
 	"\x06errors\x18\x03 \x03(\v2\x17.common.ValidationErrorR\x06errors\x12\x19\n" +
 	"\x05error\x18\x04 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
-	"\x06_error\"\xb7\x02\n" +
+	"\x06_error\"\xe9\x02\n" +
 	"\x06Server\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1c\n" +
@@ -1078,7 +1658,9 @@ const file_controlplane_server_proto_rawDesc = "" +
 	"credential\x12\x16\n" +
 	"\x06isRoot\x18\a \x01(\x05R\x06isRoot\x122\n" +
 	"\x06status\x18\b \x01(\x0e2\x1a.controlplane.StatusServerR\x06status\x12/\n" +
-	"\x05agent\x18\t \x01(\v2\x19.controlplane.ServerAgentR\x05agent\"n\n" +
+	"\x05agent\x18\t \x01(\v2\x19.controlplane.ServerAgentR\x05agent\x120\n" +
+	"\x14host_key_fingerprint\x18\n" +
+	" \x01(\tR\x12hostKeyFingerprint\"n\n" +
 	"\vServerAgent\x12\x12\n" +
 	"\x04port\x18\x01 \x01(\x05R\x04port\x127\n" +
 	"\x06status\x18\x02 \x01(\x0e2\x1f.controlplane.AgentStatusServerR\x06status\x12\x12\n" +
@@ -1091,7 +1673,50 @@ const file_controlplane_server_proto_rawDesc = "" +
 	"\a_sshKey\"]\n" +
 	"\x0fMessageResponse\x120\n" +
 	"\x06status\x18\x01 \x01(\x0e2\x18.controlplane.StatusCodeR\x06status\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage*\x83\x01\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x88\x02\n" +
+	"\x0eMeshSyncResult\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x1f\n" +
+	"\vserver_name\x18\x02 \x01(\tR\n" +
+	"serverName\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x1f\n" +
+	"\vpeers_added\x18\x04 \x01(\x05R\n" +
+	"peersAdded\x12#\n" +
+	"\rpeers_removed\x18\x05 \x01(\x05R\fpeersRemoved\x12#\n" +
+	"\rpeers_updated\x18\x06 \x01(\x05R\fpeersUpdated\x12\x14\n" +
+	"\x05error\x18\a \x01(\tR\x05error\x12\x1f\n" +
+	"\vduration_ms\x18\b \x01(\x03R\n" +
+	"durationMs\"\xce\x01\n" +
+	"\x0eMeshSyncReport\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x124\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1c.controlplane.MeshSyncStatusR\x06status\x126\n" +
+	"\aresults\x18\x03 \x03(\v2\x1c.controlplane.MeshSyncResultR\aresults\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\tR\tcreatedAt\x12\x1f\n" +
+	"\vfinished_at\x18\x05 \x01(\tR\n" +
+	"finishedAt\"\x9f\x01\n" +
+	"\x10MeshSyncResponse\x120\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x18.controlplane.StatusCodeR\x06status\x124\n" +
+	"\x06report\x18\x02 \x01(\v2\x1c.controlplane.MeshSyncReportR\x06report\x12\x19\n" +
+	"\x05error\x18\x03 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
+	"\x06_error\"\x82\x01\n" +
+	"\x12ConnectionPoolHost\x12\x1b\n" +
+	"\tserver_id\x18\x01 \x01(\tR\bserverId\x12\x16\n" +
+	"\x06leases\x18\x02 \x01(\x05R\x06leases\x12\x1a\n" +
+	"\bsessions\x18\x03 \x01(\x05R\bsessions\x12\x1b\n" +
+	"\tlast_used\x18\x04 \x01(\tR\blastUsed\"\xbf\x02\n" +
+	"\x13ConnectionPoolStats\x12 \n" +
+	"\vconnections\x18\x01 \x01(\x05R\vconnections\x12\x16\n" +
+	"\x06leases\x18\x02 \x01(\x05R\x06leases\x12\x1a\n" +
+	"\bsessions\x18\x03 \x01(\x05R\bsessions\x12\x12\n" +
+	"\x04hits\x18\x04 \x01(\x03R\x04hits\x12\x14\n" +
+	"\x05dials\x18\x05 \x01(\x03R\x05dials\x12#\n" +
+	"\rdial_failures\x18\x06 \x01(\x03R\fdialFailures\x12\x1c\n" +
+	"\tevictions\x18\a \x01(\x03R\tevictions\x12-\n" +
+	"\x12keepalive_failures\x18\b \x01(\x03R\x11keepaliveFailures\x126\n" +
+	"\x05hosts\x18\t \x03(\v2 .controlplane.ConnectionPoolHostR\x05hosts\"\x88\x01\n" +
+	"\x1bConnectionPoolStatsResponse\x120\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x18.controlplane.StatusCodeR\x06status\x127\n" +
+	"\x05stats\x18\x02 \x01(\v2!.controlplane.ConnectionPoolStatsR\x05stats*\x83\x01\n" +
 	"\n" +
 	"StatusCode\x12\x0f\n" +
 	"\vUNSPECIFIED\x10\x00\x12\a\n" +
@@ -1113,17 +1738,26 @@ const file_controlplane_server_proto_rawDesc = "" +
 	"\x10FINALIZING_SETUP\x10\x03\x12\v\n" +
 	"\aSUCCESS\x10\x04\x12\n" +
 	"\n" +
-	"\x06FAILED\x10\x052\xe4\x04\n" +
+	"\x06FAILED\x10\x05*V\n" +
+	"\x0eMeshSyncStatus\x12\x17\n" +
+	"\x13MESH_SYNC_SUCCEEDED\x10\x00\x12\x15\n" +
+	"\x11MESH_SYNC_PARTIAL\x10\x01\x12\x14\n" +
+	"\x10MESH_SYNC_FAILED\x10\x022\xd2\a\n" +
 	"\rServerService\x12<\n" +
 	"\x06Create\x12\x14.controlplane.Server\x1a\x1c.controlplane.ServerResponse\x125\n" +
 	"\x03Get\x12\x10.controlplane.Id\x1a\x1c.controlplane.ServerResponse\x123\n" +
 	"\x03All\x12\r.common.Empty\x1a\x1d.controlplane.ServersResponse\x12<\n" +
 	"\x06Update\x12\x14.controlplane.Server\x1a\x1c.controlplane.ServerResponse\x129\n" +
 	"\x06Delete\x12\x10.controlplane.Id\x1a\x1d.controlplane.MessageResponse\x12A\n" +
-	"\x0fRetryConnection\x12\x10.controlplane.Id\x1a\x1c.controlplane.ServerResponse\x12?\n" +
-	"\fInstallAgent\x12\x10.controlplane.Id\x1a\x1d.controlplane.MessageResponse\x12I\n" +
+	"\x0fRetryConnection\x12\x10.controlplane.Id\x1a\x1c.controlplane.ServerResponse\x12O\n" +
+	"\fTrustHostKey\x12!.controlplane.TrustHostKeyRequest\x1a\x1c.controlplane.ServerResponse\x12?\n" +
+	"\fInstallAgent\x12\x10.controlplane.Id\x1a\x1d.controlplane.MessageResponse\x12H\n" +
+	"\x10PlanInstallAgent\x12\x10.controlplane.Id\x1a\".controlplane.WorkflowPlanResponse\x12I\n" +
 	"\bGetStats\x12\x1d.controlplane.GetStatsRequest\x1a\x1e.controlplane.GetStatsResponse\x12a\n" +
-	"\x10GetRealtimeStats\x12%.controlplane.GetRealtimeStatsRequest\x1a&.controlplane.GetRealtimeStatsResponseB;Z9github.com/zhinea/sylix/internal/infra/proto/controlplaneb\x06proto3"
+	"\x10GetRealtimeStats\x12%.controlplane.GetRealtimeStatsRequest\x1a&.controlplane.GetRealtimeStatsResponse\x129\n" +
+	"\bSyncMesh\x12\r.common.Empty\x1a\x1e.controlplane.MeshSyncResponse\x12B\n" +
+	"\x11GetMeshSyncReport\x12\r.common.Empty\x1a\x1e.controlplane.MeshSyncResponse\x12R\n" +
+	"\x16GetConnectionPoolStats\x12\r.common.Empty\x1a).controlplane.ConnectionPoolStatsResponseB;Z9github.com/zhinea/sylix/internal/infra/proto/controlplaneb\x06proto3"
 
 var (
 	file_controlplane_server_proto_rawDescOnce sync.Once
@@ -1137,65 +1771,91 @@ func file_controlplane_server_proto_rawDescGZIP() []byte {
 	return file_controlplane_server_proto_rawDescData
 }
 
-var file_controlplane_server_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_controlplane_server_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_controlplane_server_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_controlplane_server_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_controlplane_server_proto_goTypes = []any{
-	(StatusCode)(0),                  // 0: controlplane.StatusCode
-	(StatusServer)(0),                // 1: controlplane.StatusServer
-	(AgentStatusServer)(0),           // 2: controlplane.AgentStatusServer
-	(*GetRealtimeStatsRequest)(nil),  // 3: controlplane.GetRealtimeStatsRequest
-	(*ServerPing)(nil),               // 4: controlplane.ServerPing
-	(*GetRealtimeStatsResponse)(nil), // 5: controlplane.GetRealtimeStatsResponse
-	(*GetStatsRequest)(nil),          // 6: controlplane.GetStatsRequest
-	(*ServerStat)(nil),               // 7: controlplane.ServerStat
-	(*GetStatsResponse)(nil),         // 8: controlplane.GetStatsResponse
-	(*Id)(nil),                       // 9: controlplane.Id
-	(*ServerResponse)(nil),           // 10: controlplane.ServerResponse
-	(*ServersResponse)(nil),          // 11: controlplane.ServersResponse
-	(*Server)(nil),                   // 12: controlplane.Server
-	(*ServerAgent)(nil),              // 13: controlplane.ServerAgent
-	(*ServerCredential)(nil),         // 14: controlplane.ServerCredential
-	(*MessageResponse)(nil),          // 15: controlplane.MessageResponse
-	(*common.ValidationError)(nil),   // 16: common.ValidationError
-	(*common.Empty)(nil),             // 17: common.Empty
+	(StatusCode)(0),                     // 0: controlplane.StatusCode
+	(StatusServer)(0),                   // 1: controlplane.StatusServer
+	(AgentStatusServer)(0),              // 2: controlplane.AgentStatusServer
+	(MeshSyncStatus)(0),                 // 3: controlplane.MeshSyncStatus
+	(*GetRealtimeStatsRequest)(nil),     // 4: controlplane.GetRealtimeStatsRequest
+	(*ServerPing)(nil),                  // 5: controlplane.ServerPing
+	(*GetRealtimeStatsResponse)(nil),    // 6: controlplane.GetRealtimeStatsResponse
+	(*TrustHostKeyRequest)(nil),         // 7: controlplane.TrustHostKeyRequest
+	(*GetStatsRequest)(nil),             // 8: controlplane.GetStatsRequest
+	(*ServerStat)(nil),                  // 9: controlplane.ServerStat
+	(*GetStatsResponse)(nil),            // 10: controlplane.GetStatsResponse
+	(*Id)(nil),                          // 11: controlplane.Id
+	(*ServerResponse)(nil),              // 12: controlplane.ServerResponse
+	(*ServersResponse)(nil),             // 13: controlplane.ServersResponse
+	(*Server)(nil),                      // 14: controlplane.Server
+	(*ServerAgent)(nil),                 // 15: controlplane.ServerAgent
+	(*ServerCredential)(nil),            // 16: controlplane.ServerCredential
+	(*MessageResponse)(nil),             // 17: controlplane.MessageResponse
+	(*MeshSyncResult)(nil),              // 18: controlplane.MeshSyncResult
+	(*MeshSyncReport)(nil),              // 19: controlplane.MeshSyncReport
+	(*MeshSyncResponse)(nil),            // 20: controlplane.MeshSyncResponse
+	(*ConnectionPoolHost)(nil),          // 21: controlplane.ConnectionPoolHost
+	(*ConnectionPoolStats)(nil),         // 22: controlplane.ConnectionPoolStats
+	(*ConnectionPoolStatsResponse)(nil), // 23: controlplane.ConnectionPoolStatsResponse
+	(*common.ValidationError)(nil),      // 24: common.ValidationError
+	(*common.Empty)(nil),                // 25: common.Empty
+	(*WorkflowPlanResponse)(nil),        // 26: controlplane.WorkflowPlanResponse
 }
 var file_controlplane_server_proto_depIdxs = []int32{
-	4,  // 0: controlplane.GetRealtimeStatsResponse.pings:type_name -> controlplane.ServerPing
-	7,  // 1: controlplane.GetStatsResponse.stats:type_name -> controlplane.ServerStat
+	5,  // 0: controlplane.GetRealtimeStatsResponse.pings:type_name -> controlplane.ServerPing
+	9,  // 1: controlplane.GetStatsResponse.stats:type_name -> controlplane.ServerStat
 	0,  // 2: controlplane.ServerResponse.status:type_name -> controlplane.StatusCode
-	12, // 3: controlplane.ServerResponse.server:type_name -> controlplane.Server
-	16, // 4: controlplane.ServerResponse.errors:type_name -> common.ValidationError
+	14, // 3: controlplane.ServerResponse.server:type_name -> controlplane.Server
+	24, // 4: controlplane.ServerResponse.errors:type_name -> common.ValidationError
 	0,  // 5: controlplane.ServersResponse.status:type_name -> controlplane.StatusCode
-	12, // 6: controlplane.ServersResponse.servers:type_name -> controlplane.Server
-	16, // 7: controlplane.ServersResponse.errors:type_name -> common.ValidationError
-	14, // 8: controlplane.Server.credential:type_name -> controlplane.ServerCredential
+	14, // 6: controlplane.ServersResponse.servers:type_name -> controlplane.Server
+	24, // 7: controlplane.ServersResponse.errors:type_name -> common.ValidationError
+	16, // 8: controlplane.Server.credential:type_name -> controlplane.ServerCredential
 	1,  // 9: controlplane.Server.status:type_name -> controlplane.StatusServer
-	13, // 10: controlplane.Server.agent:type_name -> controlplane.ServerAgent
+	15, // 10: controlplane.Server.agent:type_name -> controlplane.ServerAgent
 	2,  // 11: controlplane.ServerAgent.status:type_name -> controlplane.AgentStatusServer
 	0,  // 12: controlplane.MessageResponse.status:type_name -> controlplane.StatusCode
-	12, // 13: controlplane.ServerService.Create:input_type -> controlplane.Server
-	9,  // 14: controlplane.ServerService.Get:input_type -> controlplane.Id
-	17, // 15: controlplane.ServerService.All:input_type -> common.Empty
-	12, // 16: controlplane.ServerService.Update:input_type -> controlplane.Server
-	9,  // 17: controlplane.ServerService.Delete:input_type -> controlplane.Id
-	9,  // 18: controlplane.ServerService.RetryConnection:input_type -> controlplane.Id
-	9,  // 19: controlplane.ServerService.InstallAgent:input_type -> controlplane.Id
-	6,  // 20: controlplane.ServerService.GetStats:input_type -> controlplane.GetStatsRequest
-	3,  // 21: controlplane.ServerService.GetRealtimeStats:input_type -> controlplane.GetRealtimeStatsRequest
-	10, // 22: controlplane.ServerService.Create:output_type -> controlplane.ServerResponse
-	10, // 23: controlplane.ServerService.Get:output_type -> controlplane.ServerResponse
-	11, // 24: controlplane.ServerService.All:output_type -> controlplane.ServersResponse
-	10, // 25: controlplane.ServerService.Update:output_type -> controlplane.ServerResponse
-	15, // 26: controlplane.ServerService.Delete:output_type -> controlplane.MessageResponse
-	10, // 27: controlplane.ServerService.RetryConnection:output_type -> controlplane.ServerResponse
-	15, // 28: controlplane.ServerService.InstallAgent:output_type -> controlplane.MessageResponse
-	8,  // 29: controlplane.ServerService.GetStats:output_type -> controlplane.GetStatsResponse
-	5,  // 30: controlplane.ServerService.GetRealtimeStats:output_type -> controlplane.GetRealtimeStatsResponse
-	22, // [22:31] is the sub-list for method output_type
-	13, // [13:22] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	3,  // 13: controlplane.MeshSyncReport.status:type_name -> controlplane.MeshSyncStatus
+	18, // 14: controlplane.MeshSyncReport.results:type_name -> controlplane.MeshSyncResult
+	0,  // 15: controlplane.MeshSyncResponse.status:type_name -> controlplane.StatusCode
+	19, // 16: controlplane.MeshSyncResponse.report:type_name -> controlplane.MeshSyncReport
+	21, // 17: controlplane.ConnectionPoolStats.hosts:type_name -> controlplane.ConnectionPoolHost
+	0,  // 18: controlplane.ConnectionPoolStatsResponse.status:type_name -> controlplane.StatusCode
+	22, // 19: controlplane.ConnectionPoolStatsResponse.stats:type_name -> controlplane.ConnectionPoolStats
+	14, // 20: controlplane.ServerService.Create:input_type -> controlplane.Server
+	11, // 21: controlplane.ServerService.Get:input_type -> controlplane.Id
+	25, // 22: controlplane.ServerService.All:input_type -> common.Empty
+	14, // 23: controlplane.ServerService.Update:input_type -> controlplane.Server
+	11, // 24: controlplane.ServerService.Delete:input_type -> controlplane.Id
+	11, // 25: controlplane.ServerService.RetryConnection:input_type -> controlplane.Id
+	7,  // 26: controlplane.ServerService.TrustHostKey:input_type -> controlplane.TrustHostKeyRequest
+	11, // 27: controlplane.ServerService.InstallAgent:input_type -> controlplane.Id
+	11, // 28: controlplane.ServerService.PlanInstallAgent:input_type -> controlplane.Id
+	8,  // 29: controlplane.ServerService.GetStats:input_type -> controlplane.GetStatsRequest
+	4,  // 30: controlplane.ServerService.GetRealtimeStats:input_type -> controlplane.GetRealtimeStatsRequest
+	25, // 31: controlplane.ServerService.SyncMesh:input_type -> common.Empty
+	25, // 32: controlplane.ServerService.GetMeshSyncReport:input_type -> common.Empty
+	25, // 33: controlplane.ServerService.GetConnectionPoolStats:input_type -> common.Empty
+	12, // 34: controlplane.ServerService.Create:output_type -> controlplane.ServerResponse
+	12, // 35: controlplane.ServerService.Get:output_type -> controlplane.ServerResponse
+	13, // 36: controlplane.ServerService.All:output_type -> controlplane.ServersResponse
+	12, // 37: controlplane.ServerService.Update:output_type -> controlplane.ServerResponse
+	17, // 38: controlplane.ServerService.Delete:output_type -> controlplane.MessageResponse
+	12, // 39: controlplane.ServerService.RetryConnection:output_type -> controlplane.ServerResponse
+	12, // 40: controlplane.ServerService.TrustHostKey:output_type -> controlplane.ServerResponse
+	17, // 41: controlplane.ServerService.InstallAgent:output_type -> controlplane.MessageResponse
+	26, // 42: controlplane.ServerService.PlanInstallAgent:output_type -> controlplane.WorkflowPlanResponse
+	10, // 43: controlplane.ServerService.GetStats:output_type -> controlplane.GetStatsResponse
+	6,  // 44: controlplane.ServerService.GetRealtimeStats:output_type -> controlplane.GetRealtimeStatsResponse
+	20, // 45: controlplane.ServerService.SyncMesh:output_type -> controlplane.MeshSyncResponse
+	20, // 46: controlplane.ServerService.GetMeshSyncReport:output_type -> controlplane.MeshSyncResponse
+	23, // 47: controlplane.ServerService.GetConnectionPoolStats:output_type -> controlplane.ConnectionPoolStatsResponse
+	34, // [34:48] is the sub-list for method output_type
+	20, // [20:34] is the sub-list for method input_type
+	20, // [20:20] is the sub-list for extension type_name
+	20, // [20:20] is the sub-list for extension extendee
+	0,  // [0:20] is the sub-list for field type_name
 }
 
 func init() { file_controlplane_server_proto_init() }
@@ -1203,16 +1863,18 @@ func file_controlplane_server_proto_init() {
 	if File_controlplane_server_proto != nil {
 		return
 	}
-	file_controlplane_server_proto_msgTypes[7].OneofWrappers = []any{}
+	file_controlplane_workflow_proto_init()
 	file_controlplane_server_proto_msgTypes[8].OneofWrappers = []any{}
-	file_controlplane_server_proto_msgTypes[11].OneofWrappers = []any{}
+	file_controlplane_server_proto_msgTypes[9].OneofWrappers = []any{}
+	file_controlplane_server_proto_msgTypes[12].OneofWrappers = []any{}
+	file_controlplane_server_proto_msgTypes[16].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_controlplane_server_proto_rawDesc), len(file_controlplane_server_proto_rawDesc)),
-			NumEnums:      3,
-			NumMessages:   13,
+			NumEnums:      4,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,15 +20,20 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ServerService_Create_FullMethodName           = "/controlplane.ServerService/Create"
-	ServerService_Get_FullMethodName              = "/controlplane.ServerService/Get"
-	ServerService_All_FullMethodName              = "/controlplane.ServerService/All"
-	ServerService_Update_FullMethodName           = "/controlplane.ServerService/Update"
-	ServerService_Delete_FullMethodName           = "/controlplane.ServerService/Delete"
-	ServerService_RetryConnection_FullMethodName  = "/controlplane.ServerService/RetryConnection"
-	ServerService_InstallAgent_FullMethodName     = "/controlplane.ServerService/InstallAgent"
-	ServerService_GetStats_FullMethodName         = "/controlplane.ServerService/GetStats"
-	ServerService_GetRealtimeStats_FullMethodName = "/controlplane.ServerService/GetRealtimeStats"
+	ServerService_Create_FullMethodName                 = "/controlplane.ServerService/Create"
+	ServerService_Get_FullMethodName                    = "/controlplane.ServerService/Get"
+	ServerService_All_FullMethodName                    = "/controlplane.ServerService/All"
+	ServerService_Update_FullMethodName                 = "/controlplane.ServerService/Update"
+	ServerService_Delete_FullMethodName                 = "/controlplane.ServerService/Delete"
+	ServerService_RetryConnection_FullMethodName        = "/controlplane.ServerService/RetryConnection"
+	ServerService_TrustHostKey_FullMethodName           = "/controlplane.ServerService/TrustHostKey"
+	ServerService_InstallAgent_FullMethodName           = "/controlplane.ServerService/InstallAgent"
+	ServerService_PlanInstallAgent_FullMethodName       = "/controlplane.ServerService/PlanInstallAgent"
+	ServerService_GetStats_FullMethodName               = "/controlplane.ServerService/GetStats"
+	ServerService_GetRealtimeStats_FullMethodName       = "/controlplane.ServerService/GetRealtimeStats"
+	ServerService_SyncMesh_FullMethodName               = "/controlplane.ServerService/SyncMesh"
+	ServerService_GetMeshSyncReport_FullMethodName      = "/controlplane.ServerService/GetMeshSyncReport"
+	ServerService_GetConnectionPoolStats_FullMethodName = "/controlplane.ServerService/GetConnectionPoolStats"
 )
 
 // ServerServiceClient is the client API for ServerService service.
@@ -41,9 +46,17 @@ type ServerServiceClient interface {
 	Update(ctx context.Context, in *Server, opts ...grpc.CallOption) (*ServerResponse, error)
 	Delete(ctx context.Context, in *Id, opts ...grpc.CallOption) (*MessageResponse, error)
 	RetryConnection(ctx context.Context, in *Id, opts ...grpc.CallOption) (*ServerResponse, error)
+	// Pins the SSH host key the server presents now, after a legitimate reinstall, when it has the
+	// expected fingerprint
+	TrustHostKey(ctx context.Context, in *TrustHostKeyRequest, opts ...grpc.CallOption) (*ServerResponse, error)
 	InstallAgent(ctx context.Context, in *Id, opts ...grpc.CallOption) (*MessageResponse, error)
+	// Shows what installing the agent would do on the server, without installing it
+	PlanInstallAgent(ctx context.Context, in *Id, opts ...grpc.CallOption) (*WorkflowPlanResponse, error)
 	GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error)
 	GetRealtimeStats(ctx context.Context, in *GetRealtimeStatsRequest, opts ...grpc.CallOption) (*GetRealtimeStatsResponse, error)
+	SyncMesh(ctx context.Context, in *common.Empty, opts ...grpc.CallOption) (*MeshSyncResponse, error)
+	GetMeshSyncReport(ctx context.Context, in *common.Empty, opts ...grpc.CallOption) (*MeshSyncResponse, error)
+	GetConnectionPoolStats(ctx context.Context, in *common.Empty, opts ...grpc.CallOption) (*ConnectionPoolStatsResponse, error)
 }
 
 type serverServiceClient struct {
@@ -114,6 +127,16 @@ func (c *serverServiceClient) RetryConnection(ctx context.Context, in *Id, opts
 	return out, nil
 }
 
+func (c *serverServiceClient) TrustHostKey(ctx context.Context, in *TrustHostKeyRequest, opts ...grpc.CallOption) (*ServerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServerResponse)
+	err := c.cc.Invoke(ctx, ServerService_TrustHostKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *serverServiceClient) InstallAgent(ctx context.Context, in *Id, opts ...grpc.CallOption) (*MessageResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(MessageResponse)
@@ -124,6 +147,16 @@ func (c *serverServiceClient) InstallAgent(ctx context.Context, in *Id, opts ...
 	return out, nil
 }
 
+func (c *serverServiceClient) PlanInstallAgent(ctx context.Context, in *Id, opts ...grpc.CallOption) (*WorkflowPlanResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(WorkflowPlanResponse)
+	err := c.cc.Invoke(ctx, ServerService_PlanInstallAgent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *serverServiceClient) GetStats(ctx context.Context, in *GetStatsRequest, opts ...grpc.CallOption) (*GetStatsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetStatsResponse)
@@ -144,6 +177,36 @@ func (c *serverServiceClient) GetRealtimeStats(ctx context.Context, in *GetRealt
 	return out, nil
 }
 
+func (c *serverServiceClient) SyncMesh(ctx context.Context, in *common.Empty, opts ...grpc.CallOption) (*MeshSyncResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MeshSyncResponse)
+	err := c.cc.Invoke(ctx, ServerService_SyncMesh_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serverServiceClient) GetMeshSyncReport(ctx context.Context, in *common.Empty, opts ...grpc.CallOption) (*MeshSyncResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MeshSyncResponse)
+	err := c.cc.Invoke(ctx, ServerService_GetMeshSyncReport_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *serverServiceClient) GetConnectionPoolStats(ctx context.Context, in *common.Empty, opts ...grpc.CallOption) (*ConnectionPoolStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConnectionPoolStatsResponse)
+	err := c.cc.Invoke(ctx, ServerService_GetConnectionPoolStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ServerServiceServer is the server API for ServerService service.
 // All implementations must embed UnimplementedServerServiceServer
 // for forward compatibility.
@@ -154,9 +217,17 @@ type ServerServiceServer interface {
 	Update(context.Context, *Server) (*ServerResponse, error)
 	Delete(context.Context, *Id) (*MessageResponse, error)
 	RetryConnection(context.Context, *Id) (*ServerResponse, error)
+	// Pins the SSH host key the server presents now, after a legitimate reinstall, when it has the
+	// expected fingerprint
+	TrustHostKey(context.Context, *TrustHostKeyRequest) (*ServerResponse, error)
 	InstallAgent(context.Context, *Id) (*MessageResponse, error)
+	// Shows what installing the agent would do on the server, without installing it
+	PlanInstallAgent(context.Context, *Id) (*WorkflowPlanResponse, error)
 	GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error)
 	GetRealtimeStats(context.Context, *GetRealtimeStatsRequest) (*GetRealtimeStatsResponse, error)
+	SyncMesh(context.Context, *common.Empty) (*MeshSyncResponse, error)
+	GetMeshSyncReport(context.Context, *common.Empty) (*MeshSyncResponse, error)
+	GetConnectionPoolStats(context.Context, *common.Empty) (*ConnectionPoolStatsResponse, error)
 	mustEmbedUnimplementedServerServiceServer()
 }
 
@@ -185,15 +256,30 @@ func (UnimplementedServerServiceServer) Delete(context.Context, *Id) (*MessageRe
 func (UnimplementedServerServiceServer) RetryConnection(context.Context, *Id) (*ServerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RetryConnection not implemented")
 }
+func (UnimplementedServerServiceServer) TrustHostKey(context.Context, *TrustHostKeyRequest) (*ServerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TrustHostKey not implemented")
+}
 func (UnimplementedServerServiceServer) InstallAgent(context.Context, *Id) (*MessageResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method InstallAgent not implemented")
 }
+func (UnimplementedServerServiceServer) PlanInstallAgent(context.Context, *Id) (*WorkflowPlanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlanInstallAgent not implemented")
+}
 func (UnimplementedServerServiceServer) GetStats(context.Context, *GetStatsRequest) (*GetStatsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStats not implemented")
 }
 func (UnimplementedServerServiceServer) GetRealtimeStats(context.Context, *GetRealtimeStatsRequest) (*GetRealtimeStatsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRealtimeStats not implemented")
 }
+func (UnimplementedServerServiceServer) SyncMesh(context.Context, *common.Empty) (*MeshSyncResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SyncMesh not implemented")
+}
+func (UnimplementedServerServiceServer) GetMeshSyncReport(context.Context, *common.Empty) (*MeshSyncResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMeshSyncReport not implemented")
+}
+func (UnimplementedServerServiceServer) GetConnectionPoolStats(context.Context, *common.Empty) (*ConnectionPoolStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConnectionPoolStats not implemented")
+}
 func (UnimplementedServerServiceServer) mustEmbedUnimplementedServerServiceServer() {}
 func (UnimplementedServerServiceServer) testEmbeddedByValue()                       {}
 
@@ -323,6 +409,24 @@ func _ServerService_RetryConnection_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ServerService_TrustHostKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TrustHostKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServerServiceServer).TrustHostKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServerService_TrustHostKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServerServiceServer).TrustHostKey(ctx, req.(*TrustHostKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ServerService_InstallAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Id)
 	if err := dec(in); err != nil {
@@ -341,6 +445,24 @@ func _ServerService_InstallAgent_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ServerService_PlanInstallAgent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Id)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServerServiceServer).PlanInstallAgent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServerService_PlanInstallAgent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServerServiceServer).PlanInstallAgent(ctx, req.(*Id))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ServerService_GetStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetStatsRequest)
 	if err := dec(in); err != nil {
@@ -377,6 +499,60 @@ func _ServerService_GetRealtimeStats_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ServerService_SyncMesh_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServerServiceServer).SyncMesh(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServerService_SyncMesh_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServerServiceServer).SyncMesh(ctx, req.(*common.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServerService_GetMeshSyncReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServerServiceServer).GetMeshSyncReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServerService_GetMeshSyncReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServerServiceServer).GetMeshSyncReport(ctx, req.(*common.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServerService_GetConnectionPoolStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServerServiceServer).GetConnectionPoolStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServerService_GetConnectionPoolStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServerServiceServer).GetConnectionPoolStats(ctx, req.(*common.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ServerService_ServiceDesc is the grpc.ServiceDesc for ServerService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -408,10 +584,18 @@ var ServerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RetryConnection",
 			Handler:    _ServerService_RetryConnection_Handler,
 		},
+		{
+			MethodName: "TrustHostKey",
+			Handler:    _ServerService_TrustHostKey_Handler,
+		},
 		{
 			MethodName: "InstallAgent",
 			Handler:    _ServerService_InstallAgent_Handler,
 		},
+		{
+			MethodName: "PlanInstallAgent",
+			Handler:    _ServerService_PlanInstallAgent_Handler,
+		},
 		{
 			MethodName: "GetStats",
 			Handler:    _ServerService_GetStats_Handler,
@@ -420,6 +604,18 @@ var ServerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetRealtimeStats",
 			Handler:    _ServerService_GetRealtimeStats_Handler,
 		},
+		{
+			MethodName: "SyncMesh",
+			Handler:    _ServerService_SyncMesh_Handler,
+		},
+		{
+			MethodName: "GetMeshSyncReport",
+			Handler:    _ServerService_GetMeshSyncReport_Handler,
+		},
+		{
+			MethodName: "GetConnectionPoolStats",
+			Handler:    _ServerService_GetConnectionPoolStats_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "controlplane/server.proto",
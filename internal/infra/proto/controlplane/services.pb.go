@@ -218,6 +218,61 @@ func (TakeAction) EnumDescriptor() ([]byte, []int) {
 	return file_controlplane_services_proto_rawDescGZIP(), []int{3}
 }
 
+type DeploymentStatus int32
+
+const (
+	DeploymentStatus_DEPLOYMENT_PENDING   DeploymentStatus = 0
+	DeploymentStatus_DEPLOYMENT_RUNNING   DeploymentStatus = 1
+	DeploymentStatus_DEPLOYMENT_SUCCEEDED DeploymentStatus = 2
+	DeploymentStatus_DEPLOYMENT_FAILED    DeploymentStatus = 3
+	DeploymentStatus_DEPLOYMENT_CANCELLED DeploymentStatus = 4
+)
+
+// Enum value maps for DeploymentStatus.
+var (
+	DeploymentStatus_name = map[int32]string{
+		0: "DEPLOYMENT_PENDING",
+		1: "DEPLOYMENT_RUNNING",
+		2: "DEPLOYMENT_SUCCEEDED",
+		3: "DEPLOYMENT_FAILED",
+		4: "DEPLOYMENT_CANCELLED",
+	}
+	DeploymentStatus_value = map[string]int32{
+		"DEPLOYMENT_PENDING":   0,
+		"DEPLOYMENT_RUNNING":   1,
+		"DEPLOYMENT_SUCCEEDED": 2,
+		"DEPLOYMENT_FAILED":    3,
+		"DEPLOYMENT_CANCELLED": 4,
+	}
+)
+
+func (x DeploymentStatus) Enum() *DeploymentStatus {
+	p := new(DeploymentStatus)
+	*p = x
+	return p
+}
+
+func (x DeploymentStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (DeploymentStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_controlplane_services_proto_enumTypes[4].Descriptor()
+}
+
+func (DeploymentStatus) Type() protoreflect.EnumType {
+	return &file_controlplane_services_proto_enumTypes[4]
+}
+
+func (x DeploymentStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use DeploymentStatus.Descriptor instead.
+func (DeploymentStatus) EnumDescriptor() ([]byte, []int) {
+	return file_controlplane_services_proto_rawDescGZIP(), []int{4}
+}
+
 // Query
 type QueryId struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -391,18 +446,73 @@ func (x *QueryTakeActions) GetServiceIds() []string {
 	return nil
 }
 
-type QueryLog struct {
+type QueryDeployment struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ServiceId     string                 `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
-	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // default is 100 lines
-	Page          int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`   // reversed page. if 1 this mean is the latest logs
+	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	ServiceId     string                 `protobuf:"bytes,2,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"` // cluster id, used to get its latest deployment when deployment_id is empty
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryDeployment) Reset() {
+	*x = QueryDeployment{}
+	mi := &file_controlplane_services_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryDeployment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryDeployment) ProtoMessage() {}
+
+func (x *QueryDeployment) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_services_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryDeployment.ProtoReflect.Descriptor instead.
+func (*QueryDeployment) Descriptor() ([]byte, []int) {
+	return file_controlplane_services_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *QueryDeployment) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *QueryDeployment) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+type QueryLog struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ServiceId string                 `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	Limit     int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"` // default is 100 lines
+	Page      int32                  `protobuf:"varint,3,opt,name=page,proto3" json:"page,omitempty"`   // reversed page. if 1 this mean is the latest logs
+	// RFC3339 timestamps or durations such as 10m, as accepted by docker logs. Not used by FollowLogs
+	Since         string `protobuf:"bytes,4,opt,name=since,proto3" json:"since,omitempty"`
+	Until         string `protobuf:"bytes,5,opt,name=until,proto3" json:"until,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *QueryLog) Reset() {
 	*x = QueryLog{}
-	mi := &file_controlplane_services_proto_msgTypes[3]
+	mi := &file_controlplane_services_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -414,7 +524,7 @@ func (x *QueryLog) String() string {
 func (*QueryLog) ProtoMessage() {}
 
 func (x *QueryLog) ProtoReflect() protoreflect.Message {
-	mi := &file_controlplane_services_proto_msgTypes[3]
+	mi := &file_controlplane_services_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -427,7 +537,7 @@ func (x *QueryLog) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueryLog.ProtoReflect.Descriptor instead.
 func (*QueryLog) Descriptor() ([]byte, []int) {
-	return file_controlplane_services_proto_rawDescGZIP(), []int{3}
+	return file_controlplane_services_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *QueryLog) GetServiceId() string {
@@ -451,6 +561,20 @@ func (x *QueryLog) GetPage() int32 {
 	return 0
 }
 
+func (x *QueryLog) GetSince() string {
+	if x != nil {
+		return x.Since
+	}
+	return ""
+}
+
+func (x *QueryLog) GetUntil() string {
+	if x != nil {
+		return x.Until
+	}
+	return ""
+}
+
 // Utils
 type ServiceNodeField struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -463,7 +587,7 @@ type ServiceNodeField struct {
 
 func (x *ServiceNodeField) Reset() {
 	*x = ServiceNodeField{}
-	mi := &file_controlplane_services_proto_msgTypes[4]
+	mi := &file_controlplane_services_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -475,7 +599,7 @@ func (x *ServiceNodeField) String() string {
 func (*ServiceNodeField) ProtoMessage() {}
 
 func (x *ServiceNodeField) ProtoReflect() protoreflect.Message {
-	mi := &file_controlplane_services_proto_msgTypes[4]
+	mi := &file_controlplane_services_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -488,7 +612,7 @@ func (x *ServiceNodeField) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceNodeField.ProtoReflect.Descriptor instead.
 func (*ServiceNodeField) Descriptor() ([]byte, []int) {
-	return file_controlplane_services_proto_rawDescGZIP(), []int{4}
+	return file_controlplane_services_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *ServiceNodeField) GetKey() string {
@@ -519,13 +643,14 @@ type ServicePort struct {
 	Protocol      string                 `protobuf:"bytes,3,opt,name=protocol,proto3" json:"protocol,omitempty"` // tcp, udp, etc
 	Host          string                 `protobuf:"bytes,4,opt,name=host,proto3" json:"host,omitempty"`
 	EnabledExpose bool                   `protobuf:"varint,5,opt,name=enabled_expose,json=enabledExpose,proto3" json:"enabled_expose,omitempty"` // this mean, if true, expose to internet
+	PublishedPort int32                  `protobuf:"varint,6,opt,name=published_port,json=publishedPort,proto3" json:"published_port,omitempty"` // host port the container port is published on
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *ServicePort) Reset() {
 	*x = ServicePort{}
-	mi := &file_controlplane_services_proto_msgTypes[5]
+	mi := &file_controlplane_services_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -537,7 +662,7 @@ func (x *ServicePort) String() string {
 func (*ServicePort) ProtoMessage() {}
 
 func (x *ServicePort) ProtoReflect() protoreflect.Message {
-	mi := &file_controlplane_services_proto_msgTypes[5]
+	mi := &file_controlplane_services_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -550,7 +675,7 @@ func (x *ServicePort) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServicePort.ProtoReflect.Descriptor instead.
 func (*ServicePort) Descriptor() ([]byte, []int) {
-	return file_controlplane_services_proto_rawDescGZIP(), []int{5}
+	return file_controlplane_services_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ServicePort) GetType() ServicePortType {
@@ -588,6 +713,13 @@ func (x *ServicePort) GetEnabledExpose() bool {
 	return false
 }
 
+func (x *ServicePort) GetPublishedPort() int32 {
+	if x != nil {
+		return x.PublishedPort
+	}
+	return 0
+}
+
 type ServiceContainer struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // docker container id
@@ -601,7 +733,7 @@ type ServiceContainer struct {
 
 func (x *ServiceContainer) Reset() {
 	*x = ServiceContainer{}
-	mi := &file_controlplane_services_proto_msgTypes[6]
+	mi := &file_controlplane_services_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -613,7 +745,7 @@ func (x *ServiceContainer) String() string {
 func (*ServiceContainer) ProtoMessage() {}
 
 func (x *ServiceContainer) ProtoReflect() protoreflect.Message {
-	mi := &file_controlplane_services_proto_msgTypes[6]
+	mi := &file_controlplane_services_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -626,7 +758,7 @@ func (x *ServiceContainer) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceContainer.ProtoReflect.Descriptor instead.
 func (*ServiceContainer) Descriptor() ([]byte, []int) {
-	return file_controlplane_services_proto_rawDescGZIP(), []int{6}
+	return file_controlplane_services_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ServiceContainer) GetId() string {
@@ -675,7 +807,7 @@ type ServiceApp struct {
 
 func (x *ServiceApp) Reset() {
 	*x = ServiceApp{}
-	mi := &file_controlplane_services_proto_msgTypes[7]
+	mi := &file_controlplane_services_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -687,7 +819,7 @@ func (x *ServiceApp) String() string {
 func (*ServiceApp) ProtoMessage() {}
 
 func (x *ServiceApp) ProtoReflect() protoreflect.Message {
-	mi := &file_controlplane_services_proto_msgTypes[7]
+	mi := &file_controlplane_services_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -700,7 +832,7 @@ func (x *ServiceApp) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceApp.ProtoReflect.Descriptor instead.
 func (*ServiceApp) Descriptor() ([]byte, []int) {
-	return file_controlplane_services_proto_rawDescGZIP(), []int{7}
+	return file_controlplane_services_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *ServiceApp) GetApp() string {
@@ -733,13 +865,14 @@ type CreateServiceRequest struct {
 	Fields        []*ServiceNodeField    `protobuf:"bytes,4,rep,name=fields,proto3" json:"fields,omitempty"`
 	ServerId      string                 `protobuf:"bytes,5,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
 	ParentId      *string                `protobuf:"bytes,6,opt,name=parent_id,json=parentId,proto3,oneof" json:"parent_id,omitempty"`
+	Id            string                 `protobuf:"bytes,7,opt,name=id,proto3" json:"id,omitempty"` // required on Update, ignored on Create
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateServiceRequest) Reset() {
 	*x = CreateServiceRequest{}
-	mi := &file_controlplane_services_proto_msgTypes[8]
+	mi := &file_controlplane_services_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -751,7 +884,7 @@ func (x *CreateServiceRequest) String() string {
 func (*CreateServiceRequest) ProtoMessage() {}
 
 func (x *CreateServiceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_controlplane_services_proto_msgTypes[8]
+	mi := &file_controlplane_services_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -764,7 +897,7 @@ func (x *CreateServiceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateServiceRequest.ProtoReflect.Descriptor instead.
 func (*CreateServiceRequest) Descriptor() ([]byte, []int) {
-	return file_controlplane_services_proto_rawDescGZIP(), []int{8}
+	return file_controlplane_services_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *CreateServiceRequest) GetName() string {
@@ -809,19 +942,28 @@ func (x *CreateServiceRequest) GetParentId() string {
 	return ""
 }
 
+func (x *CreateServiceRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
 // always get latest the logs
 type LogsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Logs          []string               `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"`
+	Logs          []string               `protobuf:"bytes,1,rep,name=logs,proto3" json:"logs,omitempty"` // oldest first
 	ServiceId     string                 `protobuf:"bytes,2,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
 	Status        ServiceStatus          `protobuf:"varint,3,opt,name=status,proto3,enum=controlplane.ServiceStatus" json:"status,omitempty"`
+	Page          int32                  `protobuf:"varint,4,opt,name=page,proto3" json:"page,omitempty"`
+	HasMore       bool                   `protobuf:"varint,5,opt,name=has_more,json=hasMore,proto3" json:"has_more,omitempty"` // older lines may be on the next page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *LogsResponse) Reset() {
 	*x = LogsResponse{}
-	mi := &file_controlplane_services_proto_msgTypes[9]
+	mi := &file_controlplane_services_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -833,7 +975,7 @@ func (x *LogsResponse) String() string {
 func (*LogsResponse) ProtoMessage() {}
 
 func (x *LogsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_controlplane_services_proto_msgTypes[9]
+	mi := &file_controlplane_services_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -846,7 +988,7 @@ func (x *LogsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogsResponse.ProtoReflect.Descriptor instead.
 func (*LogsResponse) Descriptor() ([]byte, []int) {
-	return file_controlplane_services_proto_rawDescGZIP(), []int{9}
+	return file_controlplane_services_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *LogsResponse) GetLogs() []string {
@@ -870,6 +1012,72 @@ func (x *LogsResponse) GetStatus() ServiceStatus {
 	return ServiceStatus_OFFLINE
 }
 
+func (x *LogsResponse) GetPage() int32 {
+	if x != nil {
+		return x.Page
+	}
+	return 0
+}
+
+func (x *LogsResponse) GetHasMore() bool {
+	if x != nil {
+		return x.HasMore
+	}
+	return false
+}
+
+type ServiceLogLine struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Stream        string                 `protobuf:"bytes,1,opt,name=stream,proto3" json:"stream,omitempty"` // stdout or stderr
+	Line          string                 `protobuf:"bytes,2,opt,name=line,proto3" json:"line,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceLogLine) Reset() {
+	*x = ServiceLogLine{}
+	mi := &file_controlplane_services_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceLogLine) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceLogLine) ProtoMessage() {}
+
+func (x *ServiceLogLine) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_services_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceLogLine.ProtoReflect.Descriptor instead.
+func (*ServiceLogLine) Descriptor() ([]byte, []int) {
+	return file_controlplane_services_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ServiceLogLine) GetStream() string {
+	if x != nil {
+		return x.Stream
+	}
+	return ""
+}
+
+func (x *ServiceLogLine) GetLine() string {
+	if x != nil {
+		return x.Line
+	}
+	return ""
+}
+
 type ServiceNode struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -901,7 +1109,7 @@ type ServiceNode struct {
 
 func (x *ServiceNode) Reset() {
 	*x = ServiceNode{}
-	mi := &file_controlplane_services_proto_msgTypes[10]
+	mi := &file_controlplane_services_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -913,7 +1121,7 @@ func (x *ServiceNode) String() string {
 func (*ServiceNode) ProtoMessage() {}
 
 func (x *ServiceNode) ProtoReflect() protoreflect.Message {
-	mi := &file_controlplane_services_proto_msgTypes[10]
+	mi := &file_controlplane_services_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -926,7 +1134,7 @@ func (x *ServiceNode) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceNode.ProtoReflect.Descriptor instead.
 func (*ServiceNode) Descriptor() ([]byte, []int) {
-	return file_controlplane_services_proto_rawDescGZIP(), []int{10}
+	return file_controlplane_services_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ServiceNode) GetId() string {
@@ -1020,31 +1228,35 @@ func (x *ServiceNode) GetUpdatedAt() string {
 	return ""
 }
 
-type ServiceNodesResponse struct {
-	state         protoimpl.MessageState    `protogen:"open.v1"`
-	Status        common.StatusCode         `protobuf:"varint,1,opt,name=status,proto3,enum=common.StatusCode" json:"status,omitempty"`
-	Services      []*ServiceNode            `protobuf:"bytes,2,rep,name=services,proto3" json:"services,omitempty"`
-	Errors        []*common.ValidationError `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`
-	Error         *string                   `protobuf:"bytes,4,opt,name=error,proto3,oneof" json:"error,omitempty"`
+// Catalog
+type NodeTypeField struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"` // text, number, port, boolean, options, relation
+	Required      bool                   `protobuf:"varint,4,opt,name=required,proto3" json:"required,omitempty"`
+	Options       []string               `protobuf:"bytes,5,rep,name=options,proto3" json:"options,omitempty"`
+	RelatedTable  string                 `protobuf:"bytes,6,opt,name=related_table,json=relatedTable,proto3" json:"related_table,omitempty"` // servers, backup_storages
+	DefaultValue  string                 `protobuf:"bytes,7,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ServiceNodesResponse) Reset() {
-	*x = ServiceNodesResponse{}
-	mi := &file_controlplane_services_proto_msgTypes[11]
+func (x *NodeTypeField) Reset() {
+	*x = NodeTypeField{}
+	mi := &file_controlplane_services_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ServiceNodesResponse) String() string {
+func (x *NodeTypeField) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ServiceNodesResponse) ProtoMessage() {}
+func (*NodeTypeField) ProtoMessage() {}
 
-func (x *ServiceNodesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_controlplane_services_proto_msgTypes[11]
+func (x *NodeTypeField) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_services_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1055,72 +1267,983 @@ func (x *ServiceNodesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ServiceNodesResponse.ProtoReflect.Descriptor instead.
-func (*ServiceNodesResponse) Descriptor() ([]byte, []int) {
-	return file_controlplane_services_proto_rawDescGZIP(), []int{11}
+// Deprecated: Use NodeTypeField.ProtoReflect.Descriptor instead.
+func (*NodeTypeField) Descriptor() ([]byte, []int) {
+	return file_controlplane_services_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *ServiceNodesResponse) GetStatus() common.StatusCode {
+func (x *NodeTypeField) GetKey() string {
 	if x != nil {
-		return x.Status
+		return x.Key
 	}
-	return common.StatusCode(0)
+	return ""
 }
 
-func (x *ServiceNodesResponse) GetServices() []*ServiceNode {
+func (x *NodeTypeField) GetDescription() string {
 	if x != nil {
-		return x.Services
+		return x.Description
 	}
-	return nil
+	return ""
 }
 
-func (x *ServiceNodesResponse) GetErrors() []*common.ValidationError {
+func (x *NodeTypeField) GetType() string {
 	if x != nil {
-		return x.Errors
+		return x.Type
+	}
+	return ""
+}
+
+func (x *NodeTypeField) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *NodeTypeField) GetOptions() []string {
+	if x != nil {
+		return x.Options
 	}
 	return nil
 }
 
-func (x *ServiceNodesResponse) GetError() string {
-	if x != nil && x.Error != nil {
-		return *x.Error
+func (x *NodeTypeField) GetRelatedTable() string {
+	if x != nil {
+		return x.RelatedTable
 	}
 	return ""
 }
 
-var File_controlplane_services_proto protoreflect.FileDescriptor
+func (x *NodeTypeField) GetDefaultValue() string {
+	if x != nil {
+		return x.DefaultValue
+	}
+	return ""
+}
 
-const file_controlplane_services_proto_rawDesc = "" +
-	"\n" +
-	"\x1bcontrolplane/services.proto\x12\fcontrolplane\x1a\x17common/validation.proto\x1a\x13common/common.proto\"(\n" +
-	"\aQueryId\x12\x1d\n" +
-	"\n" +
-	"service_id\x18\x01 \x01(\tR\tserviceId\"{\n" +
-	"\rQueryServices\x12\x12\n" +
-	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
-	"\x06search\x18\x03 \x01(\tR\x06search\x12\x12\n" +
-	"\x04sort\x18\x04 \x01(\tR\x04sort\x12\x14\n" +
-	"\x05order\x18\x05 \x01(\tR\x05order\"e\n" +
-	"\x10QueryTakeActions\x120\n" +
-	"\x06action\x18\x01 \x01(\x0e2\x18.controlplane.TakeActionR\x06action\x12\x1f\n" +
-	"\vservice_ids\x18\x02 \x03(\tR\n" +
-	"serviceIds\"S\n" +
-	"\bQueryLog\x12\x1d\n" +
-	"\n" +
-	"service_id\x18\x01 \x01(\tR\tserviceId\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x12\n" +
-	"\x04page\x18\x03 \x01(\x05R\x04page\"N\n" +
-	"\x10ServiceNodeField\x12\x10\n" +
-	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value\x12\x12\n" +
-	"\x04type\x18\x03 \x01(\tR\x04type\"\xab\x01\n" +
-	"\vServicePort\x121\n" +
-	"\x04type\x18\x01 \x01(\x0e2\x1d.controlplane.ServicePortTypeR\x04type\x12\x12\n" +
-	"\x04port\x18\x02 \x01(\x05R\x04port\x12\x1a\n" +
-	"\bprotocol\x18\x03 \x01(\tR\bprotocol\x12\x12\n" +
+type NodeTypePort struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Peer          string                 `protobuf:"bytes,1,opt,name=peer,proto3" json:"peer,omitempty"` // node type the port is imported from or exported to
+	Port          int32                  `protobuf:"varint,2,opt,name=port,proto3" json:"port,omitempty"`
+	Protocol      string                 `protobuf:"bytes,3,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NodeTypePort) Reset() {
+	*x = NodeTypePort{}
+	mi := &file_controlplane_services_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NodeTypePort) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeTypePort) ProtoMessage() {}
+
+func (x *NodeTypePort) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_services_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeTypePort.ProtoReflect.Descriptor instead.
+func (*NodeTypePort) Descriptor() ([]byte, []int) {
+	return file_controlplane_services_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *NodeTypePort) GetPeer() string {
+	if x != nil {
+		return x.Peer
+	}
+	return ""
+}
+
+func (x *NodeTypePort) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *NodeTypePort) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *NodeTypePort) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type NodeType struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Type            string                 `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Name            string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description     string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	PriorityStartup int32                  `protobuf:"varint,4,opt,name=priority_startup,json=priorityStartup,proto3" json:"priority_startup,omitempty"`
+	Fields          []*NodeTypeField       `protobuf:"bytes,5,rep,name=fields,proto3" json:"fields,omitempty"`
+	Imports         []*NodeTypePort        `protobuf:"bytes,6,rep,name=imports,proto3" json:"imports,omitempty"`
+	Exports         []*NodeTypePort        `protobuf:"bytes,7,rep,name=exports,proto3" json:"exports,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *NodeType) Reset() {
+	*x = NodeType{}
+	mi := &file_controlplane_services_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NodeType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeType) ProtoMessage() {}
+
+func (x *NodeType) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_services_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeType.ProtoReflect.Descriptor instead.
+func (*NodeType) Descriptor() ([]byte, []int) {
+	return file_controlplane_services_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *NodeType) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *NodeType) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *NodeType) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *NodeType) GetPriorityStartup() int32 {
+	if x != nil {
+		return x.PriorityStartup
+	}
+	return 0
+}
+
+func (x *NodeType) GetFields() []*NodeTypeField {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *NodeType) GetImports() []*NodeTypePort {
+	if x != nil {
+		return x.Imports
+	}
+	return nil
+}
+
+func (x *NodeType) GetExports() []*NodeTypePort {
+	if x != nil {
+		return x.Exports
+	}
+	return nil
+}
+
+type NodeTypesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        common.StatusCode      `protobuf:"varint,1,opt,name=status,proto3,enum=common.StatusCode" json:"status,omitempty"`
+	App           string                 `protobuf:"bytes,2,opt,name=app,proto3" json:"app,omitempty"`
+	NodeTypes     []*NodeType            `protobuf:"bytes,3,rep,name=node_types,json=nodeTypes,proto3" json:"node_types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NodeTypesResponse) Reset() {
+	*x = NodeTypesResponse{}
+	mi := &file_controlplane_services_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NodeTypesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NodeTypesResponse) ProtoMessage() {}
+
+func (x *NodeTypesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_services_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NodeTypesResponse.ProtoReflect.Descriptor instead.
+func (*NodeTypesResponse) Descriptor() ([]byte, []int) {
+	return file_controlplane_services_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *NodeTypesResponse) GetStatus() common.StatusCode {
+	if x != nil {
+		return x.Status
+	}
+	return common.StatusCode(0)
+}
+
+func (x *NodeTypesResponse) GetApp() string {
+	if x != nil {
+		return x.App
+	}
+	return ""
+}
+
+func (x *NodeTypesResponse) GetNodeTypes() []*NodeType {
+	if x != nil {
+		return x.NodeTypes
+	}
+	return nil
+}
+
+// Topology
+type ConnectRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SourceId      string                 `protobuf:"bytes,1,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
+	TargetId      string                 `protobuf:"bytes,2,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConnectRequest) Reset() {
+	*x = ConnectRequest{}
+	mi := &file_controlplane_services_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConnectRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConnectRequest) ProtoMessage() {}
+
+func (x *ConnectRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_services_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConnectRequest.ProtoReflect.Descriptor instead.
+func (*ConnectRequest) Descriptor() ([]byte, []int) {
+	return file_controlplane_services_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ConnectRequest) GetSourceId() string {
+	if x != nil {
+		return x.SourceId
+	}
+	return ""
+}
+
+func (x *ConnectRequest) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+type ServiceEdge struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SourceId      string                 `protobuf:"bytes,2,opt,name=source_id,json=sourceId,proto3" json:"source_id,omitempty"`
+	TargetId      string                 `protobuf:"bytes,3,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceEdge) Reset() {
+	*x = ServiceEdge{}
+	mi := &file_controlplane_services_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceEdge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceEdge) ProtoMessage() {}
+
+func (x *ServiceEdge) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_services_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceEdge.ProtoReflect.Descriptor instead.
+func (*ServiceEdge) Descriptor() ([]byte, []int) {
+	return file_controlplane_services_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ServiceEdge) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ServiceEdge) GetSourceId() string {
+	if x != nil {
+		return x.SourceId
+	}
+	return ""
+}
+
+func (x *ServiceEdge) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+type ServiceEndpoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServiceId     string                 `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	Host          string                 `protobuf:"bytes,2,opt,name=host,proto3" json:"host,omitempty"`
+	Port          int32                  `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceEndpoint) Reset() {
+	*x = ServiceEndpoint{}
+	mi := &file_controlplane_services_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceEndpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceEndpoint) ProtoMessage() {}
+
+func (x *ServiceEndpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_services_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceEndpoint.ProtoReflect.Descriptor instead.
+func (*ServiceEndpoint) Descriptor() ([]byte, []int) {
+	return file_controlplane_services_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ServiceEndpoint) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+func (x *ServiceEndpoint) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *ServiceEndpoint) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+// ResolvedImport is the upstream a node uses for one of its imported ports
+type ResolvedImport struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ServiceId     string                 `protobuf:"bytes,1,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	From          string                 `protobuf:"bytes,2,opt,name=from,proto3" json:"from,omitempty"`  // node type the port is imported from
+	Port          int32                  `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"` // container port, e.g. 9898
+	Protocol      string                 `protobuf:"bytes,4,opt,name=protocol,proto3" json:"protocol,omitempty"`
+	Upstreams     []*ServiceEndpoint     `protobuf:"bytes,5,rep,name=upstreams,proto3" json:"upstreams,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolvedImport) Reset() {
+	*x = ResolvedImport{}
+	mi := &file_controlplane_services_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolvedImport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolvedImport) ProtoMessage() {}
+
+func (x *ResolvedImport) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_services_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolvedImport.ProtoReflect.Descriptor instead.
+func (*ResolvedImport) Descriptor() ([]byte, []int) {
+	return file_controlplane_services_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ResolvedImport) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+func (x *ResolvedImport) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ResolvedImport) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *ResolvedImport) GetProtocol() string {
+	if x != nil {
+		return x.Protocol
+	}
+	return ""
+}
+
+func (x *ResolvedImport) GetUpstreams() []*ServiceEndpoint {
+	if x != nil {
+		return x.Upstreams
+	}
+	return nil
+}
+
+type TopologyResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Status        common.StatusCode         `protobuf:"varint,1,opt,name=status,proto3,enum=common.StatusCode" json:"status,omitempty"`
+	Edges         []*ServiceEdge            `protobuf:"bytes,2,rep,name=edges,proto3" json:"edges,omitempty"`
+	Imports       []*ResolvedImport         `protobuf:"bytes,3,rep,name=imports,proto3" json:"imports,omitempty"`
+	Errors        []*common.ValidationError `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
+	Error         *string                   `protobuf:"bytes,5,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TopologyResponse) Reset() {
+	*x = TopologyResponse{}
+	mi := &file_controlplane_services_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TopologyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TopologyResponse) ProtoMessage() {}
+
+func (x *TopologyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_services_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TopologyResponse.ProtoReflect.Descriptor instead.
+func (*TopologyResponse) Descriptor() ([]byte, []int) {
+	return file_controlplane_services_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *TopologyResponse) GetStatus() common.StatusCode {
+	if x != nil {
+		return x.Status
+	}
+	return common.StatusCode(0)
+}
+
+func (x *TopologyResponse) GetEdges() []*ServiceEdge {
+	if x != nil {
+		return x.Edges
+	}
+	return nil
+}
+
+func (x *TopologyResponse) GetImports() []*ResolvedImport {
+	if x != nil {
+		return x.Imports
+	}
+	return nil
+}
+
+func (x *TopologyResponse) GetErrors() []*common.ValidationError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *TopologyResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+// Deployment
+type DeploymentStep struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Project       string                 `protobuf:"bytes,1,opt,name=project,proto3" json:"project,omitempty"` // compose project name
+	ServerId      string                 `protobuf:"bytes,2,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	ServiceIds    []string               `protobuf:"bytes,3,rep,name=service_ids,json=serviceIds,proto3" json:"service_ids,omitempty"`
+	Priority      int32                  `protobuf:"varint,4,opt,name=priority,proto3" json:"priority,omitempty"`
+	Status        DeploymentStatus       `protobuf:"varint,5,opt,name=status,proto3,enum=controlplane.DeploymentStatus" json:"status,omitempty"`
+	Error         string                 `protobuf:"bytes,6,opt,name=error,proto3" json:"error,omitempty"`
+	StartedAt     string                 `protobuf:"bytes,7,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	FinishedAt    string                 `protobuf:"bytes,8,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeploymentStep) Reset() {
+	*x = DeploymentStep{}
+	mi := &file_controlplane_services_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeploymentStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeploymentStep) ProtoMessage() {}
+
+func (x *DeploymentStep) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_services_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeploymentStep.ProtoReflect.Descriptor instead.
+func (*DeploymentStep) Descriptor() ([]byte, []int) {
+	return file_controlplane_services_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *DeploymentStep) GetProject() string {
+	if x != nil {
+		return x.Project
+	}
+	return ""
+}
+
+func (x *DeploymentStep) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *DeploymentStep) GetServiceIds() []string {
+	if x != nil {
+		return x.ServiceIds
+	}
+	return nil
+}
+
+func (x *DeploymentStep) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *DeploymentStep) GetStatus() DeploymentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return DeploymentStatus_DEPLOYMENT_PENDING
+}
+
+func (x *DeploymentStep) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DeploymentStep) GetStartedAt() string {
+	if x != nil {
+		return x.StartedAt
+	}
+	return ""
+}
+
+func (x *DeploymentStep) GetFinishedAt() string {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return ""
+}
+
+type Deployment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ClusterId     string                 `protobuf:"bytes,2,opt,name=cluster_id,json=clusterId,proto3" json:"cluster_id,omitempty"`
+	Status        DeploymentStatus       `protobuf:"varint,3,opt,name=status,proto3,enum=controlplane.DeploymentStatus" json:"status,omitempty"`
+	Error         string                 `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+	Steps         []*DeploymentStep      `protobuf:"bytes,5,rep,name=steps,proto3" json:"steps,omitempty"`
+	CreatedAt     string                 `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     string                 `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	FinishedAt    string                 `protobuf:"bytes,8,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Deployment) Reset() {
+	*x = Deployment{}
+	mi := &file_controlplane_services_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Deployment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Deployment) ProtoMessage() {}
+
+func (x *Deployment) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_services_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Deployment.ProtoReflect.Descriptor instead.
+func (*Deployment) Descriptor() ([]byte, []int) {
+	return file_controlplane_services_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *Deployment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Deployment) GetClusterId() string {
+	if x != nil {
+		return x.ClusterId
+	}
+	return ""
+}
+
+func (x *Deployment) GetStatus() DeploymentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return DeploymentStatus_DEPLOYMENT_PENDING
+}
+
+func (x *Deployment) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *Deployment) GetSteps() []*DeploymentStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *Deployment) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *Deployment) GetUpdatedAt() string {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return ""
+}
+
+func (x *Deployment) GetFinishedAt() string {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return ""
+}
+
+type DeploymentResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Status        common.StatusCode         `protobuf:"varint,1,opt,name=status,proto3,enum=common.StatusCode" json:"status,omitempty"`
+	Deployment    *Deployment               `protobuf:"bytes,2,opt,name=deployment,proto3" json:"deployment,omitempty"`
+	Errors        []*common.ValidationError `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`
+	Error         *string                   `protobuf:"bytes,4,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeploymentResponse) Reset() {
+	*x = DeploymentResponse{}
+	mi := &file_controlplane_services_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeploymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeploymentResponse) ProtoMessage() {}
+
+func (x *DeploymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_services_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeploymentResponse.ProtoReflect.Descriptor instead.
+func (*DeploymentResponse) Descriptor() ([]byte, []int) {
+	return file_controlplane_services_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DeploymentResponse) GetStatus() common.StatusCode {
+	if x != nil {
+		return x.Status
+	}
+	return common.StatusCode(0)
+}
+
+func (x *DeploymentResponse) GetDeployment() *Deployment {
+	if x != nil {
+		return x.Deployment
+	}
+	return nil
+}
+
+func (x *DeploymentResponse) GetErrors() []*common.ValidationError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *DeploymentResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+type ServiceNodesResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Status        common.StatusCode         `protobuf:"varint,1,opt,name=status,proto3,enum=common.StatusCode" json:"status,omitempty"`
+	Services      []*ServiceNode            `protobuf:"bytes,2,rep,name=services,proto3" json:"services,omitempty"`
+	Errors        []*common.ValidationError `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`
+	Error         *string                   `protobuf:"bytes,4,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceNodesResponse) Reset() {
+	*x = ServiceNodesResponse{}
+	mi := &file_controlplane_services_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceNodesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceNodesResponse) ProtoMessage() {}
+
+func (x *ServiceNodesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_services_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceNodesResponse.ProtoReflect.Descriptor instead.
+func (*ServiceNodesResponse) Descriptor() ([]byte, []int) {
+	return file_controlplane_services_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *ServiceNodesResponse) GetStatus() common.StatusCode {
+	if x != nil {
+		return x.Status
+	}
+	return common.StatusCode(0)
+}
+
+func (x *ServiceNodesResponse) GetServices() []*ServiceNode {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+func (x *ServiceNodesResponse) GetErrors() []*common.ValidationError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *ServiceNodesResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+var File_controlplane_services_proto protoreflect.FileDescriptor
+
+const file_controlplane_services_proto_rawDesc = "" +
+	"\n" +
+	"\x1bcontrolplane/services.proto\x12\fcontrolplane\x1a\x17common/validation.proto\x1a\x13common/common.proto\"(\n" +
+	"\aQueryId\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x01 \x01(\tR\tserviceId\"{\n" +
+	"\rQueryServices\x12\x12\n" +
+	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06search\x18\x03 \x01(\tR\x06search\x12\x12\n" +
+	"\x04sort\x18\x04 \x01(\tR\x04sort\x12\x14\n" +
+	"\x05order\x18\x05 \x01(\tR\x05order\"e\n" +
+	"\x10QueryTakeActions\x120\n" +
+	"\x06action\x18\x01 \x01(\x0e2\x18.controlplane.TakeActionR\x06action\x12\x1f\n" +
+	"\vservice_ids\x18\x02 \x03(\tR\n" +
+	"serviceIds\"U\n" +
+	"\x0fQueryDeployment\x12#\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x02 \x01(\tR\tserviceId\"\x7f\n" +
+	"\bQueryLog\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x01 \x01(\tR\tserviceId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x12\n" +
+	"\x04page\x18\x03 \x01(\x05R\x04page\x12\x14\n" +
+	"\x05since\x18\x04 \x01(\tR\x05since\x12\x14\n" +
+	"\x05until\x18\x05 \x01(\tR\x05until\"N\n" +
+	"\x10ServiceNodeField\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\"\xd2\x01\n" +
+	"\vServicePort\x121\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x1d.controlplane.ServicePortTypeR\x04type\x12\x12\n" +
+	"\x04port\x18\x02 \x01(\x05R\x04port\x12\x1a\n" +
+	"\bprotocol\x18\x03 \x01(\tR\bprotocol\x12\x12\n" +
 	"\x04host\x18\x04 \x01(\tR\x04host\x12%\n" +
-	"\x0eenabled_expose\x18\x05 \x01(\bR\renabledExpose\"\x8a\x01\n" +
+	"\x0eenabled_expose\x18\x05 \x01(\bR\renabledExpose\x12%\n" +
+	"\x0epublished_port\x18\x06 \x01(\x05R\rpublishedPort\"\x8a\x01\n" +
 	"\x10ServiceContainer\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x14\n" +
@@ -1133,21 +2256,27 @@ const file_controlplane_services_proto_rawDesc = "" +
 	"ServiceApp\x12\x10\n" +
 	"\x03app\x18\x01 \x01(\tR\x03app\x12\x18\n" +
 	"\aversion\x18\x02 \x01(\tR\aversion\x12\x18\n" +
-	"\aservice\x18\x03 \x01(\tR\aservice\"\x8a\x02\n" +
+	"\aservice\x18\x03 \x01(\tR\aservice\"\x9a\x02\n" +
 	"\x14CreateServiceRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12-\n" +
 	"\x04type\x18\x02 \x01(\x0e2\x19.controlplane.ServiceTypeR\x04type\x12*\n" +
 	"\x03app\x18\x03 \x01(\v2\x18.controlplane.ServiceAppR\x03app\x126\n" +
 	"\x06fields\x18\x04 \x03(\v2\x1e.controlplane.ServiceNodeFieldR\x06fields\x12\x1b\n" +
 	"\tserver_id\x18\x05 \x01(\tR\bserverId\x12 \n" +
-	"\tparent_id\x18\x06 \x01(\tH\x00R\bparentId\x88\x01\x01B\f\n" +
+	"\tparent_id\x18\x06 \x01(\tH\x00R\bparentId\x88\x01\x01\x12\x0e\n" +
+	"\x02id\x18\a \x01(\tR\x02idB\f\n" +
 	"\n" +
-	"_parent_id\"v\n" +
+	"_parent_id\"\xa5\x01\n" +
 	"\fLogsResponse\x12\x12\n" +
 	"\x04logs\x18\x01 \x03(\tR\x04logs\x12\x1d\n" +
 	"\n" +
 	"service_id\x18\x02 \x01(\tR\tserviceId\x123\n" +
-	"\x06status\x18\x03 \x01(\x0e2\x1b.controlplane.ServiceStatusR\x06status\"\x91\x04\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x1b.controlplane.ServiceStatusR\x06status\x12\x12\n" +
+	"\x04page\x18\x04 \x01(\x05R\x04page\x12\x19\n" +
+	"\bhas_more\x18\x05 \x01(\bR\ahasMore\"<\n" +
+	"\x0eServiceLogLine\x12\x16\n" +
+	"\x06stream\x18\x01 \x01(\tR\x06stream\x12\x12\n" +
+	"\x04line\x18\x02 \x01(\tR\x04line\"\x91\x04\n" +
 	"\vServiceNode\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12*\n" +
@@ -1164,7 +2293,93 @@ const file_controlplane_services_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\f \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\r \x01(\tR\tupdatedAt\"\xcf\x01\n" +
+	"updated_at\x18\r \x01(\tR\tupdatedAt\"\xd7\x01\n" +
+	"\rNodeTypeField\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12\x1a\n" +
+	"\brequired\x18\x04 \x01(\bR\brequired\x12\x18\n" +
+	"\aoptions\x18\x05 \x03(\tR\aoptions\x12#\n" +
+	"\rrelated_table\x18\x06 \x01(\tR\frelatedTable\x12#\n" +
+	"\rdefault_value\x18\a \x01(\tR\fdefaultValue\"t\n" +
+	"\fNodeTypePort\x12\x12\n" +
+	"\x04peer\x18\x01 \x01(\tR\x04peer\x12\x12\n" +
+	"\x04port\x18\x02 \x01(\x05R\x04port\x12\x1a\n" +
+	"\bprotocol\x18\x03 \x01(\tR\bprotocol\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\"\xa0\x02\n" +
+	"\bNodeType\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12)\n" +
+	"\x10priority_startup\x18\x04 \x01(\x05R\x0fpriorityStartup\x123\n" +
+	"\x06fields\x18\x05 \x03(\v2\x1b.controlplane.NodeTypeFieldR\x06fields\x124\n" +
+	"\aimports\x18\x06 \x03(\v2\x1a.controlplane.NodeTypePortR\aimports\x124\n" +
+	"\aexports\x18\a \x03(\v2\x1a.controlplane.NodeTypePortR\aexports\"\x88\x01\n" +
+	"\x11NodeTypesResponse\x12*\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x12.common.StatusCodeR\x06status\x12\x10\n" +
+	"\x03app\x18\x02 \x01(\tR\x03app\x125\n" +
+	"\n" +
+	"node_types\x18\x03 \x03(\v2\x16.controlplane.NodeTypeR\tnodeTypes\"J\n" +
+	"\x0eConnectRequest\x12\x1b\n" +
+	"\tsource_id\x18\x01 \x01(\tR\bsourceId\x12\x1b\n" +
+	"\ttarget_id\x18\x02 \x01(\tR\btargetId\"W\n" +
+	"\vServiceEdge\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1b\n" +
+	"\tsource_id\x18\x02 \x01(\tR\bsourceId\x12\x1b\n" +
+	"\ttarget_id\x18\x03 \x01(\tR\btargetId\"X\n" +
+	"\x0fServiceEndpoint\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x01 \x01(\tR\tserviceId\x12\x12\n" +
+	"\x04host\x18\x02 \x01(\tR\x04host\x12\x12\n" +
+	"\x04port\x18\x03 \x01(\x05R\x04port\"\xb0\x01\n" +
+	"\x0eResolvedImport\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x01 \x01(\tR\tserviceId\x12\x12\n" +
+	"\x04from\x18\x02 \x01(\tR\x04from\x12\x12\n" +
+	"\x04port\x18\x03 \x01(\x05R\x04port\x12\x1a\n" +
+	"\bprotocol\x18\x04 \x01(\tR\bprotocol\x12;\n" +
+	"\tupstreams\x18\x05 \x03(\v2\x1d.controlplane.ServiceEndpointR\tupstreams\"\xfd\x01\n" +
+	"\x10TopologyResponse\x12*\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x12.common.StatusCodeR\x06status\x12/\n" +
+	"\x05edges\x18\x02 \x03(\v2\x19.controlplane.ServiceEdgeR\x05edges\x126\n" +
+	"\aimports\x18\x03 \x03(\v2\x1c.controlplane.ResolvedImportR\aimports\x12/\n" +
+	"\x06errors\x18\x04 \x03(\v2\x17.common.ValidationErrorR\x06errors\x12\x19\n" +
+	"\x05error\x18\x05 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
+	"\x06_error\"\x92\x02\n" +
+	"\x0eDeploymentStep\x12\x18\n" +
+	"\aproject\x18\x01 \x01(\tR\aproject\x12\x1b\n" +
+	"\tserver_id\x18\x02 \x01(\tR\bserverId\x12\x1f\n" +
+	"\vservice_ids\x18\x03 \x03(\tR\n" +
+	"serviceIds\x12\x1a\n" +
+	"\bpriority\x18\x04 \x01(\x05R\bpriority\x126\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x1e.controlplane.DeploymentStatusR\x06status\x12\x14\n" +
+	"\x05error\x18\x06 \x01(\tR\x05error\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\a \x01(\tR\tstartedAt\x12\x1f\n" +
+	"\vfinished_at\x18\b \x01(\tR\n" +
+	"finishedAt\"\x9c\x02\n" +
+	"\n" +
+	"Deployment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"cluster_id\x18\x02 \x01(\tR\tclusterId\x126\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x1e.controlplane.DeploymentStatusR\x06status\x12\x14\n" +
+	"\x05error\x18\x04 \x01(\tR\x05error\x122\n" +
+	"\x05steps\x18\x05 \x03(\v2\x1c.controlplane.DeploymentStepR\x05steps\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\tR\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\tR\tupdatedAt\x12\x1f\n" +
+	"\vfinished_at\x18\b \x01(\tR\n" +
+	"finishedAt\"\xd0\x01\n" +
+	"\x12DeploymentResponse\x12*\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x12.common.StatusCodeR\x06status\x128\n" +
+	"\n" +
+	"deployment\x18\x02 \x01(\v2\x18.controlplane.DeploymentR\n" +
+	"deployment\x12/\n" +
+	"\x06errors\x18\x03 \x03(\v2\x17.common.ValidationErrorR\x06errors\x12\x19\n" +
+	"\x05error\x18\x04 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
+	"\x06_error\"\xcf\x01\n" +
 	"\x14ServiceNodesResponse\x12*\n" +
 	"\x06status\x18\x01 \x01(\x0e2\x12.common.StatusCodeR\x06status\x125\n" +
 	"\bservices\x18\x02 \x03(\v2\x19.controlplane.ServiceNodeR\bservices\x12/\n" +
@@ -1187,7 +2402,13 @@ const file_controlplane_services_proto_rawDesc = "" +
 	"TakeAction\x12\t\n" +
 	"\x05START\x10\x00\x12\b\n" +
 	"\x04STOP\x10\x01\x12\v\n" +
-	"\aRESTART\x10\x022\xf0\x03\n" +
+	"\aRESTART\x10\x02*\x8d\x01\n" +
+	"\x10DeploymentStatus\x12\x16\n" +
+	"\x12DEPLOYMENT_PENDING\x10\x00\x12\x16\n" +
+	"\x12DEPLOYMENT_RUNNING\x10\x01\x12\x18\n" +
+	"\x14DEPLOYMENT_SUCCEEDED\x10\x02\x12\x15\n" +
+	"\x11DEPLOYMENT_FAILED\x10\x03\x12\x18\n" +
+	"\x14DEPLOYMENT_CANCELLED\x10\x042\x9e\b\n" +
 	"\x0fServicesService\x12F\n" +
 	"\x03All\x12\x1b.controlplane.QueryServices\x1a\".controlplane.ServiceNodesResponse\x127\n" +
 	"\x03One\x12\x15.controlplane.QueryId\x1a\x19.controlplane.ServiceNode\x12G\n" +
@@ -1195,7 +2416,17 @@ const file_controlplane_services_proto_rawDesc = "" +
 	"\x06Update\x12\".controlplane.CreateServiceRequest\x1a\x19.controlplane.ServiceNode\x128\n" +
 	"\x06Delete\x12\x15.controlplane.QueryId\x1a\x17.common.MessageResponse\x12Q\n" +
 	"\vTakeActions\x12\x1e.controlplane.QueryTakeActions\x1a\".controlplane.ServiceNodesResponse\x12=\n" +
-	"\aGetLogs\x12\x16.controlplane.QueryLog\x1a\x1a.controlplane.LogsResponseB;Z9github.com/zhinea/sylix/internal/infra/proto/controlplaneb\x06proto3"
+	"\aGetLogs\x12\x16.controlplane.QueryLog\x1a\x1a.controlplane.LogsResponse\x12D\n" +
+	"\n" +
+	"FollowLogs\x12\x16.controlplane.QueryLog\x1a\x1c.controlplane.ServiceLogLine0\x01\x12;\n" +
+	"\tNodeTypes\x12\r.common.Empty\x1a\x1f.controlplane.NodeTypesResponse\x12@\n" +
+	"\aConnect\x12\x1c.controlplane.ConnectRequest\x1a\x17.common.MessageResponse\x12C\n" +
+	"\n" +
+	"Disconnect\x12\x1c.controlplane.ConnectRequest\x1a\x17.common.MessageResponse\x12A\n" +
+	"\bTopology\x12\x15.controlplane.QueryId\x1a\x1e.controlplane.TopologyResponse\x12A\n" +
+	"\x06Deploy\x12\x15.controlplane.QueryId\x1a .controlplane.DeploymentResponse\x12P\n" +
+	"\rGetDeployment\x12\x1d.controlplane.QueryDeployment\x1a .controlplane.DeploymentResponse\x12J\n" +
+	"\x10CancelDeployment\x12\x1d.controlplane.QueryDeployment\x1a\x17.common.MessageResponseB;Z9github.com/zhinea/sylix/internal/infra/proto/controlplaneb\x06proto3"
 
 var (
 	file_controlplane_services_proto_rawDescOnce sync.Once
@@ -1209,65 +2440,113 @@ func file_controlplane_services_proto_rawDescGZIP() []byte {
 	return file_controlplane_services_proto_rawDescData
 }
 
-var file_controlplane_services_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
-var file_controlplane_services_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_controlplane_services_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_controlplane_services_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file_controlplane_services_proto_goTypes = []any{
 	(ServiceType)(0),               // 0: controlplane.ServiceType
 	(ServiceStatus)(0),             // 1: controlplane.ServiceStatus
 	(ServicePortType)(0),           // 2: controlplane.ServicePortType
 	(TakeAction)(0),                // 3: controlplane.TakeAction
-	(*QueryId)(nil),                // 4: controlplane.QueryId
-	(*QueryServices)(nil),          // 5: controlplane.QueryServices
-	(*QueryTakeActions)(nil),       // 6: controlplane.QueryTakeActions
-	(*QueryLog)(nil),               // 7: controlplane.QueryLog
-	(*ServiceNodeField)(nil),       // 8: controlplane.ServiceNodeField
-	(*ServicePort)(nil),            // 9: controlplane.ServicePort
-	(*ServiceContainer)(nil),       // 10: controlplane.ServiceContainer
-	(*ServiceApp)(nil),             // 11: controlplane.ServiceApp
-	(*CreateServiceRequest)(nil),   // 12: controlplane.CreateServiceRequest
-	(*LogsResponse)(nil),           // 13: controlplane.LogsResponse
-	(*ServiceNode)(nil),            // 14: controlplane.ServiceNode
-	(*ServiceNodesResponse)(nil),   // 15: controlplane.ServiceNodesResponse
-	(common.StatusCode)(0),         // 16: common.StatusCode
-	(*common.ValidationError)(nil), // 17: common.ValidationError
-	(*common.MessageResponse)(nil), // 18: common.MessageResponse
+	(DeploymentStatus)(0),          // 4: controlplane.DeploymentStatus
+	(*QueryId)(nil),                // 5: controlplane.QueryId
+	(*QueryServices)(nil),          // 6: controlplane.QueryServices
+	(*QueryTakeActions)(nil),       // 7: controlplane.QueryTakeActions
+	(*QueryDeployment)(nil),        // 8: controlplane.QueryDeployment
+	(*QueryLog)(nil),               // 9: controlplane.QueryLog
+	(*ServiceNodeField)(nil),       // 10: controlplane.ServiceNodeField
+	(*ServicePort)(nil),            // 11: controlplane.ServicePort
+	(*ServiceContainer)(nil),       // 12: controlplane.ServiceContainer
+	(*ServiceApp)(nil),             // 13: controlplane.ServiceApp
+	(*CreateServiceRequest)(nil),   // 14: controlplane.CreateServiceRequest
+	(*LogsResponse)(nil),           // 15: controlplane.LogsResponse
+	(*ServiceLogLine)(nil),         // 16: controlplane.ServiceLogLine
+	(*ServiceNode)(nil),            // 17: controlplane.ServiceNode
+	(*NodeTypeField)(nil),          // 18: controlplane.NodeTypeField
+	(*NodeTypePort)(nil),           // 19: controlplane.NodeTypePort
+	(*NodeType)(nil),               // 20: controlplane.NodeType
+	(*NodeTypesResponse)(nil),      // 21: controlplane.NodeTypesResponse
+	(*ConnectRequest)(nil),         // 22: controlplane.ConnectRequest
+	(*ServiceEdge)(nil),            // 23: controlplane.ServiceEdge
+	(*ServiceEndpoint)(nil),        // 24: controlplane.ServiceEndpoint
+	(*ResolvedImport)(nil),         // 25: controlplane.ResolvedImport
+	(*TopologyResponse)(nil),       // 26: controlplane.TopologyResponse
+	(*DeploymentStep)(nil),         // 27: controlplane.DeploymentStep
+	(*Deployment)(nil),             // 28: controlplane.Deployment
+	(*DeploymentResponse)(nil),     // 29: controlplane.DeploymentResponse
+	(*ServiceNodesResponse)(nil),   // 30: controlplane.ServiceNodesResponse
+	(common.StatusCode)(0),         // 31: common.StatusCode
+	(*common.ValidationError)(nil), // 32: common.ValidationError
+	(*common.Empty)(nil),           // 33: common.Empty
+	(*common.MessageResponse)(nil), // 34: common.MessageResponse
 }
 var file_controlplane_services_proto_depIdxs = []int32{
 	3,  // 0: controlplane.QueryTakeActions.action:type_name -> controlplane.TakeAction
 	2,  // 1: controlplane.ServicePort.type:type_name -> controlplane.ServicePortType
 	0,  // 2: controlplane.CreateServiceRequest.type:type_name -> controlplane.ServiceType
-	11, // 3: controlplane.CreateServiceRequest.app:type_name -> controlplane.ServiceApp
-	8,  // 4: controlplane.CreateServiceRequest.fields:type_name -> controlplane.ServiceNodeField
+	13, // 3: controlplane.CreateServiceRequest.app:type_name -> controlplane.ServiceApp
+	10, // 4: controlplane.CreateServiceRequest.fields:type_name -> controlplane.ServiceNodeField
 	1,  // 5: controlplane.LogsResponse.status:type_name -> controlplane.ServiceStatus
-	11, // 6: controlplane.ServiceNode.app:type_name -> controlplane.ServiceApp
+	13, // 6: controlplane.ServiceNode.app:type_name -> controlplane.ServiceApp
 	0,  // 7: controlplane.ServiceNode.type:type_name -> controlplane.ServiceType
 	1,  // 8: controlplane.ServiceNode.status:type_name -> controlplane.ServiceStatus
-	8,  // 9: controlplane.ServiceNode.fields:type_name -> controlplane.ServiceNodeField
-	14, // 10: controlplane.ServiceNode.nodes:type_name -> controlplane.ServiceNode
-	9,  // 11: controlplane.ServiceNode.ports:type_name -> controlplane.ServicePort
-	10, // 12: controlplane.ServiceNode.container:type_name -> controlplane.ServiceContainer
-	16, // 13: controlplane.ServiceNodesResponse.status:type_name -> common.StatusCode
-	14, // 14: controlplane.ServiceNodesResponse.services:type_name -> controlplane.ServiceNode
-	17, // 15: controlplane.ServiceNodesResponse.errors:type_name -> common.ValidationError
-	5,  // 16: controlplane.ServicesService.All:input_type -> controlplane.QueryServices
-	4,  // 17: controlplane.ServicesService.One:input_type -> controlplane.QueryId
-	12, // 18: controlplane.ServicesService.Create:input_type -> controlplane.CreateServiceRequest
-	12, // 19: controlplane.ServicesService.Update:input_type -> controlplane.CreateServiceRequest
-	4,  // 20: controlplane.ServicesService.Delete:input_type -> controlplane.QueryId
-	6,  // 21: controlplane.ServicesService.TakeActions:input_type -> controlplane.QueryTakeActions
-	7,  // 22: controlplane.ServicesService.GetLogs:input_type -> controlplane.QueryLog
-	15, // 23: controlplane.ServicesService.All:output_type -> controlplane.ServiceNodesResponse
-	14, // 24: controlplane.ServicesService.One:output_type -> controlplane.ServiceNode
-	14, // 25: controlplane.ServicesService.Create:output_type -> controlplane.ServiceNode
-	14, // 26: controlplane.ServicesService.Update:output_type -> controlplane.ServiceNode
-	18, // 27: controlplane.ServicesService.Delete:output_type -> common.MessageResponse
-	15, // 28: controlplane.ServicesService.TakeActions:output_type -> controlplane.ServiceNodesResponse
-	13, // 29: controlplane.ServicesService.GetLogs:output_type -> controlplane.LogsResponse
-	23, // [23:30] is the sub-list for method output_type
-	16, // [16:23] is the sub-list for method input_type
-	16, // [16:16] is the sub-list for extension type_name
-	16, // [16:16] is the sub-list for extension extendee
-	0,  // [0:16] is the sub-list for field type_name
+	10, // 9: controlplane.ServiceNode.fields:type_name -> controlplane.ServiceNodeField
+	17, // 10: controlplane.ServiceNode.nodes:type_name -> controlplane.ServiceNode
+	11, // 11: controlplane.ServiceNode.ports:type_name -> controlplane.ServicePort
+	12, // 12: controlplane.ServiceNode.container:type_name -> controlplane.ServiceContainer
+	18, // 13: controlplane.NodeType.fields:type_name -> controlplane.NodeTypeField
+	19, // 14: controlplane.NodeType.imports:type_name -> controlplane.NodeTypePort
+	19, // 15: controlplane.NodeType.exports:type_name -> controlplane.NodeTypePort
+	31, // 16: controlplane.NodeTypesResponse.status:type_name -> common.StatusCode
+	20, // 17: controlplane.NodeTypesResponse.node_types:type_name -> controlplane.NodeType
+	24, // 18: controlplane.ResolvedImport.upstreams:type_name -> controlplane.ServiceEndpoint
+	31, // 19: controlplane.TopologyResponse.status:type_name -> common.StatusCode
+	23, // 20: controlplane.TopologyResponse.edges:type_name -> controlplane.ServiceEdge
+	25, // 21: controlplane.TopologyResponse.imports:type_name -> controlplane.ResolvedImport
+	32, // 22: controlplane.TopologyResponse.errors:type_name -> common.ValidationError
+	4,  // 23: controlplane.DeploymentStep.status:type_name -> controlplane.DeploymentStatus
+	4,  // 24: controlplane.Deployment.status:type_name -> controlplane.DeploymentStatus
+	27, // 25: controlplane.Deployment.steps:type_name -> controlplane.DeploymentStep
+	31, // 26: controlplane.DeploymentResponse.status:type_name -> common.StatusCode
+	28, // 27: controlplane.DeploymentResponse.deployment:type_name -> controlplane.Deployment
+	32, // 28: controlplane.DeploymentResponse.errors:type_name -> common.ValidationError
+	31, // 29: controlplane.ServiceNodesResponse.status:type_name -> common.StatusCode
+	17, // 30: controlplane.ServiceNodesResponse.services:type_name -> controlplane.ServiceNode
+	32, // 31: controlplane.ServiceNodesResponse.errors:type_name -> common.ValidationError
+	6,  // 32: controlplane.ServicesService.All:input_type -> controlplane.QueryServices
+	5,  // 33: controlplane.ServicesService.One:input_type -> controlplane.QueryId
+	14, // 34: controlplane.ServicesService.Create:input_type -> controlplane.CreateServiceRequest
+	14, // 35: controlplane.ServicesService.Update:input_type -> controlplane.CreateServiceRequest
+	5,  // 36: controlplane.ServicesService.Delete:input_type -> controlplane.QueryId
+	7,  // 37: controlplane.ServicesService.TakeActions:input_type -> controlplane.QueryTakeActions
+	9,  // 38: controlplane.ServicesService.GetLogs:input_type -> controlplane.QueryLog
+	9,  // 39: controlplane.ServicesService.FollowLogs:input_type -> controlplane.QueryLog
+	33, // 40: controlplane.ServicesService.NodeTypes:input_type -> common.Empty
+	22, // 41: controlplane.ServicesService.Connect:input_type -> controlplane.ConnectRequest
+	22, // 42: controlplane.ServicesService.Disconnect:input_type -> controlplane.ConnectRequest
+	5,  // 43: controlplane.ServicesService.Topology:input_type -> controlplane.QueryId
+	5,  // 44: controlplane.ServicesService.Deploy:input_type -> controlplane.QueryId
+	8,  // 45: controlplane.ServicesService.GetDeployment:input_type -> controlplane.QueryDeployment
+	8,  // 46: controlplane.ServicesService.CancelDeployment:input_type -> controlplane.QueryDeployment
+	30, // 47: controlplane.ServicesService.All:output_type -> controlplane.ServiceNodesResponse
+	17, // 48: controlplane.ServicesService.One:output_type -> controlplane.ServiceNode
+	17, // 49: controlplane.ServicesService.Create:output_type -> controlplane.ServiceNode
+	17, // 50: controlplane.ServicesService.Update:output_type -> controlplane.ServiceNode
+	34, // 51: controlplane.ServicesService.Delete:output_type -> common.MessageResponse
+	30, // 52: controlplane.ServicesService.TakeActions:output_type -> controlplane.ServiceNodesResponse
+	15, // 53: controlplane.ServicesService.GetLogs:output_type -> controlplane.LogsResponse
+	16, // 54: controlplane.ServicesService.FollowLogs:output_type -> controlplane.ServiceLogLine
+	21, // 55: controlplane.ServicesService.NodeTypes:output_type -> controlplane.NodeTypesResponse
+	34, // 56: controlplane.ServicesService.Connect:output_type -> common.MessageResponse
+	34, // 57: controlplane.ServicesService.Disconnect:output_type -> common.MessageResponse
+	26, // 58: controlplane.ServicesService.Topology:output_type -> controlplane.TopologyResponse
+	29, // 59: controlplane.ServicesService.Deploy:output_type -> controlplane.DeploymentResponse
+	29, // 60: controlplane.ServicesService.GetDeployment:output_type -> controlplane.DeploymentResponse
+	34, // 61: controlplane.ServicesService.CancelDeployment:output_type -> common.MessageResponse
+	47, // [47:62] is the sub-list for method output_type
+	32, // [32:47] is the sub-list for method input_type
+	32, // [32:32] is the sub-list for extension type_name
+	32, // [32:32] is the sub-list for extension extendee
+	0,  // [0:32] is the sub-list for field type_name
 }
 
 func init() { file_controlplane_services_proto_init() }
@@ -1275,15 +2554,17 @@ func file_controlplane_services_proto_init() {
 	if File_controlplane_services_proto != nil {
 		return
 	}
-	file_controlplane_services_proto_msgTypes[8].OneofWrappers = []any{}
-	file_controlplane_services_proto_msgTypes[11].OneofWrappers = []any{}
+	file_controlplane_services_proto_msgTypes[9].OneofWrappers = []any{}
+	file_controlplane_services_proto_msgTypes[21].OneofWrappers = []any{}
+	file_controlplane_services_proto_msgTypes[24].OneofWrappers = []any{}
+	file_controlplane_services_proto_msgTypes[25].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_controlplane_services_proto_rawDesc), len(file_controlplane_services_proto_rawDesc)),
-			NumEnums:      4,
-			NumMessages:   12,
+			NumEnums:      5,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,13 +20,21 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ServicesService_All_FullMethodName         = "/controlplane.ServicesService/All"
-	ServicesService_One_FullMethodName         = "/controlplane.ServicesService/One"
-	ServicesService_Create_FullMethodName      = "/controlplane.ServicesService/Create"
-	ServicesService_Update_FullMethodName      = "/controlplane.ServicesService/Update"
-	ServicesService_Delete_FullMethodName      = "/controlplane.ServicesService/Delete"
-	ServicesService_TakeActions_FullMethodName = "/controlplane.ServicesService/TakeActions"
-	ServicesService_GetLogs_FullMethodName     = "/controlplane.ServicesService/GetLogs"
+	ServicesService_All_FullMethodName              = "/controlplane.ServicesService/All"
+	ServicesService_One_FullMethodName              = "/controlplane.ServicesService/One"
+	ServicesService_Create_FullMethodName           = "/controlplane.ServicesService/Create"
+	ServicesService_Update_FullMethodName           = "/controlplane.ServicesService/Update"
+	ServicesService_Delete_FullMethodName           = "/controlplane.ServicesService/Delete"
+	ServicesService_TakeActions_FullMethodName      = "/controlplane.ServicesService/TakeActions"
+	ServicesService_GetLogs_FullMethodName          = "/controlplane.ServicesService/GetLogs"
+	ServicesService_FollowLogs_FullMethodName       = "/controlplane.ServicesService/FollowLogs"
+	ServicesService_NodeTypes_FullMethodName        = "/controlplane.ServicesService/NodeTypes"
+	ServicesService_Connect_FullMethodName          = "/controlplane.ServicesService/Connect"
+	ServicesService_Disconnect_FullMethodName       = "/controlplane.ServicesService/Disconnect"
+	ServicesService_Topology_FullMethodName         = "/controlplane.ServicesService/Topology"
+	ServicesService_Deploy_FullMethodName           = "/controlplane.ServicesService/Deploy"
+	ServicesService_GetDeployment_FullMethodName    = "/controlplane.ServicesService/GetDeployment"
+	ServicesService_CancelDeployment_FullMethodName = "/controlplane.ServicesService/CancelDeployment"
 )
 
 // ServicesServiceClient is the client API for ServicesService service.
@@ -40,6 +48,15 @@ type ServicesServiceClient interface {
 	Delete(ctx context.Context, in *QueryId, opts ...grpc.CallOption) (*common.MessageResponse, error)
 	TakeActions(ctx context.Context, in *QueryTakeActions, opts ...grpc.CallOption) (*ServiceNodesResponse, error)
 	GetLogs(ctx context.Context, in *QueryLog, opts ...grpc.CallOption) (*LogsResponse, error)
+	// Streams the last limit lines of the container logs of a service, then the lines written after them
+	FollowLogs(ctx context.Context, in *QueryLog, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ServiceLogLine], error)
+	NodeTypes(ctx context.Context, in *common.Empty, opts ...grpc.CallOption) (*NodeTypesResponse, error)
+	Connect(ctx context.Context, in *ConnectRequest, opts ...grpc.CallOption) (*common.MessageResponse, error)
+	Disconnect(ctx context.Context, in *ConnectRequest, opts ...grpc.CallOption) (*common.MessageResponse, error)
+	Topology(ctx context.Context, in *QueryId, opts ...grpc.CallOption) (*TopologyResponse, error)
+	Deploy(ctx context.Context, in *QueryId, opts ...grpc.CallOption) (*DeploymentResponse, error)
+	GetDeployment(ctx context.Context, in *QueryDeployment, opts ...grpc.CallOption) (*DeploymentResponse, error)
+	CancelDeployment(ctx context.Context, in *QueryDeployment, opts ...grpc.CallOption) (*common.MessageResponse, error)
 }
 
 type servicesServiceClient struct {
@@ -120,6 +137,95 @@ func (c *servicesServiceClient) GetLogs(ctx context.Context, in *QueryLog, opts
 	return out, nil
 }
 
+func (c *servicesServiceClient) FollowLogs(ctx context.Context, in *QueryLog, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ServiceLogLine], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ServicesService_ServiceDesc.Streams[0], ServicesService_FollowLogs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[QueryLog, ServiceLogLine]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ServicesService_FollowLogsClient = grpc.ServerStreamingClient[ServiceLogLine]
+
+func (c *servicesServiceClient) NodeTypes(ctx context.Context, in *common.Empty, opts ...grpc.CallOption) (*NodeTypesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NodeTypesResponse)
+	err := c.cc.Invoke(ctx, ServicesService_NodeTypes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *servicesServiceClient) Connect(ctx context.Context, in *ConnectRequest, opts ...grpc.CallOption) (*common.MessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(common.MessageResponse)
+	err := c.cc.Invoke(ctx, ServicesService_Connect_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *servicesServiceClient) Disconnect(ctx context.Context, in *ConnectRequest, opts ...grpc.CallOption) (*common.MessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(common.MessageResponse)
+	err := c.cc.Invoke(ctx, ServicesService_Disconnect_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *servicesServiceClient) Topology(ctx context.Context, in *QueryId, opts ...grpc.CallOption) (*TopologyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TopologyResponse)
+	err := c.cc.Invoke(ctx, ServicesService_Topology_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *servicesServiceClient) Deploy(ctx context.Context, in *QueryId, opts ...grpc.CallOption) (*DeploymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeploymentResponse)
+	err := c.cc.Invoke(ctx, ServicesService_Deploy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *servicesServiceClient) GetDeployment(ctx context.Context, in *QueryDeployment, opts ...grpc.CallOption) (*DeploymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeploymentResponse)
+	err := c.cc.Invoke(ctx, ServicesService_GetDeployment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *servicesServiceClient) CancelDeployment(ctx context.Context, in *QueryDeployment, opts ...grpc.CallOption) (*common.MessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(common.MessageResponse)
+	err := c.cc.Invoke(ctx, ServicesService_CancelDeployment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ServicesServiceServer is the server API for ServicesService service.
 // All implementations must embed UnimplementedServicesServiceServer
 // for forward compatibility.
@@ -131,6 +237,15 @@ type ServicesServiceServer interface {
 	Delete(context.Context, *QueryId) (*common.MessageResponse, error)
 	TakeActions(context.Context, *QueryTakeActions) (*ServiceNodesResponse, error)
 	GetLogs(context.Context, *QueryLog) (*LogsResponse, error)
+	// Streams the last limit lines of the container logs of a service, then the lines written after them
+	FollowLogs(*QueryLog, grpc.ServerStreamingServer[ServiceLogLine]) error
+	NodeTypes(context.Context, *common.Empty) (*NodeTypesResponse, error)
+	Connect(context.Context, *ConnectRequest) (*common.MessageResponse, error)
+	Disconnect(context.Context, *ConnectRequest) (*common.MessageResponse, error)
+	Topology(context.Context, *QueryId) (*TopologyResponse, error)
+	Deploy(context.Context, *QueryId) (*DeploymentResponse, error)
+	GetDeployment(context.Context, *QueryDeployment) (*DeploymentResponse, error)
+	CancelDeployment(context.Context, *QueryDeployment) (*common.MessageResponse, error)
 	mustEmbedUnimplementedServicesServiceServer()
 }
 
@@ -162,6 +277,30 @@ func (UnimplementedServicesServiceServer) TakeActions(context.Context, *QueryTak
 func (UnimplementedServicesServiceServer) GetLogs(context.Context, *QueryLog) (*LogsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetLogs not implemented")
 }
+func (UnimplementedServicesServiceServer) FollowLogs(*QueryLog, grpc.ServerStreamingServer[ServiceLogLine]) error {
+	return status.Errorf(codes.Unimplemented, "method FollowLogs not implemented")
+}
+func (UnimplementedServicesServiceServer) NodeTypes(context.Context, *common.Empty) (*NodeTypesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NodeTypes not implemented")
+}
+func (UnimplementedServicesServiceServer) Connect(context.Context, *ConnectRequest) (*common.MessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Connect not implemented")
+}
+func (UnimplementedServicesServiceServer) Disconnect(context.Context, *ConnectRequest) (*common.MessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Disconnect not implemented")
+}
+func (UnimplementedServicesServiceServer) Topology(context.Context, *QueryId) (*TopologyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Topology not implemented")
+}
+func (UnimplementedServicesServiceServer) Deploy(context.Context, *QueryId) (*DeploymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Deploy not implemented")
+}
+func (UnimplementedServicesServiceServer) GetDeployment(context.Context, *QueryDeployment) (*DeploymentResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDeployment not implemented")
+}
+func (UnimplementedServicesServiceServer) CancelDeployment(context.Context, *QueryDeployment) (*common.MessageResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelDeployment not implemented")
+}
 func (UnimplementedServicesServiceServer) mustEmbedUnimplementedServicesServiceServer() {}
 func (UnimplementedServicesServiceServer) testEmbeddedByValue()                         {}
 
@@ -309,6 +448,143 @@ func _ServicesService_GetLogs_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ServicesService_FollowLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(QueryLog)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ServicesServiceServer).FollowLogs(m, &grpc.GenericServerStream[QueryLog, ServiceLogLine]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ServicesService_FollowLogsServer = grpc.ServerStreamingServer[ServiceLogLine]
+
+func _ServicesService_NodeTypes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(common.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServicesServiceServer).NodeTypes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServicesService_NodeTypes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServicesServiceServer).NodeTypes(ctx, req.(*common.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServicesService_Connect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConnectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServicesServiceServer).Connect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServicesService_Connect_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServicesServiceServer).Connect(ctx, req.(*ConnectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServicesService_Disconnect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConnectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServicesServiceServer).Disconnect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServicesService_Disconnect_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServicesServiceServer).Disconnect(ctx, req.(*ConnectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServicesService_Topology_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServicesServiceServer).Topology(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServicesService_Topology_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServicesServiceServer).Topology(ctx, req.(*QueryId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServicesService_Deploy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryId)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServicesServiceServer).Deploy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServicesService_Deploy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServicesServiceServer).Deploy(ctx, req.(*QueryId))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServicesService_GetDeployment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryDeployment)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServicesServiceServer).GetDeployment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServicesService_GetDeployment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServicesServiceServer).GetDeployment(ctx, req.(*QueryDeployment))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ServicesService_CancelDeployment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryDeployment)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ServicesServiceServer).CancelDeployment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ServicesService_CancelDeployment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ServicesServiceServer).CancelDeployment(ctx, req.(*QueryDeployment))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ServicesService_ServiceDesc is the grpc.ServiceDesc for ServicesService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -344,7 +620,41 @@ var ServicesService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetLogs",
 			Handler:    _ServicesService_GetLogs_Handler,
 		},
+		{
+			MethodName: "NodeTypes",
+			Handler:    _ServicesService_NodeTypes_Handler,
+		},
+		{
+			MethodName: "Connect",
+			Handler:    _ServicesService_Connect_Handler,
+		},
+		{
+			MethodName: "Disconnect",
+			Handler:    _ServicesService_Disconnect_Handler,
+		},
+		{
+			MethodName: "Topology",
+			Handler:    _ServicesService_Topology_Handler,
+		},
+		{
+			MethodName: "Deploy",
+			Handler:    _ServicesService_Deploy_Handler,
+		},
+		{
+			MethodName: "GetDeployment",
+			Handler:    _ServicesService_GetDeployment_Handler,
+		},
+		{
+			MethodName: "CancelDeployment",
+			Handler:    _ServicesService_CancelDeployment_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "FollowLogs",
+			Handler:       _ServicesService_FollowLogs_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "controlplane/services.proto",
 }
@@ -0,0 +1,893 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v3.12.4
+// source: controlplane/workflow.proto
+
+package controlplane
+
+import (
+	common "github.com/zhinea/sylix/internal/infra/proto/common"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type WorkflowParam struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Required      bool                   `protobuf:"varint,3,opt,name=required,proto3" json:"required,omitempty"`
+	Default       string                 `protobuf:"bytes,4,opt,name=default,proto3" json:"default,omitempty"`
+	Secret        bool                   `protobuf:"varint,5,opt,name=secret,proto3" json:"secret,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkflowParam) Reset() {
+	*x = WorkflowParam{}
+	mi := &file_controlplane_workflow_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkflowParam) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowParam) ProtoMessage() {}
+
+func (x *WorkflowParam) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_workflow_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowParam.ProtoReflect.Descriptor instead.
+func (*WorkflowParam) Descriptor() ([]byte, []int) {
+	return file_controlplane_workflow_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WorkflowParam) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WorkflowParam) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *WorkflowParam) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+func (x *WorkflowParam) GetDefault() string {
+	if x != nil {
+		return x.Default
+	}
+	return ""
+}
+
+func (x *WorkflowParam) GetSecret() bool {
+	if x != nil {
+		return x.Secret
+	}
+	return false
+}
+
+type WorkflowStepDefinition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Action        string                 `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	DependsOn     []string               `protobuf:"bytes,4,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
+	Hosts         []string               `protobuf:"bytes,5,rep,name=hosts,proto3" json:"hosts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkflowStepDefinition) Reset() {
+	*x = WorkflowStepDefinition{}
+	mi := &file_controlplane_workflow_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkflowStepDefinition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowStepDefinition) ProtoMessage() {}
+
+func (x *WorkflowStepDefinition) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_workflow_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowStepDefinition.ProtoReflect.Descriptor instead.
+func (*WorkflowStepDefinition) Descriptor() ([]byte, []int) {
+	return file_controlplane_workflow_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WorkflowStepDefinition) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WorkflowStepDefinition) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WorkflowStepDefinition) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *WorkflowStepDefinition) GetDependsOn() []string {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+func (x *WorkflowStepDefinition) GetHosts() []string {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+type WorkflowDefinition struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Name          string                    `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                    `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Params        []*WorkflowParam          `protobuf:"bytes,3,rep,name=params,proto3" json:"params,omitempty"`
+	Hosts         []string                  `protobuf:"bytes,4,rep,name=hosts,proto3" json:"hosts,omitempty"` // hosts the steps run on besides the server, each given a server by a run
+	Steps         []*WorkflowStepDefinition `protobuf:"bytes,5,rep,name=steps,proto3" json:"steps,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkflowDefinition) Reset() {
+	*x = WorkflowDefinition{}
+	mi := &file_controlplane_workflow_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkflowDefinition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowDefinition) ProtoMessage() {}
+
+func (x *WorkflowDefinition) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_workflow_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowDefinition.ProtoReflect.Descriptor instead.
+func (*WorkflowDefinition) Descriptor() ([]byte, []int) {
+	return file_controlplane_workflow_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *WorkflowDefinition) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WorkflowDefinition) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *WorkflowDefinition) GetParams() []*WorkflowParam {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *WorkflowDefinition) GetHosts() []string {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+func (x *WorkflowDefinition) GetSteps() []*WorkflowStepDefinition {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+type WorkflowDefinitionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        common.StatusCode      `protobuf:"varint,1,opt,name=status,proto3,enum=common.StatusCode" json:"status,omitempty"`
+	Data          []*WorkflowDefinition  `protobuf:"bytes,2,rep,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkflowDefinitionsResponse) Reset() {
+	*x = WorkflowDefinitionsResponse{}
+	mi := &file_controlplane_workflow_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkflowDefinitionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowDefinitionsResponse) ProtoMessage() {}
+
+func (x *WorkflowDefinitionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_workflow_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowDefinitionsResponse.ProtoReflect.Descriptor instead.
+func (*WorkflowDefinitionsResponse) Descriptor() ([]byte, []int) {
+	return file_controlplane_workflow_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *WorkflowDefinitionsResponse) GetStatus() common.StatusCode {
+	if x != nil {
+		return x.Status
+	}
+	return common.StatusCode(0)
+}
+
+func (x *WorkflowDefinitionsResponse) GetData() []*WorkflowDefinition {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type RunWorkflowRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	ServerId      string                 `protobuf:"bytes,2,opt,name=server_id,json=serverId,proto3" json:"server_id,omitempty"`
+	Params        map[string]string      `protobuf:"bytes,3,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Hosts         map[string]string      `protobuf:"bytes,4,rep,name=hosts,proto3" json:"hosts,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // host name -> server id
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunWorkflowRequest) Reset() {
+	*x = RunWorkflowRequest{}
+	mi := &file_controlplane_workflow_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunWorkflowRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunWorkflowRequest) ProtoMessage() {}
+
+func (x *RunWorkflowRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_workflow_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunWorkflowRequest.ProtoReflect.Descriptor instead.
+func (*RunWorkflowRequest) Descriptor() ([]byte, []int) {
+	return file_controlplane_workflow_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RunWorkflowRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RunWorkflowRequest) GetServerId() string {
+	if x != nil {
+		return x.ServerId
+	}
+	return ""
+}
+
+func (x *RunWorkflowRequest) GetParams() map[string]string {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *RunWorkflowRequest) GetHosts() map[string]string {
+	if x != nil {
+		return x.Hosts
+	}
+	return nil
+}
+
+type RunWorkflowResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Status        common.StatusCode         `protobuf:"varint,1,opt,name=status,proto3,enum=common.StatusCode" json:"status,omitempty"`
+	RunId         string                    `protobuf:"bytes,2,opt,name=run_id,json=runId,proto3" json:"run_id,omitempty"`
+	LogFile       string                    `protobuf:"bytes,3,opt,name=log_file,json=logFile,proto3" json:"log_file,omitempty"` // file of the server's logs the output goes to
+	Errors        []*common.ValidationError `protobuf:"bytes,4,rep,name=errors,proto3" json:"errors,omitempty"`
+	Error         *string                   `protobuf:"bytes,5,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RunWorkflowResponse) Reset() {
+	*x = RunWorkflowResponse{}
+	mi := &file_controlplane_workflow_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RunWorkflowResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunWorkflowResponse) ProtoMessage() {}
+
+func (x *RunWorkflowResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_workflow_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunWorkflowResponse.ProtoReflect.Descriptor instead.
+func (*RunWorkflowResponse) Descriptor() ([]byte, []int) {
+	return file_controlplane_workflow_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RunWorkflowResponse) GetStatus() common.StatusCode {
+	if x != nil {
+		return x.Status
+	}
+	return common.StatusCode(0)
+}
+
+func (x *RunWorkflowResponse) GetRunId() string {
+	if x != nil {
+		return x.RunId
+	}
+	return ""
+}
+
+func (x *RunWorkflowResponse) GetLogFile() string {
+	if x != nil {
+		return x.LogFile
+	}
+	return ""
+}
+
+func (x *RunWorkflowResponse) GetErrors() []*common.ValidationError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *RunWorkflowResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+type PlannedStep struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         int32                  `protobuf:"varint,1,opt,name=index,proto3" json:"index,omitempty"` // position of the step in the workflow
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Host          string                 `protobuf:"bytes,3,opt,name=host,proto3" json:"host,omitempty"`
+	Stage         string                 `protobuf:"bytes,4,opt,name=stage,proto3" json:"stage,omitempty"`
+	Action        string                 `protobuf:"bytes,5,opt,name=action,proto3" json:"action,omitempty"`
+	DependsOn     []string               `protobuf:"bytes,6,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
+	Decision      string                 `protobuf:"bytes,7,opt,name=decision,proto3" json:"decision,omitempty"` // run, skip (condition does not hold) or unknown (condition uses outputs of earlier steps)
+	Condition     string                 `protobuf:"bytes,8,opt,name=condition,proto3" json:"condition,omitempty"`
+	Command       string                 `protobuf:"bytes,9,opt,name=command,proto3" json:"command,omitempty"`
+	SourcePath    string                 `protobuf:"bytes,10,opt,name=source_path,json=sourcePath,proto3" json:"source_path,omitempty"`
+	DestPath      string                 `protobuf:"bytes,11,opt,name=dest_path,json=destPath,proto3" json:"dest_path,omitempty"`
+	Content       string                 `protobuf:"bytes,12,opt,name=content,proto3" json:"content,omitempty"` // secrets redacted as ***
+	Mode          string                 `protobuf:"bytes,13,opt,name=mode,proto3" json:"mode,omitempty"`       // octal permissions of written files
+	Detail        string                 `protobuf:"bytes,14,opt,name=detail,proto3" json:"detail,omitempty"`
+	Rollback      *PlannedStep           `protobuf:"bytes,15,opt,name=rollback,proto3" json:"rollback,omitempty"`
+	Error         string                 `protobuf:"bytes,16,opt,name=error,proto3" json:"error,omitempty"` // why the step would fail before doing anything
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlannedStep) Reset() {
+	*x = PlannedStep{}
+	mi := &file_controlplane_workflow_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlannedStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlannedStep) ProtoMessage() {}
+
+func (x *PlannedStep) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_workflow_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlannedStep.ProtoReflect.Descriptor instead.
+func (*PlannedStep) Descriptor() ([]byte, []int) {
+	return file_controlplane_workflow_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *PlannedStep) GetIndex() int32 {
+	if x != nil {
+		return x.Index
+	}
+	return 0
+}
+
+func (x *PlannedStep) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PlannedStep) GetHost() string {
+	if x != nil {
+		return x.Host
+	}
+	return ""
+}
+
+func (x *PlannedStep) GetStage() string {
+	if x != nil {
+		return x.Stage
+	}
+	return ""
+}
+
+func (x *PlannedStep) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *PlannedStep) GetDependsOn() []string {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+func (x *PlannedStep) GetDecision() string {
+	if x != nil {
+		return x.Decision
+	}
+	return ""
+}
+
+func (x *PlannedStep) GetCondition() string {
+	if x != nil {
+		return x.Condition
+	}
+	return ""
+}
+
+func (x *PlannedStep) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *PlannedStep) GetSourcePath() string {
+	if x != nil {
+		return x.SourcePath
+	}
+	return ""
+}
+
+func (x *PlannedStep) GetDestPath() string {
+	if x != nil {
+		return x.DestPath
+	}
+	return ""
+}
+
+func (x *PlannedStep) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *PlannedStep) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *PlannedStep) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+func (x *PlannedStep) GetRollback() *PlannedStep {
+	if x != nil {
+		return x.Rollback
+	}
+	return nil
+}
+
+func (x *PlannedStep) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type WorkflowPlan struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Steps         []*PlannedStep         `protobuf:"bytes,2,rep,name=steps,proto3" json:"steps,omitempty"` // in the order they would start
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkflowPlan) Reset() {
+	*x = WorkflowPlan{}
+	mi := &file_controlplane_workflow_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkflowPlan) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowPlan) ProtoMessage() {}
+
+func (x *WorkflowPlan) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_workflow_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowPlan.ProtoReflect.Descriptor instead.
+func (*WorkflowPlan) Descriptor() ([]byte, []int) {
+	return file_controlplane_workflow_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *WorkflowPlan) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WorkflowPlan) GetSteps() []*PlannedStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+type WorkflowPlanResponse struct {
+	state         protoimpl.MessageState    `protogen:"open.v1"`
+	Status        common.StatusCode         `protobuf:"varint,1,opt,name=status,proto3,enum=common.StatusCode" json:"status,omitempty"`
+	Plan          *WorkflowPlan             `protobuf:"bytes,2,opt,name=plan,proto3" json:"plan,omitempty"`
+	Errors        []*common.ValidationError `protobuf:"bytes,3,rep,name=errors,proto3" json:"errors,omitempty"`
+	Error         *string                   `protobuf:"bytes,4,opt,name=error,proto3,oneof" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WorkflowPlanResponse) Reset() {
+	*x = WorkflowPlanResponse{}
+	mi := &file_controlplane_workflow_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WorkflowPlanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowPlanResponse) ProtoMessage() {}
+
+func (x *WorkflowPlanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_controlplane_workflow_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowPlanResponse.ProtoReflect.Descriptor instead.
+func (*WorkflowPlanResponse) Descriptor() ([]byte, []int) {
+	return file_controlplane_workflow_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WorkflowPlanResponse) GetStatus() common.StatusCode {
+	if x != nil {
+		return x.Status
+	}
+	return common.StatusCode(0)
+}
+
+func (x *WorkflowPlanResponse) GetPlan() *WorkflowPlan {
+	if x != nil {
+		return x.Plan
+	}
+	return nil
+}
+
+func (x *WorkflowPlanResponse) GetErrors() []*common.ValidationError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *WorkflowPlanResponse) GetError() string {
+	if x != nil && x.Error != nil {
+		return *x.Error
+	}
+	return ""
+}
+
+var File_controlplane_workflow_proto protoreflect.FileDescriptor
+
+const file_controlplane_workflow_proto_rawDesc = "" +
+	"\n" +
+	"\x1bcontrolplane/workflow.proto\x12\fcontrolplane\x1a\x17common/validation.proto\x1a\x13common/common.proto\"\x93\x01\n" +
+	"\rWorkflowParam\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1a\n" +
+	"\brequired\x18\x03 \x01(\bR\brequired\x12\x18\n" +
+	"\adefault\x18\x04 \x01(\tR\adefault\x12\x16\n" +
+	"\x06secret\x18\x05 \x01(\bR\x06secret\"\x89\x01\n" +
+	"\x16WorkflowStepDefinition\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x1d\n" +
+	"\n" +
+	"depends_on\x18\x04 \x03(\tR\tdependsOn\x12\x14\n" +
+	"\x05hosts\x18\x05 \x03(\tR\x05hosts\"\xd1\x01\n" +
+	"\x12WorkflowDefinition\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x123\n" +
+	"\x06params\x18\x03 \x03(\v2\x1b.controlplane.WorkflowParamR\x06params\x12\x14\n" +
+	"\x05hosts\x18\x04 \x03(\tR\x05hosts\x12:\n" +
+	"\x05steps\x18\x05 \x03(\v2$.controlplane.WorkflowStepDefinitionR\x05steps\"\x7f\n" +
+	"\x1bWorkflowDefinitionsResponse\x12*\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x12.common.StatusCodeR\x06status\x124\n" +
+	"\x04data\x18\x02 \x03(\v2 .controlplane.WorkflowDefinitionR\x04data\"\xc3\x02\n" +
+	"\x12RunWorkflowRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1b\n" +
+	"\tserver_id\x18\x02 \x01(\tR\bserverId\x12D\n" +
+	"\x06params\x18\x03 \x03(\v2,.controlplane.RunWorkflowRequest.ParamsEntryR\x06params\x12A\n" +
+	"\x05hosts\x18\x04 \x03(\v2+.controlplane.RunWorkflowRequest.HostsEntryR\x05hosts\x1a9\n" +
+	"\vParamsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a8\n" +
+	"\n" +
+	"HostsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc9\x01\n" +
+	"\x13RunWorkflowResponse\x12*\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x12.common.StatusCodeR\x06status\x12\x15\n" +
+	"\x06run_id\x18\x02 \x01(\tR\x05runId\x12\x19\n" +
+	"\blog_file\x18\x03 \x01(\tR\alogFile\x12/\n" +
+	"\x06errors\x18\x04 \x03(\v2\x17.common.ValidationErrorR\x06errors\x12\x19\n" +
+	"\x05error\x18\x05 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
+	"\x06_error\"\xbd\x03\n" +
+	"\vPlannedStep\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\x05R\x05index\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04host\x18\x03 \x01(\tR\x04host\x12\x14\n" +
+	"\x05stage\x18\x04 \x01(\tR\x05stage\x12\x16\n" +
+	"\x06action\x18\x05 \x01(\tR\x06action\x12\x1d\n" +
+	"\n" +
+	"depends_on\x18\x06 \x03(\tR\tdependsOn\x12\x1a\n" +
+	"\bdecision\x18\a \x01(\tR\bdecision\x12\x1c\n" +
+	"\tcondition\x18\b \x01(\tR\tcondition\x12\x18\n" +
+	"\acommand\x18\t \x01(\tR\acommand\x12\x1f\n" +
+	"\vsource_path\x18\n" +
+	" \x01(\tR\n" +
+	"sourcePath\x12\x1b\n" +
+	"\tdest_path\x18\v \x01(\tR\bdestPath\x12\x18\n" +
+	"\acontent\x18\f \x01(\tR\acontent\x12\x12\n" +
+	"\x04mode\x18\r \x01(\tR\x04mode\x12\x16\n" +
+	"\x06detail\x18\x0e \x01(\tR\x06detail\x125\n" +
+	"\brollback\x18\x0f \x01(\v2\x19.controlplane.PlannedStepR\brollback\x12\x14\n" +
+	"\x05error\x18\x10 \x01(\tR\x05error\"S\n" +
+	"\fWorkflowPlan\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12/\n" +
+	"\x05steps\x18\x02 \x03(\v2\x19.controlplane.PlannedStepR\x05steps\"\xc8\x01\n" +
+	"\x14WorkflowPlanResponse\x12*\n" +
+	"\x06status\x18\x01 \x01(\x0e2\x12.common.StatusCodeR\x06status\x12.\n" +
+	"\x04plan\x18\x02 \x01(\v2\x1a.controlplane.WorkflowPlanR\x04plan\x12/\n" +
+	"\x06errors\x18\x03 \x03(\v2\x17.common.ValidationErrorR\x06errors\x12\x19\n" +
+	"\x05error\x18\x04 \x01(\tH\x00R\x05error\x88\x01\x01B\b\n" +
+	"\x06_error2\xec\x01\n" +
+	"\x0fWorkflowService\x12?\n" +
+	"\x03All\x12\r.common.Empty\x1a).controlplane.WorkflowDefinitionsResponse\x12J\n" +
+	"\x03Run\x12 .controlplane.RunWorkflowRequest\x1a!.controlplane.RunWorkflowResponse\x12L\n" +
+	"\x04Plan\x12 .controlplane.RunWorkflowRequest\x1a\".controlplane.WorkflowPlanResponseB;Z9github.com/zhinea/sylix/internal/infra/proto/controlplaneb\x06proto3"
+
+var (
+	file_controlplane_workflow_proto_rawDescOnce sync.Once
+	file_controlplane_workflow_proto_rawDescData []byte
+)
+
+func file_controlplane_workflow_proto_rawDescGZIP() []byte {
+	file_controlplane_workflow_proto_rawDescOnce.Do(func() {
+		file_controlplane_workflow_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_controlplane_workflow_proto_rawDesc), len(file_controlplane_workflow_proto_rawDesc)))
+	})
+	return file_controlplane_workflow_proto_rawDescData
+}
+
+var file_controlplane_workflow_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_controlplane_workflow_proto_goTypes = []any{
+	(*WorkflowParam)(nil),               // 0: controlplane.WorkflowParam
+	(*WorkflowStepDefinition)(nil),      // 1: controlplane.WorkflowStepDefinition
+	(*WorkflowDefinition)(nil),          // 2: controlplane.WorkflowDefinition
+	(*WorkflowDefinitionsResponse)(nil), // 3: controlplane.WorkflowDefinitionsResponse
+	(*RunWorkflowRequest)(nil),          // 4: controlplane.RunWorkflowRequest
+	(*RunWorkflowResponse)(nil),         // 5: controlplane.RunWorkflowResponse
+	(*PlannedStep)(nil),                 // 6: controlplane.PlannedStep
+	(*WorkflowPlan)(nil),                // 7: controlplane.WorkflowPlan
+	(*WorkflowPlanResponse)(nil),        // 8: controlplane.WorkflowPlanResponse
+	nil,                                 // 9: controlplane.RunWorkflowRequest.ParamsEntry
+	nil,                                 // 10: controlplane.RunWorkflowRequest.HostsEntry
+	(common.StatusCode)(0),              // 11: common.StatusCode
+	(*common.ValidationError)(nil),      // 12: common.ValidationError
+	(*common.Empty)(nil),                // 13: common.Empty
+}
+var file_controlplane_workflow_proto_depIdxs = []int32{
+	0,  // 0: controlplane.WorkflowDefinition.params:type_name -> controlplane.WorkflowParam
+	1,  // 1: controlplane.WorkflowDefinition.steps:type_name -> controlplane.WorkflowStepDefinition
+	11, // 2: controlplane.WorkflowDefinitionsResponse.status:type_name -> common.StatusCode
+	2,  // 3: controlplane.WorkflowDefinitionsResponse.data:type_name -> controlplane.WorkflowDefinition
+	9,  // 4: controlplane.RunWorkflowRequest.params:type_name -> controlplane.RunWorkflowRequest.ParamsEntry
+	10, // 5: controlplane.RunWorkflowRequest.hosts:type_name -> controlplane.RunWorkflowRequest.HostsEntry
+	11, // 6: controlplane.RunWorkflowResponse.status:type_name -> common.StatusCode
+	12, // 7: controlplane.RunWorkflowResponse.errors:type_name -> common.ValidationError
+	6,  // 8: controlplane.PlannedStep.rollback:type_name -> controlplane.PlannedStep
+	6,  // 9: controlplane.WorkflowPlan.steps:type_name -> controlplane.PlannedStep
+	11, // 10: controlplane.WorkflowPlanResponse.status:type_name -> common.StatusCode
+	7,  // 11: controlplane.WorkflowPlanResponse.plan:type_name -> controlplane.WorkflowPlan
+	12, // 12: controlplane.WorkflowPlanResponse.errors:type_name -> common.ValidationError
+	13, // 13: controlplane.WorkflowService.All:input_type -> common.Empty
+	4,  // 14: controlplane.WorkflowService.Run:input_type -> controlplane.RunWorkflowRequest
+	4,  // 15: controlplane.WorkflowService.Plan:input_type -> controlplane.RunWorkflowRequest
+	3,  // 16: controlplane.WorkflowService.All:output_type -> controlplane.WorkflowDefinitionsResponse
+	5,  // 17: controlplane.WorkflowService.Run:output_type -> controlplane.RunWorkflowResponse
+	8,  // 18: controlplane.WorkflowService.Plan:output_type -> controlplane.WorkflowPlanResponse
+	16, // [16:19] is the sub-list for method output_type
+	13, // [13:16] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_controlplane_workflow_proto_init() }
+func file_controlplane_workflow_proto_init() {
+	if File_controlplane_workflow_proto != nil {
+		return
+	}
+	file_controlplane_workflow_proto_msgTypes[5].OneofWrappers = []any{}
+	file_controlplane_workflow_proto_msgTypes[8].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_controlplane_workflow_proto_rawDesc), len(file_controlplane_workflow_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_controlplane_workflow_proto_goTypes,
+		DependencyIndexes: file_controlplane_workflow_proto_depIdxs,
+		MessageInfos:      file_controlplane_workflow_proto_msgTypes,
+	}.Build()
+	File_controlplane_workflow_proto = out.File
+	file_controlplane_workflow_proto_goTypes = nil
+	file_controlplane_workflow_proto_depIdxs = nil
+}
@@ -0,0 +1,31 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+)
+
+// run executes the command, writing its output to stdout and stderr as it comes.
+func run(ctx context.Context, stdout, stderr io.Writer, name string, args ...string) error {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to run %s %s: %w", name, strings.Join(args, " "), err)
+	}
+	return nil
+}
+
+// output executes the command and returns its combined output, which is part of the error on failure.
+func output(ctx context.Context, name string, args ...string) (string, error) {
+	var out bytes.Buffer
+	if err := run(ctx, &out, &out, name, args...); err != nil {
+		return out.String(), fmt.Errorf("%w, output: %s", err, strings.TrimSpace(out.String()))
+	}
+	return out.String(), nil
+}
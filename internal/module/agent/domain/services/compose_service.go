@@ -0,0 +1,90 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// ProjectsDir is where compose projects are written, the same directory the controlplane uses when
+// it deploys over SSH.
+const ProjectsDir = "/opt/sylix/projects"
+
+var ErrInvalidProject = errors.New("invalid project name")
+
+var projectName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// ComposeService runs docker compose projects on this server.
+type ComposeService struct {
+	dir string
+}
+
+func NewComposeService(dir string) *ComposeService {
+	return &ComposeService{
+		dir: dir,
+	}
+}
+
+// Deploy writes the compose file of the project and starts it, streaming the output.
+func (s *ComposeService) Deploy(ctx context.Context, project string, compose []byte, stdout, stderr io.Writer) error {
+	if !projectName.MatchString(project) {
+		return fmt.Errorf("%w: %q", ErrInvalidProject, project)
+	}
+
+	dir := filepath.Join(s.dir, project)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create project directory: %w", err)
+	}
+	// the compose file carries credentials of the storage it uses
+	if err := os.WriteFile(s.file(project), compose, 0600); err != nil {
+		return fmt.Errorf("failed to write compose file: %w", err)
+	}
+
+	return run(ctx, stdout, stderr, "docker", s.args(project, "up", "-d", "--remove-orphans")...)
+}
+
+func (s *ComposeService) Stop(ctx context.Context, project string) (string, error) {
+	if !projectName.MatchString(project) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidProject, project)
+	}
+	return output(ctx, "docker", s.args(project, "stop")...)
+}
+
+// Remove takes the project down and deletes its directory. Named volumes are only removed when asked.
+func (s *ComposeService) Remove(ctx context.Context, project string, volumes bool) (string, error) {
+	if !projectName.MatchString(project) {
+		return "", fmt.Errorf("%w: %q", ErrInvalidProject, project)
+	}
+
+	args := s.args(project, "down", "--remove-orphans")
+	if volumes {
+		args = append(args, "-v")
+	}
+	out, err := output(ctx, "docker", args...)
+	if err != nil {
+		return out, err
+	}
+
+	if err := os.RemoveAll(filepath.Join(s.dir, project)); err != nil {
+		return out, fmt.Errorf("failed to remove project directory: %w", err)
+	}
+	return out, nil
+}
+
+func (s *ComposeService) file(project string) string {
+	return filepath.Join(s.dir, project, "docker-compose.yml")
+}
+
+// args builds a docker compose command line for the project. Without a compose file docker compose
+// still finds the containers of the project by its labels.
+func (s *ComposeService) args(project string, command ...string) []string {
+	args := []string{"compose", "-p", project}
+	if _, err := os.Stat(s.file(project)); err == nil {
+		args = append(args, "-f", s.file(project))
+	}
+	return append(args, command...)
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os/exec"
+	"strconv"
+	"time"
+)
+
+const defaultExecTimeout = 5 * time.Minute
+
+// LogsOptions selects the lines docker logs returns.
+type LogsOptions struct {
+	Container  string
+	Tail       int
+	Since      string
+	Until      string
+	Follow     bool
+	Timestamps bool
+}
+
+type ExecResult struct {
+	ExitCode int
+	Stdout   string
+	Stderr   string
+}
+
+// ContainerService reads logs of and runs commands in containers on this server.
+type ContainerService struct{}
+
+func NewContainerService() *ContainerService {
+	return &ContainerService{}
+}
+
+// Logs streams the logs of the container. With Follow it only returns once ctx is done or the
+// container stops.
+func (s *ContainerService) Logs(ctx context.Context, opts LogsOptions, stdout, stderr io.Writer) error {
+	args := []string{"logs"}
+	if opts.Tail > 0 {
+		args = append(args, "--tail", strconv.Itoa(opts.Tail))
+	}
+	if opts.Since != "" {
+		args = append(args, "--since", opts.Since)
+	}
+	if opts.Until != "" {
+		args = append(args, "--until", opts.Until)
+	}
+	if opts.Follow {
+		args = append(args, "--follow")
+	}
+	if opts.Timestamps {
+		args = append(args, "--timestamps")
+	}
+	args = append(args, opts.Container)
+
+	return run(ctx, stdout, stderr, "docker", args...)
+}
+
+// Exec runs the command in the container, or on the host when container is empty. A command that
+// exits non-zero is no error, its exit code is part of the result.
+func (s *ContainerService) Exec(ctx context.Context, container string, command []string, timeout time.Duration) (*ExecResult, error) {
+	if timeout <= 0 {
+		timeout = defaultExecTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	name, args := command[0], command[1:]
+	if container != "" {
+		name, args = "docker", append([]string{"exec", container}, command...)
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return nil, err
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	return &ExecResult{
+		ExitCode: cmd.ProcessState.ExitCode(),
+		Stdout:   stdout.String(),
+		Stderr:   stderr.String(),
+	}, nil
+}
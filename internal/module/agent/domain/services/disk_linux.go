@@ -0,0 +1,14 @@
+//go:build linux
+
+package services
+
+import "syscall"
+
+// diskUsage returns the total and available bytes of the filesystem holding path.
+func diskUsage(path string) (total, free uint64) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, 0
+	}
+	return stat.Blocks * uint64(stat.Bsize), stat.Bavail * uint64(stat.Bsize)
+}
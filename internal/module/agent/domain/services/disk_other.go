@@ -0,0 +1,8 @@
+//go:build !linux
+
+package services
+
+// diskUsage is only implemented on Linux, the agent's target platform.
+func diskUsage(path string) (total, free uint64) {
+	return 0, 0
+}
@@ -0,0 +1,107 @@
+package services
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type SystemInfo struct {
+	Hostname        string
+	OS              string
+	Kernel          string
+	Arch            string
+	CPUs            int
+	MemoryTotal     uint64
+	MemoryAvailable uint64
+	DiskTotal       uint64
+	DiskFree        uint64
+	Load1           float64
+	Load5           float64
+	Load15          float64
+	DockerVersion   string
+	Uptime          time.Duration
+}
+
+// SystemService reports on the server the agent runs on. Values that cannot be read are left empty.
+type SystemService struct {
+	started time.Time
+}
+
+func NewSystemService() *SystemService {
+	return &SystemService{
+		started: time.Now(),
+	}
+}
+
+// Uptime returns how long the agent has been running.
+func (s *SystemService) Uptime() time.Duration {
+	return time.Since(s.started)
+}
+
+// DockerVersion returns the version of the docker daemon, failing when it does not answer.
+func (s *SystemService) DockerVersion(ctx context.Context) (string, error) {
+	out, err := output(ctx, "docker", "version", "--format", "{{.Server.Version}}")
+	return strings.TrimSpace(out), err
+}
+
+func (s *SystemService) Info(ctx context.Context) *SystemInfo {
+	info := &SystemInfo{
+		OS:     runtime.GOOS,
+		Arch:   runtime.GOARCH,
+		CPUs:   runtime.NumCPU(),
+		Kernel: readTrimmed("/proc/sys/kernel/osrelease"),
+	}
+
+	info.Hostname, _ = os.Hostname()
+	info.DockerVersion, _ = s.DockerVersion(ctx)
+	info.DiskTotal, info.DiskFree = diskUsage("/")
+
+	if release, err := os.ReadFile("/etc/os-release"); err == nil {
+		for _, line := range strings.Split(string(release), "\n") {
+			if value, ok := strings.CutPrefix(line, "PRETTY_NAME="); ok {
+				info.OS = strings.Trim(value, `"`)
+			}
+		}
+	}
+
+	if meminfo, err := os.ReadFile("/proc/meminfo"); err == nil {
+		for _, line := range strings.Split(string(meminfo), "\n") {
+			fields := strings.Fields(line)
+			if len(fields) < 2 {
+				continue
+			}
+			kb, _ := strconv.ParseUint(fields[1], 10, 64)
+			switch fields[0] {
+			case "MemTotal:":
+				info.MemoryTotal = kb * 1024
+			case "MemAvailable:":
+				info.MemoryAvailable = kb * 1024
+			}
+		}
+	}
+
+	if fields := strings.Fields(readTrimmed("/proc/loadavg")); len(fields) >= 3 {
+		info.Load1, _ = strconv.ParseFloat(fields[0], 64)
+		info.Load5, _ = strconv.ParseFloat(fields[1], 64)
+		info.Load15, _ = strconv.ParseFloat(fields[2], 64)
+	}
+
+	if fields := strings.Fields(readTrimmed("/proc/uptime")); len(fields) >= 1 {
+		seconds, _ := strconv.ParseFloat(fields[0], 64)
+		info.Uptime = time.Duration(seconds * float64(time.Second))
+	}
+
+	return info
+}
+
+func readTrimmed(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
@@ -0,0 +1,153 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/zhinea/sylix/internal/common"
+	"github.com/zhinea/sylix/internal/common/logger"
+	pbAgent "github.com/zhinea/sylix/internal/infra/proto/agent"
+	pbCommon "github.com/zhinea/sylix/internal/infra/proto/common"
+	"github.com/zhinea/sylix/internal/module/agent/domain/services"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type AgentService struct {
+	pbAgent.UnimplementedAgentServiceServer
+	system     *services.SystemService
+	compose    *services.ComposeService
+	containers *services.ContainerService
+}
+
+func NewAgentService(system *services.SystemService, compose *services.ComposeService, containers *services.ContainerService) *AgentService {
+	return &AgentService{
+		system:     system,
+		compose:    compose,
+		containers: containers,
+	}
+}
+
+func (s *AgentService) Health(ctx context.Context, _ *pbCommon.Empty) (*pbAgent.HealthResponse, error) {
+	_, err := s.system.DockerVersion(ctx)
+
+	return &pbAgent.HealthResponse{
+		Status:        "ok",
+		Version:       common.Version,
+		UptimeSeconds: int64(s.system.Uptime().Seconds()),
+		Docker:        err == nil,
+	}, nil
+}
+
+func (s *AgentService) SystemInfo(ctx context.Context, _ *pbCommon.Empty) (*pbAgent.SystemInfoResponse, error) {
+	info := s.system.Info(ctx)
+
+	return &pbAgent.SystemInfoResponse{
+		Hostname:        info.Hostname,
+		Os:              info.OS,
+		Kernel:          info.Kernel,
+		Arch:            info.Arch,
+		Cpus:            int32(info.CPUs),
+		MemoryTotal:     info.MemoryTotal,
+		MemoryAvailable: info.MemoryAvailable,
+		DiskTotal:       info.DiskTotal,
+		DiskFree:        info.DiskFree,
+		Load1:           info.Load1,
+		Load5:           info.Load5,
+		Load15:          info.Load15,
+		DockerVersion:   info.DockerVersion,
+		UptimeSeconds:   int64(info.Uptime.Seconds()),
+	}, nil
+}
+
+func (s *AgentService) DeployCompose(req *pbAgent.DeployComposeRequest, stream pbAgent.AgentService_DeployComposeServer) error {
+	if len(req.Compose) == 0 {
+		return status.Error(codes.InvalidArgument, "compose is required")
+	}
+
+	logger.Log.Info("Deploying compose project", zap.String("project", req.Project))
+
+	stdout, stderr := newOutputWriters(stream.Send)
+	err := s.compose.Deploy(stream.Context(), req.Project, req.Compose, stdout, stderr)
+	stdout.Flush()
+	stderr.Flush()
+	if err != nil {
+		logger.Log.Error("Failed to deploy compose project", zap.String("project", req.Project), zap.Error(err))
+		return toStatusError(err)
+	}
+	return nil
+}
+
+func (s *AgentService) StopCompose(ctx context.Context, req *pbAgent.ComposeProject) (*pbAgent.CommandResult, error) {
+	out, err := s.compose.Stop(ctx, req.Project)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pbAgent.CommandResult{Output: out}, nil
+}
+
+func (s *AgentService) RemoveCompose(ctx context.Context, req *pbAgent.RemoveComposeRequest) (*pbAgent.CommandResult, error) {
+	logger.Log.Info("Removing compose project", zap.String("project", req.Project), zap.Bool("volumes", req.Volumes))
+
+	out, err := s.compose.Remove(ctx, req.Project, req.Volumes)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+	return &pbAgent.CommandResult{Output: out}, nil
+}
+
+func (s *AgentService) ContainerLogs(req *pbAgent.ContainerLogsRequest, stream pbAgent.AgentService_ContainerLogsServer) error {
+	if req.Container == "" {
+		return status.Error(codes.InvalidArgument, "container is required")
+	}
+
+	stdout, stderr := newOutputWriters(stream.Send)
+	err := s.containers.Logs(stream.Context(), services.LogsOptions{
+		Container:  req.Container,
+		Tail:       int(req.Tail),
+		Since:      req.Since,
+		Until:      req.Until,
+		Follow:     req.Follow,
+		Timestamps: req.Timestamps,
+	}, stdout, stderr)
+	stdout.Flush()
+	stderr.Flush()
+
+	// a follow ends when the client goes away
+	if err != nil && stream.Context().Err() == nil {
+		return toStatusError(err)
+	}
+	return nil
+}
+
+func (s *AgentService) Exec(ctx context.Context, req *pbAgent.ExecRequest) (*pbAgent.ExecResponse, error) {
+	if len(req.Command) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "command is required")
+	}
+
+	result, err := s.containers.Exec(ctx, req.Container, req.Command, time.Duration(req.TimeoutSeconds)*time.Second)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pbAgent.ExecResponse{
+		ExitCode: int32(result.ExitCode),
+		Stdout:   result.Stdout,
+		Stderr:   result.Stderr,
+	}, nil
+}
+
+func toStatusError(err error) error {
+	switch {
+	case errors.Is(err, services.ErrInvalidProject):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
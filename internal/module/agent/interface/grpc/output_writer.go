@@ -0,0 +1,54 @@
+package grpc
+
+import (
+	"bytes"
+	"sync"
+
+	pbAgent "github.com/zhinea/sylix/internal/infra/proto/agent"
+)
+
+// outputWriter sends what a command writes as OutputLine messages, one per line. The stdout and
+// stderr writers of a command share a lock, as a stream must not be sent on concurrently.
+type outputWriter struct {
+	mu     *sync.Mutex
+	stream string
+	send   func(*pbAgent.OutputLine) error
+	buf    []byte
+}
+
+func newOutputWriters(send func(*pbAgent.OutputLine) error) (stdout, stderr *outputWriter) {
+	mu := &sync.Mutex{}
+	return &outputWriter{mu: mu, stream: "stdout", send: send},
+		&outputWriter{mu: mu, stream: "stderr", send: send}
+}
+
+func (w *outputWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		line := string(w.buf[:i])
+		w.buf = w.buf[i+1:]
+		if err := w.send(&pbAgent.OutputLine{Stream: w.stream, Line: line}); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// Flush sends a last line that did not end with a newline.
+func (w *outputWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.buf) == 0 {
+		return nil
+	}
+	line := string(w.buf)
+	w.buf = nil
+	return w.send(&pbAgent.OutputLine{Stream: w.stream, Line: line})
+}
@@ -0,0 +1,78 @@
+package agent
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/zhinea/sylix/internal/common/config"
+	"github.com/zhinea/sylix/internal/common/util"
+	pbAgent "github.com/zhinea/sylix/internal/infra/proto/agent"
+	"github.com/zhinea/sylix/internal/module/agent/domain/services"
+	grpcServices "github.com/zhinea/sylix/internal/module/agent/interface/grpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// NewServer creates the gRPC server of the agent with the AgentService registered. Only the
+// controlplane, presenting its client certificate signed by the configured CA, is accepted.
+func NewServer(cfg *config.AgentConfig) (*grpc.Server, error) {
+	tlsConfig, err := TLSConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(tlsConfig)))
+	pbAgent.RegisterAgentServiceServer(server, grpcServices.NewAgentService(
+		services.NewSystemService(),
+		services.NewComposeService(services.ProjectsDir),
+		services.NewContainerService(),
+	))
+	return server, nil
+}
+
+// TLSConfig loads the certificate of the agent and the CA its clients must be signed by.
+// Certificates the CA issued to other agents are signed by it as well, so the client must also
+// present the controlplane's identity and be issued for client authentication.
+func TLSConfig(cfg *config.AgentConfig) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(cfg.Security.CertFile, cfg.Security.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load agent certificate: %w", err)
+	}
+
+	caPEM, err := os.ReadFile(cfg.Security.CAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+	}
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no certificate found in %s", cfg.Security.CAFile)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+		VerifyConnection: func(cs tls.ConnectionState) error {
+			if len(cs.PeerCertificates) == 0 {
+				return errors.New("no client certificate presented")
+			}
+			return verifyControlplane(cs.PeerCertificates[0])
+		},
+	}, nil
+}
+
+// verifyControlplane checks that cert is the controlplane's client certificate.
+func verifyControlplane(cert *x509.Certificate) error {
+	if cert.Subject.CommonName != util.ControlplaneCertName && cert.VerifyHostname(util.ControlplaneCertName) != nil {
+		return fmt.Errorf("client %q is not the controlplane", cert.Subject.CommonName)
+	}
+	if !slices.Contains(cert.ExtKeyUsage, x509.ExtKeyUsageClientAuth) {
+		return fmt.Errorf("certificate of %q is not issued for client authentication", cert.Subject.CommonName)
+	}
+	return nil
+}
@@ -0,0 +1,208 @@
+package agent_test
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zhinea/sylix/internal/common/config"
+	"github.com/zhinea/sylix/internal/common/logger"
+	"github.com/zhinea/sylix/internal/common/util"
+	pbAgent "github.com/zhinea/sylix/internal/infra/proto/agent"
+	pbCommon "github.com/zhinea/sylix/internal/infra/proto/common"
+	"github.com/zhinea/sylix/internal/module/agent"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/services"
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"gorm.io/gorm"
+)
+
+// fakeDocker stands in for docker logs, printing a line to each stream.
+const fakeDocker = `#!/bin/sh
+if [ "$1" = "logs" ]; then
+	echo "log of $2 $3 $4"
+	echo "warning" >&2
+fi
+`
+
+// memoryCARepository keeps the cluster CA in memory.
+type memoryCARepository struct {
+	ca *entity.CertificateAuthority
+}
+
+func (r *memoryCARepository) Create(_ context.Context, ca *entity.CertificateAuthority) (*entity.CertificateAuthority, error) {
+	r.ca = ca
+	return ca, nil
+}
+
+func (r *memoryCARepository) GetByName(_ context.Context, _ string) (*entity.CertificateAuthority, error) {
+	if r.ca == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return r.ca, nil
+}
+
+func (r *memoryCARepository) Update(_ context.Context, ca *entity.CertificateAuthority) error {
+	r.ca = ca
+	return nil
+}
+
+// startAgent issues the agent a certificate for the loopback address and serves it, returning
+// the certificate service of the controlplane and the address of the agent.
+func startAgent(t *testing.T) (*services.CertificateService, *memoryCARepository, string) {
+	t.Helper()
+	logger.Log = zap.NewNop()
+
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "docker"), []byte(fakeDocker), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	repo := &memoryCARepository{}
+	certs := services.NewCertificateService(repo)
+	if err := certs.Init(context.Background()); err != nil {
+		t.Fatalf("init certificates: %v", err)
+	}
+
+	server := &entity.Server{IpAddress: "127.0.0.1"}
+	if _, err := certs.IssueServerCertificate(server); err != nil {
+		t.Fatalf("issue agent certificate: %v", err)
+	}
+
+	dir := t.TempDir()
+	cfg := config.DefaultAgentConfig()
+	cfg.Security.CertFile = filepath.Join(dir, "server.crt")
+	cfg.Security.KeyFile = filepath.Join(dir, "server.key")
+	cfg.Security.CAFile = filepath.Join(dir, "ca.crt")
+	for path, content := range map[string]string{
+		cfg.Security.CertFile: server.Agent.Cert,
+		cfg.Security.KeyFile:  server.Agent.Key,
+		cfg.Security.CAFile:   certs.CACertificate(),
+	} {
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	grpcServer, err := agent.NewServer(cfg)
+	if err != nil {
+		t.Fatalf("create agent server: %v", err)
+	}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go grpcServer.Serve(lis)
+	t.Cleanup(grpcServer.Stop)
+
+	return certs, repo, lis.Addr().String()
+}
+
+func dial(t *testing.T, addr string, tlsConfig *tls.Config) pbAgent.AgentServiceClient {
+	t.Helper()
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return pbAgent.NewAgentServiceClient(conn)
+}
+
+func TestAgentServesControlplane(t *testing.T) {
+	certs, _, addr := startAgent(t)
+	client := dial(t, addr, certs.ClientTLSConfig("127.0.0.1"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	health, err := client.Health(ctx, &pbCommon.Empty{})
+	if err != nil {
+		t.Fatalf("Health: %v", err)
+	}
+	if health.Status != "ok" {
+		t.Errorf("Health status = %q, want ok", health.Status)
+	}
+
+	exec, err := client.Exec(ctx, &pbAgent.ExecRequest{Command: []string{"sh", "-c", "echo out; echo err >&2; exit 3"}})
+	if err != nil {
+		t.Fatalf("Exec: %v", err)
+	}
+	if exec.ExitCode != 3 || exec.Stdout != "out\n" || exec.Stderr != "err\n" {
+		t.Errorf("Exec = %d %q %q, want 3 \"out\\n\" \"err\\n\"", exec.ExitCode, exec.Stdout, exec.Stderr)
+	}
+
+	stream, err := client.ContainerLogs(ctx, &pbAgent.ContainerLogsRequest{Container: "compute-1", Tail: 10})
+	if err != nil {
+		t.Fatalf("ContainerLogs: %v", err)
+	}
+	var lines []string
+	for {
+		line, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("ContainerLogs: %v", err)
+		}
+		lines = append(lines, line.Stream+": "+line.Line)
+	}
+	got := strings.Join(lines, "\n")
+	for _, want := range []string{"stdout: log of --tail 10 compute-1", "stderr: warning"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("ContainerLogs = %q, missing %q", got, want)
+		}
+	}
+}
+
+func TestAgentRejectsOtherClients(t *testing.T) {
+	certs, repo, addr := startAgent(t)
+
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM([]byte(certs.CACertificate()))
+
+	// Another agent's certificate, signed by the same CA but only good for serving
+	other := &entity.Server{IpAddress: "10.0.0.2"}
+	if _, err := certs.IssueServerCertificate(other); err != nil {
+		t.Fatal(err)
+	}
+	// A client certificate of the CA that is not the controlplane's
+	intruderCert, intruderKey, err := util.GenerateCert([]byte(repo.ca.Cert), []byte(repo.ca.Key), "intruder", x509.ExtKeyUsageClientAuth)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, pair := range map[string][2]string{
+		"agent certificate":     {other.Agent.Cert, other.Agent.Key},
+		"other client identity": {string(intruderCert), string(intruderKey)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			cert, err := tls.X509KeyPair([]byte(pair[0]), []byte(pair[1]))
+			if err != nil {
+				t.Fatal(err)
+			}
+			client := dial(t, addr, &tls.Config{
+				RootCAs:      roots,
+				Certificates: []tls.Certificate{cert},
+				ServerName:   "127.0.0.1",
+				MinVersion:   tls.VersionTLS12,
+			})
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if _, err := client.Health(ctx, &pbCommon.Empty{}); err == nil {
+				t.Fatal("Health succeeded, want the client rejected")
+			}
+		})
+	}
+}
@@ -0,0 +1,268 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/zhinea/sylix/internal/common/logger"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/compose"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/nodetype"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/repository"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/services"
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrBackupInProgress    = errors.New("a backup of the service is already running")
+	ErrBackupNotConfigured = errors.New("service has no backup storage")
+)
+
+const (
+	DefaultBackupJobLimit = 20
+	MaxBackupJobLimit     = 100
+
+	// backupScheduleField is the node field holding the cron schedule of its backups.
+	backupScheduleField = "backup_schedule"
+	// backupStorageField is the node field holding the backup storage its backups go to.
+	backupStorageField = "backup_storage_id"
+	// backupCheckInterval is how often schedules are checked, the finest step of a cron expression.
+	backupCheckInterval = time.Minute
+)
+
+// BackupScheduler backs service nodes up to their backup storage on the cron schedule of their
+// backup_schedule field, or when triggered. The backup is streamed from the node's server to the
+// bucket as it is taken, and every one is recorded as a BackupJob.
+type BackupScheduler struct {
+	repo          repository.BackupJobRepository
+	nodeRepo      repository.ServiceNodeRepository
+	backupRepo    repository.BackupStorageRepository
+	backupService *services.BackupService
+	remote        *services.RemoteService
+
+	mu      sync.Mutex
+	running map[string]bool
+}
+
+func NewBackupScheduler(
+	repo repository.BackupJobRepository,
+	nodeRepo repository.ServiceNodeRepository,
+	backupRepo repository.BackupStorageRepository,
+	backupService *services.BackupService,
+	remote *services.RemoteService,
+) *BackupScheduler {
+	return &BackupScheduler{
+		repo:          repo,
+		nodeRepo:      nodeRepo,
+		backupRepo:    backupRepo,
+		backupService: backupService,
+		remote:        remote,
+		running:       make(map[string]bool),
+	}
+}
+
+// Start fails the jobs a restart interrupted, then starts the backups that are due every minute.
+func (s *BackupScheduler) Start() {
+	ctx := context.Background()
+	s.failInterrupted(ctx)
+
+	go func() {
+		ticker := time.NewTicker(backupCheckInterval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			s.runDue(ctx)
+		}
+	}()
+}
+
+// Trigger starts a backup of the service now. The job is returned while the backup runs.
+func (s *BackupScheduler) Trigger(ctx context.Context, serviceID string) (*entity.BackupJob, error) {
+	node, err := s.nodeRepo.GetByID(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	return s.start(ctx, node, entity.BackupTriggerManual)
+}
+
+// Jobs returns a page of the jobs matching query, latest first, and how many match in all.
+func (s *BackupScheduler) Jobs(ctx context.Context, query repository.BackupJobQuery) ([]*entity.BackupJob, int64, error) {
+	if query.Limit <= 0 {
+		query.Limit = DefaultBackupJobLimit
+	}
+	query.Limit = min(query.Limit, MaxBackupJobLimit)
+	return s.repo.GetAll(ctx, query)
+}
+
+// runDue starts the scheduled backups whose time has come. A schedule goes on from the last job
+// it started, so a backup missed while the controlplane was down runs once it is back.
+func (s *BackupScheduler) runDue(ctx context.Context) {
+	nodes, err := s.nodeRepo.GetWithField(ctx, backupScheduleField)
+	if err != nil {
+		logger.Log.Error("Failed to get services to back up", zap.Error(err))
+		return
+	}
+
+	now := time.Now()
+	for _, node := range nodes {
+		// Scheduled before a storage was required, there is nowhere to back up to
+		if node.Field(backupStorageField) == "" {
+			continue
+		}
+		schedule, err := nodetype.ParseSchedule(node.Field(backupScheduleField))
+		if err != nil {
+			logger.Log.Warn("Skipping backup with an invalid schedule", zap.String("service_id", node.Id), zap.Error(err))
+			continue
+		}
+
+		since := node.CreatedAt
+		last, err := s.repo.GetLatest(ctx, node.Id, entity.BackupTriggerSchedule)
+		if err == nil {
+			since = last.StartedAt
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Log.Error("Failed to get the last backup", zap.String("service_id", node.Id), zap.Error(err))
+			continue
+		}
+
+		next := schedule.Next(since)
+		if next.IsZero() || next.After(now) {
+			continue
+		}
+		if _, err := s.start(ctx, node, entity.BackupTriggerSchedule); err != nil && !errors.Is(err, ErrBackupInProgress) {
+			logger.Log.Error("Failed to start scheduled backup", zap.String("service_id", node.Id), zap.Error(err))
+		}
+	}
+}
+
+// start records a job backing the node up and runs it in the background. A backup that cannot be
+// taken, such as one of a node that is not deployed, is recorded as a failed job so its schedule
+// moves on.
+func (s *BackupScheduler) start(ctx context.Context, node *entity.ServiceNode, trigger string) (*entity.BackupJob, error) {
+	storageID := node.Field(backupStorageField)
+	if storageID == "" {
+		return nil, ErrBackupNotConfigured
+	}
+
+	s.mu.Lock()
+	if s.running[node.Id] {
+		s.mu.Unlock()
+		return nil, ErrBackupInProgress
+	}
+	s.running[node.Id] = true
+	s.mu.Unlock()
+
+	job := &entity.BackupJob{
+		ServiceNodeID: node.Id,
+		StorageID:     storageID,
+		Trigger:       trigger,
+		Status:        entity.BackupJobStatusRunning,
+		StartedAt:     time.Now(),
+	}
+	var backup *compose.Backup
+	storage, err := s.backupRepo.GetByID(ctx, storageID)
+	if err != nil {
+		err = fmt.Errorf("failed to get backup storage: %w", err)
+	} else {
+		job.Bucket = storage.Bucket
+		backup, err = compose.NodeBackup(node)
+	}
+	if err == nil {
+		job.Kind = backup.Kind
+		job.Key, err = compose.BackupKey(node, backup.Kind, job.StartedAt)
+	}
+
+	job, createErr := s.repo.Create(ctx, job)
+	if createErr != nil {
+		s.release(node.Id)
+		return nil, createErr
+	}
+	if err != nil {
+		s.finish(job, err)
+		s.release(node.Id)
+		return job, nil
+	}
+
+	logger.Log.Info("Backup started", zap.String("job_id", job.Id), zap.String("service_id", node.Id), zap.String("key", job.Key))
+	go func() {
+		defer s.release(node.Id)
+		s.run(job, node, backup, storage)
+	}()
+	return job, nil
+}
+
+// run pipes the output of the backup command into an upload to the bucket.
+func (s *BackupScheduler) run(job *entity.BackupJob, node *entity.ServiceNode, backup *compose.Backup, storage *entity.BackupStorage) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reader, writer := io.Pipe()
+	streamed := make(chan struct{})
+	go func() {
+		defer close(streamed)
+		// Closing with a nil error ends the upload, any other fails it
+		writer.CloseWithError(s.remote.Stream(ctx, node.ServerID, backup.Command, writer))
+	}()
+
+	size, checksum, err := s.backupService.Upload(ctx, storage, job.Key, reader)
+	if err != nil {
+		// The command is stopped when the upload failed first
+		cancel()
+		reader.CloseWithError(err)
+	}
+	<-streamed
+
+	job.Size = size
+	job.Checksum = checksum
+	s.finish(job, err)
+}
+
+// finish records the outcome of the job.
+func (s *BackupScheduler) finish(job *entity.BackupJob, err error) {
+	finished := time.Now()
+	job.FinishedAt = &finished
+	job.Duration = finished.Sub(job.StartedAt)
+	job.Status = entity.BackupJobStatusSucceeded
+	if err != nil {
+		job.Status = entity.BackupJobStatusFailed
+		job.Error = err.Error()
+		logger.Log.Error("Backup failed", zap.String("job_id", job.Id), zap.String("service_id", job.ServiceNodeID), zap.Error(err))
+	} else {
+		logger.Log.Info("Backup finished", zap.String("job_id", job.Id), zap.String("service_id", job.ServiceNodeID),
+			zap.Int64("size", job.Size), zap.Duration("duration", job.Duration))
+	}
+
+	if err := s.repo.Update(context.Background(), job); err != nil {
+		logger.Log.Error("Failed to save backup job", zap.String("job_id", job.Id), zap.Error(err))
+	}
+}
+
+// failInterrupted fails the jobs still running when the controlplane stopped, their uploads were
+// never completed.
+func (s *BackupScheduler) failInterrupted(ctx context.Context) {
+	jobs, err := s.repo.GetByStatus(ctx, entity.BackupJobStatusRunning)
+	if err != nil {
+		logger.Log.Error("Failed to get interrupted backups", zap.Error(err))
+		return
+	}
+
+	for _, job := range jobs {
+		finished := time.Now()
+		job.Status = entity.BackupJobStatusFailed
+		job.Error = "interrupted by a controlplane restart"
+		job.FinishedAt = &finished
+		if err := s.repo.Update(ctx, job); err != nil {
+			logger.Log.Error("Failed to fail interrupted backup", zap.String("job_id", job.Id), zap.Error(err))
+		}
+	}
+}
+
+func (s *BackupScheduler) release(serviceID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.running, serviceID)
+}
@@ -0,0 +1,572 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/zhinea/sylix/internal/common/logger"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/compose"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/nodetype"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/repository"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/services"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/topology"
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+	"go.uber.org/zap"
+)
+
+var (
+	ErrDeploymentInProgress = errors.New("cluster already has a deployment in progress")
+	ErrDeploymentFinished   = errors.New("deployment already finished")
+	ErrNotACluster          = errors.New("service is not a cluster")
+	ErrClusterEmpty         = errors.New("cluster has no nodes to deploy")
+)
+
+// TopologyError is returned when a cluster cannot be deployed because some imports are unresolved.
+type TopologyError struct {
+	Problems []topology.Problem
+}
+
+func (e *TopologyError) Error() string {
+	return fmt.Sprintf("cluster topology has %d unresolved imports", len(e.Problems))
+}
+
+const (
+	deployHealthTimeout = 10 * time.Minute
+	verifyHealthTimeout = time.Minute
+)
+
+// DeploymentOrchestrator deploys clusters one compose project at a time, in startup priority order,
+// waiting for each project to become healthy before starting the next one.
+type DeploymentOrchestrator struct {
+	repo            repository.DeploymentRepository
+	nodeRepo        repository.ServiceNodeRepository
+	serverRepo      repository.ServerRepository
+	backupRepo      repository.BackupStorageRepository
+	registry        *nodetype.Registry
+	topologyService *services.TopologyService
+	deployService   *services.DeployService
+	renderer        *compose.Renderer
+	ports           compose.PortAllocator
+	logs            *services.LogHub
+
+	mu      sync.Mutex
+	running map[string]context.CancelFunc
+	// planning holds the clusters a deployment is being planned for, which takes remote checks
+	planning map[string]bool
+}
+
+func NewDeploymentOrchestrator(
+	repo repository.DeploymentRepository,
+	nodeRepo repository.ServiceNodeRepository,
+	serverRepo repository.ServerRepository,
+	backupRepo repository.BackupStorageRepository,
+	registry *nodetype.Registry,
+	topologyService *services.TopologyService,
+	deployService *services.DeployService,
+	ports compose.PortAllocator,
+	logs *services.LogHub,
+) *DeploymentOrchestrator {
+	return &DeploymentOrchestrator{
+		repo:            repo,
+		nodeRepo:        nodeRepo,
+		serverRepo:      serverRepo,
+		backupRepo:      backupRepo,
+		registry:        registry,
+		topologyService: topologyService,
+		deployService:   deployService,
+		renderer:        compose.NewRenderer(registry),
+		ports:           ports,
+		logs:            logs,
+		running:         make(map[string]context.CancelFunc),
+		planning:        make(map[string]bool),
+	}
+}
+
+// Deploy plans the deployment of a cluster and starts it in the background.
+func (o *DeploymentOrchestrator) Deploy(ctx context.Context, clusterID string) (*entity.Deployment, error) {
+	if err := o.startPlanning(ctx, clusterID); err != nil {
+		return nil, err
+	}
+	defer func() {
+		o.mu.Lock()
+		delete(o.planning, clusterID)
+		o.mu.Unlock()
+	}()
+
+	deployment, err := o.plan(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := o.repo.Create(ctx, deployment); err != nil {
+		return nil, err
+	}
+
+	o.start(deployment)
+
+	return deployment, nil
+}
+
+// startPlanning marks the cluster as being planned for, unless a deployment of it is already being
+// planned or has not finished. The mark stays until the deployment is created, so the cluster is
+// only locked while it is checked, not while the plan is made.
+func (o *DeploymentOrchestrator) startPlanning(ctx context.Context, clusterID string) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.planning[clusterID] {
+		return ErrDeploymentInProgress
+	}
+	if latest, err := o.repo.GetLatestByClusterID(ctx, clusterID); err == nil && !latest.Finished() {
+		return ErrDeploymentInProgress
+	}
+	o.planning[clusterID] = true
+	return nil
+}
+
+// Get returns a deployment, or the latest deployment of the cluster when id is empty.
+func (o *DeploymentOrchestrator) Get(ctx context.Context, id, clusterID string) (*entity.Deployment, error) {
+	if id != "" {
+		return o.repo.GetByID(ctx, id)
+	}
+	return o.repo.GetLatestByClusterID(ctx, clusterID)
+}
+
+// Cancel stops a deployment. A step that is already running a command finishes that command first.
+func (o *DeploymentOrchestrator) Cancel(ctx context.Context, id string) error {
+	deployment, err := o.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if deployment.Finished() {
+		return ErrDeploymentFinished
+	}
+
+	o.mu.Lock()
+	cancel, ok := o.running[id]
+	o.mu.Unlock()
+	if ok {
+		cancel()
+		return nil
+	}
+
+	// Not running in this process, e.g. waiting to be resumed
+	now := time.Now()
+	deployment.Status = entity.DeploymentStatusCancelled
+	deployment.Error = context.Canceled.Error()
+	deployment.FinishedAt = &now
+	return o.repo.Update(ctx, deployment)
+}
+
+// Resume restarts the deployments that were interrupted by a controlplane restart. Steps that
+// succeeded are skipped, the step that was running is started again.
+func (o *DeploymentOrchestrator) Resume(ctx context.Context) {
+	deployments, err := o.repo.GetUnfinished(ctx)
+	if err != nil {
+		logger.Log.Error("Failed to load unfinished deployments", zap.Error(err))
+		return
+	}
+
+	for _, deployment := range deployments {
+		logger.Log.Info("Resuming deployment", zap.String("deployment_id", deployment.Id), zap.String("cluster_id", deployment.ClusterID))
+		o.start(deployment)
+	}
+}
+
+// plan assigns ports to the nodes of the cluster, renders their compose projects and turns them
+// into deployment steps.
+func (o *DeploymentOrchestrator) plan(ctx context.Context, clusterID string) (*entity.Deployment, error) {
+	cluster, err := o.nodeRepo.GetByID(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	if cluster.Type != entity.ServiceTypeCluster {
+		return nil, ErrNotACluster
+	}
+
+	graph, _, err := o.topologyService.Graph(ctx, clusterID)
+	if err != nil {
+		return nil, err
+	}
+	if len(graph.Nodes()) == 0 {
+		return nil, ErrClusterEmpty
+	}
+
+	for _, node := range graph.Nodes() {
+		nodeType, ok := o.registry.Get(node.App.Service)
+		if !ok {
+			return nil, fmt.Errorf("unknown node type %q", node.App.Service)
+		}
+		if err := compose.AssignPorts(ctx, node, nodeType, o.ports); err != nil {
+			return nil, err
+		}
+		if err := o.nodeRepo.UpdatePorts(ctx, node.Id, node.Ports); err != nil {
+			return nil, err
+		}
+	}
+
+	resolution, err := o.topologyService.Resolve(ctx, graph)
+	if err != nil {
+		return nil, err
+	}
+	if !resolution.Valid() {
+		return nil, &TopologyError{Problems: resolution.Problems}
+	}
+
+	servers, err := o.serverRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	storages, err := o.backupRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &compose.Cluster{
+		ID:         cluster.Id,
+		Name:       cluster.Name,
+		Graph:      graph,
+		Resolution: resolution,
+		Servers:    make(map[string]*entity.Server, len(servers)),
+		Storages:   make(map[string]*entity.BackupStorage, len(storages)),
+	}
+	for _, server := range servers {
+		c.Servers[server.Id] = server
+	}
+	for _, storage := range storages {
+		c.Storages[storage.Id] = storage
+	}
+
+	projects, err := o.renderer.Render(c)
+	if err != nil {
+		return nil, err
+	}
+
+	deployment := &entity.Deployment{
+		ClusterID: clusterID,
+		Status:    entity.DeploymentStatusPending,
+	}
+	for i, project := range projects {
+		content, err := project.File.Marshal()
+		if err != nil {
+			return nil, err
+		}
+
+		step := &entity.DeploymentStep{
+			Position: i,
+			Priority: project.Priority,
+			Project:  project.Name,
+			ServerID: project.ServerID,
+			Compose:  string(content),
+			Status:   entity.DeploymentStatusPending,
+		}
+		for _, node := range project.Nodes {
+			step.ServiceIDs = append(step.ServiceIDs, node.Id)
+		}
+		deployment.Steps = append(deployment.Steps, step)
+	}
+
+	return deployment, nil
+}
+
+// start runs the deployment in the background. It is registered as running before start returns,
+// so a Cancel arriving right after stops it instead of marking it cancelled behind its back.
+func (o *DeploymentOrchestrator) start(deployment *entity.Deployment) {
+	ctx, cancel := context.WithCancel(context.Background())
+	o.mu.Lock()
+	o.running[deployment.Id] = cancel
+	o.mu.Unlock()
+
+	go func() {
+		defer func() {
+			o.mu.Lock()
+			delete(o.running, deployment.Id)
+			o.mu.Unlock()
+			cancel()
+		}()
+		o.run(ctx, deployment)
+	}()
+}
+
+func (o *DeploymentOrchestrator) run(ctx context.Context, deployment *entity.Deployment) {
+	// Cancelled while it was not registered yet, such as between being created and started
+	if current, err := o.repo.GetByID(ctx, deployment.Id); err == nil && current.Finished() {
+		logger.Log.Info("Deployment finished before it started", zap.String("deployment_id", deployment.Id), zap.Int("status", current.Status))
+		return
+	}
+
+	log := openDeploymentLog(deployment.Id)
+	defer log.Close()
+
+	logger.Log.Info("Starting deployment", zap.String("deployment_id", deployment.Id), zap.String("cluster_id", deployment.ClusterID))
+	log.Printf("Deploying cluster %s in %d steps", deployment.ClusterID, len(deployment.Steps))
+
+	deployment.Status = entity.DeploymentStatusRunning
+	if err := o.repo.Update(context.Background(), deployment); err != nil {
+		logger.Log.Error("Failed to update deployment", zap.Error(err), zap.String("deployment_id", deployment.Id))
+	}
+
+	for _, step := range deployment.Steps {
+		if step.Status == entity.DeploymentStatusSucceeded {
+			log.Printf("Skipping %s, already deployed", step.Project)
+			continue
+		}
+		if err := o.runStep(ctx, step, log); err != nil {
+			o.finish(ctx, deployment, err, log)
+			return
+		}
+	}
+
+	log.Printf("Verifying ports and health of all services...")
+	o.finish(ctx, deployment, o.verify(ctx, deployment), log)
+}
+
+// runStep starts the compose project of the step and waits until all its containers are healthy.
+func (o *DeploymentOrchestrator) runStep(ctx context.Context, step *entity.DeploymentStep, log *deploymentLog) error {
+	// ctx may be cancelled, state changes must be persisted regardless
+	store := context.Background()
+
+	now := time.Now()
+	step.Status = entity.DeploymentStatusRunning
+	step.Error = ""
+	step.StartedAt = &now
+	step.FinishedAt = nil
+	o.saveStep(step)
+	o.publishStep(step)
+
+	nodes, err := o.nodeRepo.GetByIDs(store, step.ServiceIDs)
+	if err == nil && len(nodes) != len(step.ServiceIDs) {
+		err = fmt.Errorf("a service of %s no longer exists", step.Project)
+	}
+
+	if err == nil {
+		log.Printf("Deploying %s on server %s (priority %d)", step.Project, step.ServerID, step.Priority)
+		o.setStatus(nodes, entity.ServiceStatusProvisioning)
+		err = o.deployStep(ctx, step, nodes, log)
+	}
+
+	finished := time.Now()
+	step.FinishedAt = &finished
+
+	if err != nil {
+		step.Status = entity.DeploymentStatusFailed
+		if ctx.Err() != nil {
+			step.Status = entity.DeploymentStatusCancelled
+			err = ctx.Err()
+		}
+		step.Error = err.Error()
+		o.saveStep(step)
+		o.publishStep(step)
+		o.setStatus(nodes, entity.ServiceStatusError)
+		log.Printf("Failed to deploy %s: %v", step.Project, err)
+		return fmt.Errorf("%s: %w", step.Project, err)
+	}
+
+	step.Status = entity.DeploymentStatusSucceeded
+	o.saveStep(step)
+	o.publishStep(step)
+	o.setStatus(nodes, entity.ServiceStatusRunning)
+	log.Printf("%s is healthy", step.Project)
+	return nil
+}
+
+func (o *DeploymentOrchestrator) deployStep(ctx context.Context, step *entity.DeploymentStep, nodes []*entity.ServiceNode, log *deploymentLog) error {
+	if err := o.deployService.Up(ctx, step.ServerID, step.Project, []byte(step.Compose), log); err != nil {
+		return err
+	}
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	containers := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		containers = append(containers, compose.ContainerName(node))
+	}
+
+	log.Printf("Waiting for %d containers to become healthy...", len(containers))
+	if err := o.deployService.WaitHealthy(ctx, step.ServerID, containers, deployHealthTimeout); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, node := range nodes {
+		name := compose.ContainerName(node)
+		id, image, err := o.deployService.ContainerInfo(ctx, step.ServerID, name)
+		if err != nil {
+			return err
+		}
+
+		container := entity.ServiceContainer{
+			ID:        id,
+			Name:      name,
+			Image:     image,
+			CreatedAt: node.Container.CreatedAt,
+			UpdatedAt: now,
+		}
+		if container.CreatedAt.IsZero() || node.Container.ID != id {
+			container.CreatedAt = now
+		}
+		if err := o.nodeRepo.UpdateContainer(context.Background(), node.Id, container); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// verify checks, once every step is up, that each published port accepts connections and every
+// container is still healthy.
+func (o *DeploymentOrchestrator) verify(ctx context.Context, deployment *entity.Deployment) error {
+	for _, step := range deployment.Steps {
+		nodes, err := o.nodeRepo.GetByIDs(ctx, step.ServiceIDs)
+		if err != nil {
+			return err
+		}
+		server, err := o.serverRepo.GetByID(ctx, step.ServerID)
+		if err != nil {
+			return err
+		}
+
+		containers := make([]string, 0, len(nodes))
+		for _, node := range nodes {
+			containers = append(containers, compose.ContainerName(node))
+		}
+		if err := o.deployService.WaitHealthy(ctx, step.ServerID, containers, verifyHealthTimeout); err != nil {
+			o.setStatus(nodes, entity.ServiceStatusError)
+			return fmt.Errorf("%s: %w", step.Project, err)
+		}
+
+		for _, node := range nodes {
+			for _, port := range node.Ports {
+				if port.Type != entity.ServicePortTypeOut || port.PublishedPort == 0 {
+					continue
+				}
+
+				host := "127.0.0.1"
+				if !port.EnabledExpose && server.InternalIP != "" {
+					host = server.InternalIP
+				}
+				if err := o.deployService.CheckPort(ctx, step.ServerID, host, port.PublishedPort); err != nil {
+					o.setStatus([]*entity.ServiceNode{node}, entity.ServiceStatusError)
+					return fmt.Errorf("%s: %w", node.Name, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (o *DeploymentOrchestrator) finish(ctx context.Context, deployment *entity.Deployment, err error, log *deploymentLog) {
+	now := time.Now()
+	deployment.FinishedAt = &now
+
+	switch {
+	case err == nil:
+		deployment.Status = entity.DeploymentStatusSucceeded
+		deployment.Error = ""
+		log.Printf("Deployment completed successfully")
+		logger.Log.Info("Deployment completed successfully", zap.String("deployment_id", deployment.Id))
+	case ctx.Err() != nil:
+		deployment.Status = entity.DeploymentStatusCancelled
+		deployment.Error = err.Error()
+		log.Printf("Deployment cancelled")
+		logger.Log.Info("Deployment cancelled", zap.String("deployment_id", deployment.Id))
+	default:
+		deployment.Status = entity.DeploymentStatusFailed
+		deployment.Error = err.Error()
+		log.Printf("Deployment failed: %v", err)
+		logger.Log.Error("Deployment failed", zap.Error(err), zap.String("deployment_id", deployment.Id))
+	}
+
+	if err := o.repo.Update(context.Background(), deployment); err != nil {
+		logger.Log.Error("Failed to update deployment", zap.Error(err), zap.String("deployment_id", deployment.Id))
+	}
+}
+
+func (o *DeploymentOrchestrator) saveStep(step *entity.DeploymentStep) {
+	if err := o.repo.UpdateStep(context.Background(), step); err != nil {
+		logger.Log.Error("Failed to update deployment step", zap.Error(err), zap.String("step_id", step.Id))
+	}
+}
+
+// publishStep reports the status of the step to the viewers of the deployment log.
+func (o *DeploymentOrchestrator) publishStep(step *entity.DeploymentStep) {
+	o.logs.PublishStep(deploymentLogPath(step.DeploymentID), services.LogStepEvent{
+		Index:  step.Position,
+		Name:   step.Project,
+		Host:   step.ServerID,
+		Status: deploymentStepStatus[step.Status],
+		Error:  step.Error,
+		Time:   time.Now(),
+	})
+}
+
+// deploymentStepStatus names the statuses of deployment steps in step events.
+var deploymentStepStatus = map[int]string{
+	entity.DeploymentStatusPending:   "pending",
+	entity.DeploymentStatusRunning:   "running",
+	entity.DeploymentStatusSucceeded: "succeeded",
+	entity.DeploymentStatusFailed:    "failed",
+	entity.DeploymentStatusCancelled: "cancelled",
+}
+
+func (o *DeploymentOrchestrator) setStatus(nodes []*entity.ServiceNode, status int) {
+	for _, node := range nodes {
+		node.Status = status
+		if err := o.nodeRepo.UpdateStatus(context.Background(), node.Id, status); err != nil {
+			logger.Log.Error("Failed to update service status", zap.Error(err), zap.String("service_id", node.Id))
+		}
+	}
+}
+
+func deploymentLogPath(deploymentID string) string {
+	return filepath.Join("logs", "deployments", deploymentID, "deploy.log")
+}
+
+// deploymentLog appends to logs/deployments/<id>/deploy.log. It is opened in append mode so a
+// resumed deployment continues the log of the interrupted one.
+type deploymentLog struct {
+	file *os.File
+}
+
+func openDeploymentLog(deploymentID string) *deploymentLog {
+	path := deploymentLogPath(deploymentID)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		logger.Log.Error("Failed to create log directory", zap.Error(err))
+		return &deploymentLog{}
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		logger.Log.Error("Failed to create log file", zap.Error(err))
+		return &deploymentLog{}
+	}
+	return &deploymentLog{file: file}
+}
+
+func (l *deploymentLog) Write(p []byte) (int, error) {
+	if l.file == nil {
+		return len(p), nil
+	}
+	return l.file.Write(p)
+}
+
+func (l *deploymentLog) Printf(format string, args ...interface{}) {
+	if l.file == nil {
+		return
+	}
+	timestamp := time.Now().Format(time.RFC3339)
+	l.file.WriteString(fmt.Sprintf("[%s] %s\n", timestamp, fmt.Sprintf(format, args...)))
+}
+
+func (l *deploymentLog) Close() {
+	if l.file != nil {
+		l.file.Close()
+	}
+}
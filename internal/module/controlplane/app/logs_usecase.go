@@ -1,113 +1,277 @@
 package app
 
 import (
-	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"time"
+
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/services"
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
 )
 
-type LogsUseCase struct{}
+// ErrInvalidLogPath is returned for log paths that would leave the logs directory.
+var ErrInvalidLogPath = errors.New("invalid log path")
+
+// LogFile is a log file on disk, or one only left in backup storage when Archived.
+type LogFile struct {
+	Name         string
+	Size         int64
+	LastModified time.Time
+	Archived     bool
+}
+
+type LogsUseCase struct {
+	hub       *services.LogHub
+	reader    *services.LogReader
+	retention *services.LogRetentionService
+	appLog    string
+}
 
-func NewLogsUseCase() *LogsUseCase {
-	return &LogsUseCase{}
+// NewLogsUseCase reads the logs under logs/, appLog being the JSON log of the controlplane itself.
+// Logs whose local copy the retention policy deleted are read from their archive.
+func NewLogsUseCase(hub *services.LogHub, reader *services.LogReader, retention *services.LogRetentionService, appLog string) *LogsUseCase {
+	return &LogsUseCase{
+		hub:       hub,
+		reader:    reader,
+		retention: retention,
+		appLog:    appLog,
+	}
 }
 
-func (uc *LogsUseCase) GetServerLogs(ctx context.Context, serverID string) ([]os.DirEntry, error) {
-	logDir := fmt.Sprintf("logs/servers/%s", serverID)
+func (uc *LogsUseCase) GetServerLogs(ctx context.Context, serverID string) ([]LogFile, error) {
+	if !plainName(serverID) {
+		return nil, ErrInvalidLogPath
+	}
+
+	logDir := filepath.Join("logs", "servers", serverID)
 	entries, err := os.ReadDir(logDir)
-	if err != nil {
-		if os.IsNotExist(err) {
-			return []os.DirEntry{}, nil
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	files := []LogFile{}
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			continue
 		}
+		files = append(files, LogFile{Name: entry.Name(), Size: info.Size(), LastModified: info.ModTime()})
+	}
+
+	archives, err := uc.archived(ctx, logDir+string(os.PathSeparator))
+	if err != nil {
 		return nil, err
 	}
-	return entries, nil
+	for _, archive := range archives {
+		if filepath.Dir(archive.Path) == logDir {
+			files = append(files, archivedLogFile(filepath.Base(archive.Path), archive))
+		}
+	}
+	return files, nil
 }
 
-func (uc *LogsUseCase) ReadServerLog(ctx context.Context, serverID, filename string, page, pageSize int) ([]string, int, int, int, error) {
-	logPath := fmt.Sprintf("logs/servers/%s/%s", serverID, filename)
-	// Security check: ensure filename doesn't contain ".."
-	if filepath.Base(filename) != filename {
-		return nil, 0, 0, 0, fmt.Errorf("invalid filename")
+func (uc *LogsUseCase) ReadServerLog(ctx context.Context, serverID, filename string, page, pageSize int, reverse bool) (*services.LogPage, error) {
+	// Security check: ensure neither name leaves the server's log directory
+	if !plainName(serverID) || !plainName(filename) {
+		return nil, ErrInvalidLogPath
 	}
 
-	return uc.readLogFile(logPath, page, pageSize)
+	path, err := uc.retention.Fetch(ctx, filepath.Join("logs", "servers", serverID, filename))
+	if err != nil {
+		return nil, err
+	}
+	return uc.reader.Page(path, page, pageSize, reverse)
 }
 
-func (uc *LogsUseCase) GetSystemLogs(ctx context.Context) ([]string, error) {
-	var logs []string
-	if _, err := os.Stat("logs"); os.IsNotExist(err) {
-		return logs, nil
+// TailServerLog watches a file of the server's logs from offset, provisioning.log when filename is empty.
+func (uc *LogsUseCase) TailServerLog(serverID, filename string, offset int64) (*services.LogSubscription, error) {
+	if filename == "" {
+		filename = "provisioning.log"
 	}
+	if !plainName(serverID) || !plainName(filename) {
+		return nil, ErrInvalidLogPath
+	}
+	return uc.hub.Subscribe(filepath.Join("logs", "servers", serverID, filename), offset)
+}
+
+// TailDeploymentLog watches the log of a deployment from offset.
+func (uc *LogsUseCase) TailDeploymentLog(deploymentID string, offset int64) (*services.LogSubscription, error) {
+	if !plainName(deploymentID) {
+		return nil, ErrInvalidLogPath
+	}
+	return uc.hub.Subscribe(deploymentLogPath(deploymentID), offset)
+}
+
+func (uc *LogsUseCase) GetSystemLogs(ctx context.Context) ([]LogFile, error) {
+	files := []LogFile{}
 	err := filepath.Walk("logs", func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
 		if !info.IsDir() {
-			logs = append(logs, path)
+			files = append(files, LogFile{Name: path, Size: info.Size(), LastModified: info.ModTime()})
 		}
 		return nil
 	})
-	return logs, err
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	archives, err := uc.archived(ctx, "logs"+string(os.PathSeparator))
+	if err != nil {
+		return nil, err
+	}
+	for _, archive := range archives {
+		files = append(files, archivedLogFile(archive.Path, archive))
+	}
+	return files, nil
 }
 
-func (uc *LogsUseCase) ReadSystemLog(ctx context.Context, filename string, page, pageSize int) ([]string, int, int, int, error) {
-	cleanPath := filepath.Clean(filename)
+func (uc *LogsUseCase) ReadSystemLog(ctx context.Context, filename string, page, pageSize int, reverse bool) (*services.LogPage, error) {
+	path, err := systemLogPath(filename)
+	if err != nil {
+		return nil, err
+	}
 
-	if !filepath.HasPrefix(cleanPath, "logs"+string(os.PathSeparator)) && cleanPath != "logs" {
-		return nil, 0, 0, 0, fmt.Errorf("invalid log path: must start with logs/")
+	local, err := uc.retention.Fetch(ctx, path)
+	if err != nil {
+		return nil, err
 	}
+	return uc.reader.Page(local, page, pageSize, reverse)
+}
 
-	if filepath.IsAbs(cleanPath) {
-		return nil, 0, 0, 0, fmt.Errorf("absolute paths not allowed")
+// SearchLog finds the lines of a file under logs/ matching filter, with includeRotated in its
+// rotated backups too, archived ones included.
+func (uc *LogsUseCase) SearchLog(ctx context.Context, filename string, filter services.LogFilter, includeRotated bool, page, pageSize int, reverse bool) (*services.LogSearchResult, error) {
+	path, err := systemLogPath(filename)
+	if err != nil {
+		return nil, err
 	}
 
-	// Verify it is inside logs directory
-	absLogs, _ := filepath.Abs("logs")
-	absPath, _ := filepath.Abs(cleanPath)
-	if !filepath.HasPrefix(absPath, absLogs) {
-		return nil, 0, 0, 0, fmt.Errorf("access denied")
+	paths := []string{path}
+	if includeRotated {
+		backups, err := uc.retention.Backups(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+		paths = append(backups, path)
+	}
+
+	local, logical, err := uc.fetch(ctx, path, paths)
+	if err != nil {
+		return nil, err
 	}
 
-	return uc.readLogFile(cleanPath, page, pageSize)
+	result, err := uc.reader.Search(ctx, local, filter, page, pageSize, reverse)
+	if err != nil {
+		return nil, err
+	}
+	for i := range result.Matches {
+		result.Matches[i].File = logical[result.Matches[i].File]
+	}
+	return result, nil
 }
 
-func (uc *LogsUseCase) readLogFile(path string, page, pageSize int) ([]string, int, int, int, error) {
-	file, err := os.Open(path)
+// Archives returns the log files uploaded to backup storage, oldest first.
+func (uc *LogsUseCase) Archives(ctx context.Context, serverID string) ([]*entity.LogArchive, error) {
+	prefix := "logs" + string(os.PathSeparator)
+	if serverID != "" {
+		if !plainName(serverID) {
+			return nil, ErrInvalidLogPath
+		}
+		prefix = filepath.Join("logs", "servers", serverID) + string(os.PathSeparator)
+	}
+	return uc.retention.Archives(ctx, prefix)
+}
+
+// QueryAppLog returns the records of the controlplane's log and its rotated backups matching
+// query, archived ones included.
+func (uc *LogsUseCase) QueryAppLog(ctx context.Context, query services.LogQuery, page, pageSize int, reverse bool) (*services.LogQueryResult, error) {
+	backups, err := uc.retention.Backups(ctx, uc.appLog)
 	if err != nil {
-		return nil, 0, 0, 0, err
+		return nil, err
+	}
+	local, logical, err := uc.fetch(ctx, uc.appLog, append(query.Prune(uc.appLog, backups), uc.appLog))
+	if err != nil {
+		return nil, err
 	}
-	defer file.Close()
 
-	scanner := bufio.NewScanner(file)
-	var lines []string
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
+	result, err := uc.reader.Query(ctx, local, query, page, pageSize, reverse)
+	if err != nil {
+		return nil, err
+	}
+	for i := range result.Records {
+		result.Records[i].File = logical[result.Records[i].File]
 	}
+	return result, nil
+}
 
-	totalLines := len(lines)
-	totalPages := (totalLines + pageSize - 1) / pageSize
-	if totalPages == 0 {
-		totalPages = 1
+// fetch returns where the files at paths, the log at path and its backups, can be read, and the
+// path each of those stands for. Archived files are read in the copy downloaded, but reported
+// under their own path.
+func (uc *LogsUseCase) fetch(ctx context.Context, path string, paths []string) ([]string, map[string]string, error) {
+	var local []string
+	logical := make(map[string]string, len(paths))
+	for _, p := range paths {
+		fetched, err := uc.retention.Fetch(ctx, p)
+		if err != nil {
+			// Once compressed, a file not written to anymore only lives on in its backups
+			if os.IsNotExist(err) && p == path && len(paths) > 1 {
+				continue
+			}
+			return nil, nil, err
+		}
+		local = append(local, fetched)
+		logical[fetched] = p
 	}
+	return local, logical, nil
+}
 
-	if page < 1 {
-		page = 1
+// archived returns the uploaded files under prefix whose local copy was deleted.
+func (uc *LogsUseCase) archived(ctx context.Context, prefix string) ([]*entity.LogArchive, error) {
+	archives, err := uc.retention.Archives(ctx, prefix)
+	if err != nil {
+		return nil, err
 	}
-	if page > totalPages {
-		page = totalPages
+	var deleted []*entity.LogArchive
+	for _, archive := range archives {
+		if !archive.Local {
+			deleted = append(deleted, archive)
+		}
 	}
+	return deleted, nil
+}
+
+func archivedLogFile(name string, archive *entity.LogArchive) LogFile {
+	return LogFile{Name: name, Size: archive.Size, LastModified: archive.ModifiedAt, Archived: true}
+}
+
+// systemLogPath checks that filename is a path inside the logs directory.
+func systemLogPath(filename string) (string, error) {
+	cleanPath := filepath.Clean(filename)
 
-	start := (page - 1) * pageSize
-	end := start + pageSize
-	if start > totalLines {
-		start = totalLines
+	if !filepath.HasPrefix(cleanPath, "logs"+string(os.PathSeparator)) && cleanPath != "logs" {
+		return "", fmt.Errorf("%w: must start with logs/", ErrInvalidLogPath)
 	}
-	if end > totalLines {
-		end = totalLines
+
+	if filepath.IsAbs(cleanPath) {
+		return "", fmt.Errorf("%w: absolute paths not allowed", ErrInvalidLogPath)
 	}
 
-	return lines[start:end], totalLines, totalPages, page, nil
+	// Verify it is inside logs directory
+	absLogs, _ := filepath.Abs("logs")
+	absPath, _ := filepath.Abs(cleanPath)
+	if !filepath.HasPrefix(absPath, absLogs) {
+		return "", fmt.Errorf("%w: access denied", ErrInvalidLogPath)
+	}
+
+	return cleanPath, nil
+}
+
+// plainName reports whether name can be joined to a path without leaving its directory.
+func plainName(name string) bool {
+	return name != "" && name != "." && name != ".." && filepath.Base(name) == name
 }
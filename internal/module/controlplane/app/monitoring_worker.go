@@ -5,8 +5,8 @@ import (
 	"time"
 
 	"github.com/zhinea/sylix/internal/common/logger"
-	"github.com/zhinea/sylix/internal/common/util"
 	"github.com/zhinea/sylix/internal/module/controlplane/domain/repository"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/services"
 	"github.com/zhinea/sylix/internal/module/controlplane/entity"
 	"go.uber.org/zap"
 )
@@ -14,12 +14,14 @@ import (
 type MonitoringWorker struct {
 	serverRepo     repository.ServerRepository
 	monitoringRepo repository.MonitoringRepository
+	remoteService  *services.RemoteService
 }
 
-func NewMonitoringWorker(serverRepo repository.ServerRepository, monitoringRepo repository.MonitoringRepository) *MonitoringWorker {
+func NewMonitoringWorker(serverRepo repository.ServerRepository, monitoringRepo repository.MonitoringRepository, remoteService *services.RemoteService) *MonitoringWorker {
 	return &MonitoringWorker{
 		serverRepo:     serverRepo,
 		monitoringRepo: monitoringRepo,
+		remoteService:  remoteService,
 	}
 }
 
@@ -65,22 +67,13 @@ func (w *MonitoringWorker) pingAllServers() {
 }
 
 func (w *MonitoringWorker) pingServer(ctx context.Context, server *entity.Server) {
-	// TODO: Implement monitoring via SSH or Docker API
-	// For now, we just check if we can SSH into the server
-	client, err := util.NewSSHClient(server.IpAddress, server.Port, server.Credential.Username, server.Credential.Password, server.Credential.SSHKey)
+	// Asks the agent for its health when it is installed, otherwise runs a trivial command over SSH
+	elapsed, err := w.remoteService.Ping(ctx, server)
 	if err != nil {
 		w.recordPingFailure(ctx, server, err.Error())
 		return
 	}
-	defer client.Close()
-
-	start := time.Now()
-	// Simple command to check responsiveness
-	if _, err := client.RunCommand("echo 'ping'"); err != nil {
-		w.recordPingFailure(ctx, server, err.Error())
-		return
-	}
-	duration := time.Since(start).Milliseconds()
+	duration := elapsed.Milliseconds()
 
 	w.monitoringRepo.SavePing(ctx, &entity.ServerPing{
 		ServerID:     server.Id,
@@ -2,9 +2,12 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/zhinea/sylix/internal/common/logger"
+	"github.com/zhinea/sylix/internal/common/util"
+	"github.com/zhinea/sylix/internal/common/workflow"
 	"github.com/zhinea/sylix/internal/module/controlplane/domain/repository"
 	"github.com/zhinea/sylix/internal/module/controlplane/domain/services"
 	"github.com/zhinea/sylix/internal/module/controlplane/entity"
@@ -15,17 +18,29 @@ type ServerUseCase struct {
 	repo              repository.ServerRepository
 	monitoringService *services.MonitoringService
 	nodeService       *services.NodeService
+	ipamService       *services.IPAMService
+	sshService        *services.SSHService
+	certService       *services.CertificateService
+	agentClients      *services.AgentClientService
 }
 
 func NewServerUseCase(
 	repo repository.ServerRepository,
 	monitoringService *services.MonitoringService,
 	nodeService *services.NodeService,
+	ipamService *services.IPAMService,
+	sshService *services.SSHService,
+	certService *services.CertificateService,
+	agentClients *services.AgentClientService,
 ) *ServerUseCase {
 	return &ServerUseCase{
 		repo:              repo,
 		monitoringService: monitoringService,
 		nodeService:       nodeService,
+		ipamService:       ipamService,
+		sshService:        sshService,
+		certService:       certService,
+		agentClients:      agentClients,
 	}
 }
 
@@ -34,7 +49,7 @@ func (uc *ServerUseCase) Create(ctx context.Context, server *entity.Server) (*en
 	server.Status = entity.ServerStatusDisconnected
 
 	// Check connection before creating
-	if err := uc.nodeService.CheckConnection(server); err == nil {
+	if err := uc.nodeService.CheckConnection(ctx, server); err == nil {
 		server.Status = entity.ServerStatusConnected
 	} else {
 		server.Status = entity.ServerStatusDisconnected
@@ -78,8 +93,26 @@ func (uc *ServerUseCase) Update(ctx context.Context, server *entity.Server) (*en
 		server.Credential.SSHKey = existing.Credential.SSHKey
 	}
 
+	// The pinned host key belongs to the address, a server moved elsewhere is pinned anew
+	if server.IpAddress == existing.IpAddress && server.Port == existing.Port {
+		server.HostKey = existing.HostKey
+	} else if err := uc.repo.UpdateHostKey(ctx, server.Id, ""); err != nil {
+		return nil, err
+	}
+
+	// Drop the pooled connection when it was made with the old address or credentials
+	if server.IpAddress != existing.IpAddress || server.Port != existing.Port ||
+		server.Credential.Username != existing.Credential.Username ||
+		!equalSecret(server.Credential.Password, existing.Credential.Password) ||
+		!equalSecret(server.Credential.SSHKey, existing.Credential.SSHKey) {
+		uc.sshService.Evict(server.Id)
+	}
+	if server.IpAddress != existing.IpAddress || server.Agent.Port != existing.Agent.Port {
+		uc.agentClients.Evict(server.Id)
+	}
+
 	// Check connection before updating
-	if err := uc.nodeService.CheckConnection(server); err == nil {
+	if err := uc.nodeService.CheckConnection(ctx, server); err == nil {
 		server.Status = entity.ServerStatusConnected
 	} else {
 		server.Status = entity.ServerStatusDisconnected
@@ -95,18 +128,52 @@ func (uc *ServerUseCase) RetryConnection(ctx context.Context, id string) (*entit
 		return nil, err
 	}
 
-	if err := uc.nodeService.CheckConnection(server); err == nil {
+	connErr := uc.nodeService.CheckConnection(ctx, server)
+	if connErr == nil {
 		server.Status = entity.ServerStatusConnected
 	} else {
 		server.Status = entity.ServerStatusDisconnected
-		logger.Log.Warn("Failed to connect to server during retry", zap.Error(err), zap.String("ip", server.IpAddress))
+		logger.Log.Warn("Failed to connect to server during retry", zap.Error(connErr), zap.String("ip", server.IpAddress))
+	}
+
+	updated, err := uc.repo.Update(ctx, server)
+	if err != nil {
+		return nil, err
+	}
+
+	// A changed host key is no transient failure, it stays until the new key is trusted explicitly
+	if errors.Is(connErr, util.ErrHostKeyMismatch) {
+		return updated, connErr
+	}
+	return updated, nil
+}
+
+// TrustHostKey pins the host key the server presents now in place of the old one, after a
+// legitimate reinstall of the server. The key must have the given fingerprint, the one the host key
+// mismatch reported.
+func (uc *ServerUseCase) TrustHostKey(ctx context.Context, id, fingerprint string) (*entity.Server, error) {
+	server, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.sshService.TrustHostKey(ctx, server, fingerprint); err != nil {
+		return nil, fmt.Errorf("failed to connect to server: %w", err)
 	}
 
+	server.Status = entity.ServerStatusConnected
 	return uc.repo.Update(ctx, server)
 }
 
 func (uc *ServerUseCase) Delete(ctx context.Context, id string) error {
-	return uc.repo.Delete(ctx, id)
+	if err := uc.repo.Delete(ctx, id); err != nil {
+		return err
+	}
+	uc.sshService.Evict(id)
+	uc.agentClients.Evict(id)
+
+	// Free the WireGuard address so it can be leased to another server
+	return uc.ipamService.Release(ctx, id)
 }
 
 func (uc *ServerUseCase) ProvisionNode(ctx context.Context, server *entity.Server) error {
@@ -114,12 +181,46 @@ func (uc *ServerUseCase) ProvisionNode(ctx context.Context, server *entity.Serve
 		return fmt.Errorf("server must be connected to provision node")
 	}
 
+	// The agent serves with a certificate from the cluster CA, issued for the server's address
+	issued, err := uc.certService.IssueServerCertificate(server)
+	if err != nil {
+		return err
+	}
+	if issued {
+		if _, err := uc.repo.Update(ctx, server); err != nil {
+			return fmt.Errorf("failed to save agent certificate: %w", err)
+		}
+		uc.agentClients.Evict(server.Id)
+	}
+
 	// Start async provisioning
 	go uc.nodeService.Install(context.Background(), server)
 
 	return nil
 }
 
+// PlanProvisionNode returns what installing the agent would do on the server, without doing it.
+func (uc *ServerUseCase) PlanProvisionNode(ctx context.Context, server *entity.Server) (*workflow.Plan, error) {
+	if server.Status != entity.ServerStatusConnected {
+		return nil, fmt.Errorf("server must be connected to provision node")
+	}
+	return uc.nodeService.PlanInstall(ctx, server)
+}
+
+// SyncMesh pushes the current WireGuard mesh to every provisioned server and waits for the report.
+func (uc *ServerUseCase) SyncMesh(ctx context.Context) (*entity.MeshSyncReport, error) {
+	return uc.nodeService.SyncMesh(ctx)
+}
+
+func (uc *ServerUseCase) GetMeshSyncReport(ctx context.Context) (*entity.MeshSyncReport, error) {
+	return uc.nodeService.LatestMeshSync(ctx)
+}
+
+// GetConnectionPoolStats returns a snapshot of the pooled SSH connections to managed servers.
+func (uc *ServerUseCase) GetConnectionPoolStats() util.SSHPoolStats {
+	return uc.sshService.PoolStats()
+}
+
 func (uc *ServerUseCase) GetStats(ctx context.Context, serverID string) ([]*entity.ServerStat, error) {
 	return uc.monitoringService.GetStats(ctx, serverID)
 }
@@ -127,3 +228,10 @@ func (uc *ServerUseCase) GetStats(ctx context.Context, serverID string) ([]*enti
 func (uc *ServerUseCase) GetRealtimeStats(ctx context.Context, serverID string, limit int) ([]*entity.ServerPing, error) {
 	return uc.monitoringService.GetRealtimeStats(ctx, serverID, limit)
 }
+
+func equalSecret(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,369 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/zhinea/sylix/internal/common/logger"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/nodetype"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/repository"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/services"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/topology"
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrServiceNodeHasNoContainer = errors.New("service has no container")
+	ErrServiceLogsTooFarBack     = errors.New("page is too far back in the logs")
+	ErrServiceParentChanged      = errors.New("service cannot be moved to another parent")
+)
+
+const (
+	DefaultServiceLogLimit = 100
+	MaxServiceLogLimit     = 1000
+	// MaxServiceLogLines bounds how far back pages of container logs reach
+	MaxServiceLogLines = 10000
+)
+
+// ServiceLogsPage is a page of the container logs of a service, page 1 holding the latest lines.
+type ServiceLogsPage struct {
+	Node    *entity.ServiceNode
+	Lines   []string
+	Page    int
+	HasMore bool
+}
+
+type ServicesUseCase struct {
+	repo             repository.ServiceNodeRepository
+	serverRepo       repository.ServerRepository
+	backupRepo       repository.BackupStorageRepository
+	registry         *nodetype.Registry
+	containerService *services.ContainerService
+	topologyService  *services.TopologyService
+}
+
+func NewServicesUseCase(
+	repo repository.ServiceNodeRepository,
+	serverRepo repository.ServerRepository,
+	backupRepo repository.BackupStorageRepository,
+	registry *nodetype.Registry,
+	containerService *services.ContainerService,
+	topologyService *services.TopologyService,
+) *ServicesUseCase {
+	return &ServicesUseCase{
+		repo:             repo,
+		serverRepo:       serverRepo,
+		backupRepo:       backupRepo,
+		registry:         registry,
+		containerService: containerService,
+		topologyService:  topologyService,
+	}
+}
+
+// NodeTypes returns the node type catalog.
+func (uc *ServicesUseCase) NodeTypes() *nodetype.Registry {
+	return uc.registry
+}
+
+// RelationExists reports whether the record referenced by a catalog relation field exists.
+func (uc *ServicesUseCase) RelationExists(ctx context.Context, table, id string) (bool, error) {
+	var err error
+	switch table {
+	case nodetype.RelatedTableServers:
+		_, err = uc.serverRepo.GetByID(ctx, id)
+	case nodetype.RelatedTableBackupStorages:
+		_, err = uc.backupRepo.GetByID(ctx, id)
+	default:
+		return false, fmt.Errorf("unsupported related table %q", table)
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func (uc *ServicesUseCase) GetAll(ctx context.Context, query repository.ServiceNodeQuery) ([]*entity.ServiceNode, error) {
+	return uc.repo.GetAll(ctx, query)
+}
+
+func (uc *ServicesUseCase) Get(ctx context.Context, id string) (*entity.ServiceNode, error) {
+	return uc.repo.GetByID(ctx, id)
+}
+
+func (uc *ServicesUseCase) Create(ctx context.Context, node *entity.ServiceNode) (*entity.ServiceNode, error) {
+	if err := uc.checkRelations(ctx, node); err != nil {
+		return nil, err
+	}
+
+	node.Id = ""
+	node.Status = entity.ServiceStatusOffline
+	node.Container = entity.ServiceContainer{}
+	uc.applyDefaults(node)
+
+	return uc.repo.Create(ctx, node)
+}
+
+func (uc *ServicesUseCase) Update(ctx context.Context, node *entity.ServiceNode) (*entity.ServiceNode, error) {
+	existing, err := uc.repo.GetByID(ctx, node.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// The edges of a node and its ordinal belong to its cluster, so it stays in it
+	if parentID(node) != parentID(existing) {
+		return nil, ErrServiceParentChanged
+	}
+
+	if err := uc.checkRelations(ctx, node); err != nil {
+		return nil, err
+	}
+
+	// Runtime state is owned by the controlplane, not by the caller
+	node.CreatedAt = existing.CreatedAt
+	node.Status = existing.Status
+	node.Container = existing.Container
+	node.Ports = existing.Ports
+	node.Ordinal = existing.Ordinal
+	uc.applyDefaults(node)
+
+	return uc.repo.Update(ctx, node)
+}
+
+func (uc *ServicesUseCase) Delete(ctx context.Context, id string) error {
+	node, err := uc.repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	// Remove containers of the node and of its children before dropping the records
+	for _, n := range append([]*entity.ServiceNode{node}, node.Nodes...) {
+		if n.ContainerRef() == "" {
+			continue
+		}
+		if err := uc.containerService.Remove(ctx, n.ServerID, n.ContainerRef()); err != nil {
+			logger.Log.Warn("Failed to remove service container", zap.Error(err), zap.String("service_id", n.Id))
+		}
+	}
+
+	return uc.repo.Delete(ctx, id)
+}
+
+// TakeActions applies the action to every service and returns the ones that succeeded,
+// together with a per-service error for the ones that did not.
+func (uc *ServicesUseCase) TakeActions(ctx context.Context, action int, ids []string) ([]*entity.ServiceNode, map[string]error, error) {
+	nodes, err := uc.repo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	failures := make(map[string]error)
+	found := make(map[string]bool)
+	var updated []*entity.ServiceNode
+
+	for _, node := range nodes {
+		found[node.Id] = true
+
+		if err := uc.takeAction(ctx, node, action); err != nil {
+			failures[node.Id] = err
+			continue
+		}
+		updated = append(updated, node)
+	}
+
+	for _, id := range ids {
+		if !found[id] {
+			failures[id] = fmt.Errorf("service not found")
+		}
+	}
+
+	return updated, failures, nil
+}
+
+func (uc *ServicesUseCase) takeAction(ctx context.Context, node *entity.ServiceNode, action int) error {
+	container := node.ContainerRef()
+	if container == "" {
+		return ErrServiceNodeHasNoContainer
+	}
+
+	var (
+		err    error
+		status int
+	)
+	switch action {
+	case entity.ServiceActionStart:
+		err = uc.containerService.Start(ctx, node.ServerID, container)
+		status = entity.ServiceStatusRunning
+	case entity.ServiceActionStop:
+		err = uc.containerService.Stop(ctx, node.ServerID, container)
+		status = entity.ServiceStatusOffline
+	case entity.ServiceActionRestart:
+		err = uc.containerService.Restart(ctx, node.ServerID, container)
+		status = entity.ServiceStatusRunning
+	default:
+		return fmt.Errorf("unknown action: %d", action)
+	}
+
+	if err != nil {
+		status = entity.ServiceStatusError
+	}
+
+	node.Status = status
+	if updateErr := uc.repo.UpdateStatus(ctx, node.Id, status); updateErr != nil {
+		logger.Log.Error("Failed to update service status", zap.Error(updateErr), zap.String("service_id", node.Id))
+	}
+
+	return err
+}
+
+// GetLogs returns a page of the container logs of the service, counting back from the latest line.
+// since and until narrow the logs down as docker logs takes them.
+func (uc *ServicesUseCase) GetLogs(ctx context.Context, serviceID string, page, limit int, since, until string) (*ServiceLogsPage, error) {
+	node, err := uc.repo.GetByID(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+	container := node.ContainerRef()
+	if container == "" {
+		return nil, ErrServiceNodeHasNoContainer
+	}
+
+	limit = serviceLogLimit(limit)
+	page = max(page, 1)
+	tail := page * limit
+	if tail > MaxServiceLogLines {
+		return nil, ErrServiceLogsTooFarBack
+	}
+
+	// The page is the oldest limit lines of the last page*limit
+	lines, err := uc.containerService.Logs(ctx, node.ServerID, container, services.ContainerLogsQuery{
+		Tail:  tail,
+		Since: since,
+		Until: until,
+	})
+	if err != nil {
+		return nil, err
+	}
+	end := max(len(lines)-(page-1)*limit, 0)
+	start := max(end-limit, 0)
+
+	return &ServiceLogsPage{
+		Node:    node,
+		Lines:   lines[start:end],
+		Page:    page,
+		HasMore: len(lines) >= tail,
+	}, nil
+}
+
+// FollowLogs passes the last limit lines of the container logs of the service to fn, then the
+// lines written after them, until ctx is done or the container stops.
+func (uc *ServicesUseCase) FollowLogs(ctx context.Context, serviceID string, limit int, fn func(stream, line string) error) error {
+	node, err := uc.repo.GetByID(ctx, serviceID)
+	if err != nil {
+		return err
+	}
+	container := node.ContainerRef()
+	if container == "" {
+		return ErrServiceNodeHasNoContainer
+	}
+
+	return uc.containerService.FollowLogs(ctx, node.ServerID, container, serviceLogLimit(limit), fn)
+}
+
+func serviceLogLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultServiceLogLimit
+	}
+	return min(limit, MaxServiceLogLimit)
+}
+
+func (uc *ServicesUseCase) Connect(ctx context.Context, sourceID, targetID string) (*entity.ServiceEdge, error) {
+	return uc.topologyService.Connect(ctx, sourceID, targetID)
+}
+
+func (uc *ServicesUseCase) Disconnect(ctx context.Context, sourceID, targetID string) error {
+	return uc.topologyService.Disconnect(ctx, sourceID, targetID)
+}
+
+// Topology returns the edges of a cluster together with the resolved imports of its nodes.
+func (uc *ServicesUseCase) Topology(ctx context.Context, clusterID string) ([]*entity.ServiceEdge, *topology.Resolution, error) {
+	graph, edges, err := uc.topologyService.Graph(ctx, clusterID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resolution, err := uc.topologyService.Resolve(ctx, graph)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return edges, resolution, nil
+}
+
+// applyDefaults fills in catalog defaults for missing fields and stamps every field with its catalog type.
+func (uc *ServicesUseCase) applyDefaults(node *entity.ServiceNode) {
+	if node.Type != entity.ServiceTypeNode {
+		return
+	}
+	nodeType, ok := uc.registry.Get(node.App.Service)
+	if !ok {
+		return
+	}
+
+	present := make(map[string]bool, len(node.Fields))
+	for _, field := range node.Fields {
+		present[field.Key] = true
+		if def, ok := nodeType.Fields[field.Key]; ok {
+			field.Type = def.Type
+		}
+	}
+
+	for _, key := range nodeType.FieldKeys() {
+		def := nodeType.Fields[key]
+		if present[key] || def.Default == "" {
+			continue
+		}
+		node.Fields = append(node.Fields, &entity.ServiceNodeField{
+			Key:   key,
+			Value: def.Default,
+			Type:  def.Type,
+		})
+	}
+}
+
+// parentID returns the ID of the cluster of node, empty when it has none.
+func parentID(node *entity.ServiceNode) string {
+	if node.ParentID == nil {
+		return ""
+	}
+	return *node.ParentID
+}
+
+func (uc *ServicesUseCase) checkRelations(ctx context.Context, node *entity.ServiceNode) error {
+	if node.ServerID != "" {
+		if _, err := uc.serverRepo.GetByID(ctx, node.ServerID); err != nil {
+			return fmt.Errorf("server not found: %w", err)
+		}
+	}
+
+	if node.ParentID != nil {
+		if *node.ParentID == node.Id {
+			return fmt.Errorf("service cannot be its own parent")
+		}
+		parent, err := uc.repo.GetByID(ctx, *node.ParentID)
+		if err != nil {
+			return fmt.Errorf("parent service not found: %w", err)
+		}
+		if parent.Type != entity.ServiceTypeCluster {
+			return fmt.Errorf("parent service must be a cluster")
+		}
+	}
+
+	return nil
+}
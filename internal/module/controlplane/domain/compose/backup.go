@@ -0,0 +1,60 @@
+package compose
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/zhinea/sylix/internal/common/util"
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+)
+
+// backupTimeFormat stamps the keys of backups, so they sort by the time they were taken.
+const backupTimeFormat = "20060102T150405Z"
+
+// Backup is how the data of a node is backed up: Command runs on the node's server and writes a
+// gzipped tarball of it to stdout.
+type Backup struct {
+	Kind    string
+	Command string
+}
+
+// NodeBackup returns how the node's data is backed up from its running container. Safekeepers
+// and pageservers have their data directory archived, the WAL and the layer files they have not
+// offloaded yet included, and computes are backed up with pg_basebackup.
+func NodeBackup(node *entity.ServiceNode) (*Backup, error) {
+	container := node.ContainerRef()
+	if container == "" {
+		return nil, fmt.Errorf("%s has no container to back up", node.Name)
+	}
+
+	// Files written while they are archived only make tar warn, exiting with 1
+	archive := func(dir string) string {
+		script := fmt.Sprintf("tar --warning=no-file-changed -czf - -C %s . || [ $? -eq 1 ]", dir)
+		return fmt.Sprintf("docker exec %s sh -c %s", util.ShellQuote(container), util.ShellQuote(script))
+	}
+
+	switch node.App.Service {
+	case TypeSafekeeper:
+		return &Backup{Kind: entity.BackupKindWAL, Command: archive("/data")}, nil
+	case TypePageserver:
+		return &Backup{Kind: entity.BackupKindLayers, Command: archive("/data/.neon")}, nil
+	case TypeCompute:
+		// Tar format on stdout only fits a single tablespace, with the WAL fetched into it
+		cmd := fmt.Sprintf("docker exec %s pg_basebackup -h 127.0.0.1 -p %s -U cloud_admin -D - -Ft -z -X fetch -c fast",
+			util.ShellQuote(container), strconv.Itoa(computePgPort))
+		return &Backup{Kind: entity.BackupKindBasebackup, Command: cmd}, nil
+	default:
+		return nil, fmt.Errorf("node type %q cannot be backed up", node.App.Service)
+	}
+}
+
+// BackupKey is where a backup of the node taken at the given time goes in the bucket, next to
+// what the cluster's nodes offload there: sylix/<cluster>/backups/<type>/<node>/<kind>/<time>.tar.gz.
+func BackupKey(node *entity.ServiceNode, kind string, at time.Time) (string, error) {
+	if node.ParentID == nil {
+		return "", fmt.Errorf("%s is not part of a cluster", node.Name)
+	}
+	return fmt.Sprintf("sylix/%s/backups/%s/%s/%s/%s.tar.gz",
+		*node.ParentID, node.App.Service, node.Id, kind, at.UTC().Format(backupTimeFormat)), nil
+}
@@ -0,0 +1,76 @@
+package compose
+
+import (
+	"bytes"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// File is the subset of the Compose specification the renderer emits.
+type File struct {
+	Name     string              `yaml:"name"`
+	Services map[string]*Service `yaml:"services"`
+	Networks map[string]*Network `yaml:"networks,omitempty"`
+	Volumes  map[string]*Volume  `yaml:"volumes,omitempty"`
+	Configs  map[string]*Config  `yaml:"configs,omitempty"`
+}
+
+type Service struct {
+	Image         string                `yaml:"image"`
+	ContainerName string                `yaml:"container_name"`
+	Hostname      string                `yaml:"hostname,omitempty"`
+	Restart       string                `yaml:"restart"`
+	Entrypoint    []string              `yaml:"entrypoint,omitempty"`
+	Command       []string              `yaml:"command,omitempty"`
+	Environment   map[string]string     `yaml:"environment,omitempty"`
+	Ports         []string              `yaml:"ports,omitempty"`
+	Volumes       []string              `yaml:"volumes,omitempty"`
+	Configs       []ServiceConfig       `yaml:"configs,omitempty"`
+	Networks      []string              `yaml:"networks,omitempty"`
+	DependsOn     map[string]Dependency `yaml:"depends_on,omitempty"`
+	Healthcheck   *Healthcheck          `yaml:"healthcheck,omitempty"`
+	Labels        map[string]string     `yaml:"labels,omitempty"`
+}
+
+type ServiceConfig struct {
+	Source string `yaml:"source"`
+	Target string `yaml:"target"`
+}
+
+type Dependency struct {
+	Condition string `yaml:"condition"`
+}
+
+type Healthcheck struct {
+	Test        []string `yaml:"test"`
+	Interval    string   `yaml:"interval"`
+	Timeout     string   `yaml:"timeout"`
+	Retries     int      `yaml:"retries"`
+	StartPeriod string   `yaml:"start_period"`
+}
+
+type Network struct {
+	Driver string `yaml:"driver"`
+}
+
+type Volume struct{}
+
+// Config is an inline config file, mounted into services through ServiceConfig.
+type Config struct {
+	Content string `yaml:"content"`
+}
+
+// Marshal encodes the file as YAML. Maps are emitted with sorted keys, so equal files encode identically.
+func (f *File) Marshal() ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(f); err != nil {
+		return nil, fmt.Errorf("failed to encode compose file: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode compose file: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,486 @@
+package compose
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/topology"
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+)
+
+// NeonDB node types
+const (
+	TypeStorageBroker = "storage_broker"
+	TypeSafekeeper    = "safekeeper"
+	TypePageserver    = "pageserver"
+	TypeCompute       = "compute"
+)
+
+const (
+	neonImage        = "ghcr.io/neondatabase/neon"
+	computeImage     = "ghcr.io/neondatabase/compute-node-v%d"
+	defaultImageTag  = "latest"
+	brokerPort       = 50051
+	safekeeperPgPort = 5454
+	safekeeperHTTP   = 7676
+	pageserverPgPort = 6400
+	pageserverHTTP   = 9898
+	computePgPort    = 55433
+	computeHTTPPort  = 3080
+)
+
+// builder renders the compose service of a single node.
+type builder struct {
+	cluster *Cluster
+	project *Project
+	node    *entity.ServiceNode
+}
+
+func (b *builder) build() (*Service, error) {
+	var (
+		service *Service
+		err     error
+	)
+
+	switch b.node.App.Service {
+	case TypeStorageBroker:
+		service = b.storageBroker()
+	case TypeSafekeeper:
+		service, err = b.safekeeper()
+	case TypePageserver:
+		service, err = b.pageserver()
+	case TypeCompute:
+		service, err = b.compute()
+	default:
+		err = fmt.Errorf("no compose template for node type %q", b.node.App.Service)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	service.ContainerName = ContainerName(b.node)
+	service.Hostname = ServiceName(b.node)
+	service.Restart = "unless-stopped"
+	service.Networks = []string{networkName}
+	service.Labels = map[string]string{
+		"sylix.cluster-id":   b.cluster.ID,
+		"sylix.service-id":   b.node.Id,
+		"sylix.service-type": b.node.App.Service,
+	}
+
+	ports, err := b.ports()
+	if err != nil {
+		return nil, err
+	}
+	service.Ports = ports
+
+	return service, nil
+}
+
+func (b *builder) storageBroker() *Service {
+	return &Service{
+		Image:       b.neonImage(),
+		Command:     []string{"storage_broker", fmt.Sprintf("--listen-addr=0.0.0.0:%d", brokerPort)},
+		Healthcheck: tcpCheck(brokerPort),
+	}
+}
+
+func (b *builder) safekeeper() (*Service, error) {
+	storage, err := b.storage()
+	if err != nil {
+		return nil, err
+	}
+	broker, err := b.upstream(TypeStorageBroker, brokerPort)
+	if err != nil {
+		return nil, err
+	}
+	advertise, err := b.self(safekeeperPgPort)
+	if err != nil {
+		return nil, err
+	}
+	id, err := b.nodeID()
+	if err != nil {
+		return nil, err
+	}
+
+	volume := b.volume("data")
+
+	return &Service{
+		Image: b.neonImage(),
+		Command: []string{
+			"safekeeper",
+			fmt.Sprintf("--listen-pg=0.0.0.0:%d", safekeeperPgPort),
+			fmt.Sprintf("--listen-http=0.0.0.0:%d", safekeeperHTTP),
+			"--advertise-pg=" + advertise,
+			"--id=" + strconv.Itoa(id),
+			"--broker-endpoint=http://" + broker,
+			"-D", "/data",
+			"--remote-storage=" + remoteStorage(storage, b.prefix()),
+		},
+		Environment: storageEnv(storage),
+		Volumes:     []string{volume + ":/data"},
+		Healthcheck: httpCheck(safekeeperHTTP, "/v1/status"),
+	}, nil
+}
+
+func (b *builder) pageserver() (*Service, error) {
+	storage, err := b.storage()
+	if err != nil {
+		return nil, err
+	}
+	broker, err := b.upstream(TypeStorageBroker, brokerPort)
+	if err != nil {
+		return nil, err
+	}
+	id, err := b.nodeID()
+	if err != nil {
+		return nil, err
+	}
+
+	config := strings.Join([]string{
+		fmt.Sprintf("broker_endpoint='http://%s'", broker),
+		"pg_distrib_dir='/usr/local/'",
+		fmt.Sprintf("listen_pg_addr='0.0.0.0:%d'", pageserverPgPort),
+		fmt.Sprintf("listen_http_addr='0.0.0.0:%d'", pageserverHTTP),
+		"remote_storage=" + remoteStorage(storage, b.prefix()),
+		"control_plane_emergency_mode=true",
+	}, "\n") + "\n"
+
+	volume := b.volume("data")
+
+	return &Service{
+		Image:       b.neonImage(),
+		Command:     []string{"pageserver", "-D", "/data/.neon"},
+		Environment: storageEnv(storage),
+		Volumes:     []string{volume + ":/data"},
+		Configs: []ServiceConfig{
+			{Source: b.config("config", config), Target: "/data/.neon/pageserver.toml"},
+			{Source: b.config("identity", fmt.Sprintf("id=%d\n", id)), Target: "/data/.neon/identity.toml"},
+		},
+		Healthcheck: httpCheck(pageserverHTTP, "/v1/status"),
+	}, nil
+}
+
+func (b *builder) compute() (*Service, error) {
+	version, err := pgVersion(b.node.Field("pg_version"))
+	if err != nil {
+		return nil, err
+	}
+	pageserverPg, err := b.upstream(TypePageserver, pageserverPgPort)
+	if err != nil {
+		return nil, err
+	}
+	pageserverAPI, err := b.upstream(TypePageserver, pageserverHTTP)
+	if err != nil {
+		return nil, err
+	}
+	safekeepers, err := b.upstreams(TypeSafekeeper, safekeeperPgPort)
+	if err != nil {
+		return nil, err
+	}
+
+	tenantID := hexID(b.cluster.ID)
+	timelineID := hexID(b.node.Id)
+	pageserverConn := fmt.Sprintf("postgresql://no_user@%s", pageserverPg)
+
+	spec := computeConfig{
+		Spec: computeSpec{
+			FormatVersion: 1.0,
+			Cluster: computeCluster{
+				ClusterID: b.cluster.ID,
+				Name:      b.cluster.Name,
+				State:     "restarted",
+				Roles:     []struct{}{},
+				Databases: []struct{}{},
+				Settings: []computeSetting{
+					{"port", strconv.Itoa(computePgPort), "integer"},
+					{"listen_addresses", "0.0.0.0", "string"},
+					{"max_connections", "100", "integer"},
+					{"wal_level", "replica", "enum"},
+					{"wal_log_hints", "on", "bool"},
+					{"max_replication_slots", "10", "integer"},
+					{"max_wal_senders", "10", "integer"},
+					{"shared_preload_libraries", "neon", "string"},
+					{"synchronous_standby_names", "walproposer", "string"},
+					{"neon.tenant_id", tenantID, "string"},
+					{"neon.timeline_id", timelineID, "string"},
+					{"neon.pageserver_connstring", pageserverConn, "string"},
+					{"neon.safekeepers", strings.Join(safekeepers, ","), "string"},
+				},
+			},
+			DeltaOperations:       []struct{}{},
+			TenantID:              tenantID,
+			TimelineID:            timelineID,
+			PageserverConnstring:  pageserverConn,
+			SafekeeperConnstrings: safekeepers,
+		},
+	}
+	spec.ComputeCtlConfig.JWKS.Keys = []struct{}{}
+
+	specJSON, err := json.MarshalIndent(spec, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode compute spec: %w", err)
+	}
+
+	service := &Service{
+		Image:      fmt.Sprintf(computeImage+":%s", version, b.imageTag()),
+		Entrypoint: []string{"/bin/bash", "/shell/compute.sh"},
+		Environment: map[string]string{
+			"COMPUTE_ID":      b.node.Id,
+			"PAGESERVER_HTTP": "http://" + pageserverAPI,
+			"PG_VERSION":      strconv.Itoa(version),
+			"TENANT_ID":       tenantID,
+			"TIMELINE_ID":     timelineID,
+		},
+		Configs: []ServiceConfig{
+			{Source: b.config("script", computeScript), Target: "/shell/compute.sh"},
+			{Source: b.config("spec", string(specJSON)+"\n"), Target: "/var/db/postgres/specs/config.json"},
+		},
+		Healthcheck: &Healthcheck{
+			Test:        []string{"CMD-SHELL", fmt.Sprintf("pg_isready -h 127.0.0.1 -p %d -U cloud_admin || exit 1", computePgPort)},
+			Interval:    "10s",
+			Timeout:     "5s",
+			Retries:     30,
+			StartPeriod: "30s",
+		},
+	}
+
+	// a co-located pageserver is started by the same project, wait for it
+	for _, pageserver := range b.cluster.Graph.NeighborsOfType(b.node.Id, TypePageserver) {
+		if b.project.Has(pageserver.Id) {
+			service.DependsOn = map[string]Dependency{
+				ServiceName(pageserver): {Condition: "service_healthy"},
+			}
+		}
+	}
+
+	return service, nil
+}
+
+// ports publishes every OUT port of the node. Ports not exposed to the internet are bound to the
+// server's WireGuard address, when it has one.
+func (b *builder) ports() ([]string, error) {
+	server, ok := b.cluster.Servers[b.node.ServerID]
+	if !ok {
+		return nil, fmt.Errorf("server %s not found", b.node.ServerID)
+	}
+
+	var ports []string
+	for _, port := range b.node.Ports {
+		if port.Type != entity.ServicePortTypeOut {
+			continue
+		}
+		if port.PublishedPort == 0 {
+			return nil, fmt.Errorf("port %d/%s has no published port", port.Port, port.Protocol)
+		}
+
+		binding := fmt.Sprintf("%d:%d/%s", port.PublishedPort, port.Port, port.Protocol)
+		if !port.EnabledExpose && server.InternalIP != "" {
+			binding = server.InternalIP + ":" + binding
+		}
+		ports = append(ports, binding)
+	}
+	return ports, nil
+}
+
+// upstream returns the single host:port the node imports port from a peer type on.
+func (b *builder) upstream(from string, port int) (string, error) {
+	upstreams, err := b.upstreams(from, port)
+	if err != nil {
+		return "", err
+	}
+	return upstreams[0], nil
+}
+
+// upstreams returns every host:port the node imports port from a peer type on. Peers in the same
+// project are addressed through the project network instead of their published port.
+func (b *builder) upstreams(from string, port int) ([]string, error) {
+	endpoints := b.cluster.Resolution.Upstreams(b.node.Id, from, port)
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("no %s resolved for port %d", from, port)
+	}
+
+	addresses := make([]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		if peer, ok := b.cluster.Graph.Node(endpoint.ServiceID); ok && b.project.Has(peer.Id) {
+			addresses = append(addresses, fmt.Sprintf("%s:%d", ServiceName(peer), port))
+			continue
+		}
+		addresses = append(addresses, fmt.Sprintf("%s:%d", endpoint.Host, endpoint.Port))
+	}
+	return addresses, nil
+}
+
+// self returns the host:port other nodes reach the given port of this node on.
+func (b *builder) self(port int) (string, error) {
+	server, ok := b.cluster.Servers[b.node.ServerID]
+	if !ok {
+		return "", fmt.Errorf("server %s not found", b.node.ServerID)
+	}
+	out := b.node.OutPort(port, "tcp")
+	if out == nil || out.PublishedPort == 0 {
+		return "", fmt.Errorf("port %d/tcp has no published port", port)
+	}
+	return fmt.Sprintf("%s:%d", topology.Host(server), out.PublishedPort), nil
+}
+
+func (b *builder) storage() (*entity.BackupStorage, error) {
+	id := b.node.Field("backup_storage_id")
+	storage, ok := b.cluster.Storages[id]
+	if !ok {
+		return nil, fmt.Errorf("backup storage %q not found", id)
+	}
+	return storage, nil
+}
+
+// nodeID is the neon node id of the node, its ordinal, so it stays the same when other nodes of
+// its type are deleted.
+func (b *builder) nodeID() (int, error) {
+	if b.node.Ordinal == 0 {
+		return 0, fmt.Errorf("%s has no ordinal to use as node id", b.node.Name)
+	}
+	return b.node.Ordinal, nil
+}
+
+// prefix is where the node offloads to in the bucket, shared by all nodes of a type in the cluster.
+func (b *builder) prefix() string {
+	return fmt.Sprintf("sylix/%s/%s/", b.cluster.ID, b.node.App.Service)
+}
+
+func (b *builder) volume(name string) string {
+	volume := ServiceName(b.node) + "-" + name
+	if b.project.File.Volumes == nil {
+		b.project.File.Volumes = make(map[string]*Volume)
+	}
+	b.project.File.Volumes[volume] = &Volume{}
+	return volume
+}
+
+func (b *builder) config(name, content string) string {
+	config := ServiceName(b.node) + "-" + name
+	if b.project.File.Configs == nil {
+		b.project.File.Configs = make(map[string]*Config)
+	}
+	b.project.File.Configs[config] = &Config{Content: content}
+	return config
+}
+
+func (b *builder) imageTag() string {
+	if b.node.App.Version != "" {
+		return b.node.App.Version
+	}
+	return defaultImageTag
+}
+
+func (b *builder) neonImage() string {
+	return neonImage + ":" + b.imageTag()
+}
+
+// pgVersion turns a pg_version option such as postgres-17 into its major version.
+func pgVersion(option string) (int, error) {
+	version, err := strconv.Atoi(strings.TrimPrefix(option, "postgres-"))
+	if err != nil {
+		return 0, fmt.Errorf("invalid pg_version %q", option)
+	}
+	return version, nil
+}
+
+func remoteStorage(storage *entity.BackupStorage, prefix string) string {
+	endpoint := storage.Endpoint
+	if !strings.HasPrefix(endpoint, "http://") && !strings.HasPrefix(endpoint, "https://") {
+		endpoint = "https://" + endpoint
+	}
+	return fmt.Sprintf("{endpoint='%s', bucket_name='%s', bucket_region='%s', prefix_in_bucket='%s'}",
+		endpoint, storage.Bucket, storage.Region, prefix)
+}
+
+func storageEnv(storage *entity.BackupStorage) map[string]string {
+	return map[string]string{
+		"AWS_ACCESS_KEY_ID":     storage.AccessKey,
+		"AWS_SECRET_ACCESS_KEY": storage.SecretKey,
+	}
+}
+
+func httpCheck(port int, path string) *Healthcheck {
+	return &Healthcheck{
+		Test:        []string{"CMD-SHELL", fmt.Sprintf("curl -sf http://127.0.0.1:%d%s > /dev/null || exit 1", port, path)},
+		Interval:    "10s",
+		Timeout:     "5s",
+		Retries:     30,
+		StartPeriod: "10s",
+	}
+}
+
+func tcpCheck(port int) *Healthcheck {
+	return &Healthcheck{
+		Test:        []string{"CMD-SHELL", fmt.Sprintf("bash -c 'exec 3<>/dev/tcp/127.0.0.1/%d' || exit 1", port)},
+		Interval:    "10s",
+		Timeout:     "5s",
+		Retries:     30,
+		StartPeriod: "10s",
+	}
+}
+
+type computeConfig struct {
+	Spec             computeSpec `json:"spec"`
+	ComputeCtlConfig struct {
+		JWKS struct {
+			Keys []struct{} `json:"keys"`
+		} `json:"jwks"`
+	} `json:"compute_ctl_config"`
+}
+
+type computeSpec struct {
+	FormatVersion         float64        `json:"format_version"`
+	Cluster               computeCluster `json:"cluster"`
+	DeltaOperations       []struct{}     `json:"delta_operations"`
+	TenantID              string         `json:"tenant_id"`
+	TimelineID            string         `json:"timeline_id"`
+	PageserverConnstring  string         `json:"pageserver_connstring"`
+	SafekeeperConnstrings []string       `json:"safekeeper_connstrings"`
+}
+
+type computeCluster struct {
+	ClusterID string           `json:"cluster_id"`
+	Name      string           `json:"name"`
+	State     string           `json:"state"`
+	Roles     []struct{}       `json:"roles"`
+	Databases []struct{}       `json:"databases"`
+	Settings  []computeSetting `json:"settings"`
+}
+
+type computeSetting struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	VarType string `json:"vartype"`
+}
+
+// computeScript attaches the tenant and creates the timeline on the pageserver before starting
+// compute_ctl, both are no-ops when they already exist.
+const computeScript = `#!/bin/bash
+set -eu
+
+until curl -sf "${PAGESERVER_HTTP}/v1/status" > /dev/null; do
+  echo "Waiting for pageserver at ${PAGESERVER_HTTP}"
+  sleep 1
+done
+
+curl -sf -X PUT -H "Content-Type: application/json" \
+  -d '{"mode": "AttachedSingle", "generation": 1, "tenant_conf": {}}' \
+  "${PAGESERVER_HTTP}/v1/tenant/${TENANT_ID}/location_config" > /dev/null
+
+if ! curl -sf "${PAGESERVER_HTTP}/v1/tenant/${TENANT_ID}/timeline/${TIMELINE_ID}" > /dev/null; then
+  curl -sf -X POST -H "Content-Type: application/json" \
+    -d "{\"new_timeline_id\": \"${TIMELINE_ID}\", \"pg_version\": ${PG_VERSION}}" \
+    "${PAGESERVER_HTTP}/v1/tenant/${TENANT_ID}/timeline/" > /dev/null
+fi
+
+exec /usr/local/bin/compute_ctl \
+  --pgdata /var/db/postgres/compute \
+  -C "postgresql://cloud_admin@localhost:55433/postgres" \
+  -b /usr/local/bin/postgres \
+  --compute-id "${COMPUTE_ID}" \
+  --config /var/db/postgres/specs/config.json
+`
@@ -0,0 +1,79 @@
+package compose
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/nodetype"
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+)
+
+// PortRequest asks for a host port on a server to publish a container port of a service on.
+// When Preferred is set, exactly that port must be handed out. Current is the port the service
+// published on before, which should be kept when possible.
+type PortRequest struct {
+	ServerID      string
+	ServiceID     string
+	ContainerPort int
+	Protocol      string
+	Preferred     int
+	Current       int
+}
+
+// PortAllocator hands out host ports on servers.
+type PortAllocator interface {
+	Allocate(ctx context.Context, req PortRequest) (int, error)
+}
+
+// AssignPorts makes sure every port the node type exports has an OUT port with a published host port.
+// Ports that were published before are kept when the allocator allows it, so re-rendering a node
+// does not move it.
+func AssignPorts(ctx context.Context, node *entity.ServiceNode, nodeType *nodetype.NodeType, allocator PortAllocator) error {
+	for _, key := range exportedPorts(nodeType) {
+		port, protocol, _ := nodetype.ParsePort(key)
+
+		out := node.OutPort(port, protocol)
+		if out == nil {
+			out = &entity.ServicePort{
+				ServiceNodeID: node.Id,
+				Type:          entity.ServicePortTypeOut,
+				Port:          port,
+				Protocol:      protocol,
+			}
+			node.Ports = append(node.Ports, out)
+		}
+
+		preferred := 0
+		if nodeType.Type == TypeCompute && port == computePgPort {
+			preferred, _ = strconv.Atoi(node.Field("pg_port"))
+			out.EnabledExpose = node.Field("expose_internet") == "true"
+		}
+
+		published, err := allocator.Allocate(ctx, PortRequest{
+			ServerID:      node.ServerID,
+			ServiceID:     node.Id,
+			ContainerPort: port,
+			Protocol:      protocol,
+			Preferred:     preferred,
+			Current:       out.PublishedPort,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to allocate a port for %s %s: %w", node.Name, key, err)
+		}
+		out.PublishedPort = published
+	}
+
+	return nil
+}
+
+// exportedPorts returns the distinct port keys a node type exports, sorted.
+func exportedPorts(nodeType *nodetype.NodeType) []string {
+	seen := make(map[string]string)
+	for _, binding := range nodeType.Exports {
+		for key, description := range binding.Ports {
+			seen[key] = description
+		}
+	}
+	return sortedKeys(seen)
+}
@@ -0,0 +1,199 @@
+package compose
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/nodetype"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/topology"
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+)
+
+const networkName = "sylix"
+
+// Cluster is everything the renderer needs to know about a cluster. Ports must already be assigned
+// with AssignPorts and the resolution computed from the same graph.
+type Cluster struct {
+	ID         string
+	Name       string
+	Graph      *topology.Graph
+	Resolution *topology.Resolution
+	Servers    map[string]*entity.Server
+	Storages   map[string]*entity.BackupStorage
+}
+
+// Project is one compose project, deployed with a single `docker compose up` on ServerID.
+type Project struct {
+	Name     string
+	ServerID string
+	Priority int
+	Nodes    []*entity.ServiceNode
+	File     *File
+}
+
+// Has reports whether the node is part of the project.
+func (p *Project) Has(serviceID string) bool {
+	for _, node := range p.Nodes {
+		if node.Id == serviceID {
+			return true
+		}
+	}
+	return false
+}
+
+type Renderer struct {
+	registry *nodetype.Registry
+}
+
+func NewRenderer(registry *nodetype.Registry) *Renderer {
+	return &Renderer{registry: registry}
+}
+
+// Render turns a resolved cluster into compose projects, ordered by startup priority.
+// Every node gets its own project, except computes, which share the project of their pageserver
+// when both run on the same server.
+func (r *Renderer) Render(c *Cluster) ([]*Project, error) {
+	if !c.Resolution.Valid() {
+		return nil, fmt.Errorf("cluster %s has unresolved imports", c.ID)
+	}
+
+	projects, err := r.group(c)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, project := range projects {
+		project.File = &File{
+			Name:     project.Name,
+			Services: make(map[string]*Service),
+			Networks: map[string]*Network{networkName: {Driver: "bridge"}},
+		}
+
+		for _, node := range project.Nodes {
+			b := &builder{cluster: c, project: project, node: node}
+			service, err := b.build()
+			if err != nil {
+				return nil, fmt.Errorf("failed to render %s: %w", node.Name, err)
+			}
+			project.File.Services[ServiceName(node)] = escapeService(service)
+		}
+
+		for _, config := range project.File.Configs {
+			config.Content = escape(config.Content)
+		}
+	}
+
+	return projects, nil
+}
+
+func (r *Renderer) group(c *Cluster) ([]*Project, error) {
+	var projects []*Project
+	byNode := make(map[string]*Project)
+
+	add := func(project *Project, node *entity.ServiceNode) error {
+		nodeType, ok := r.registry.Get(node.App.Service)
+		if !ok {
+			return fmt.Errorf("unknown node type %q", node.App.Service)
+		}
+		if project.Priority == 0 || nodeType.PriorityStartup < project.Priority {
+			project.Priority = nodeType.PriorityStartup
+		}
+		project.Nodes = append(project.Nodes, node)
+		byNode[node.Id] = project
+		return nil
+	}
+
+	// computes go last, so the project of their pageserver already exists
+	var computes []*entity.ServiceNode
+	for _, node := range c.Graph.Nodes() {
+		if node.Type != entity.ServiceTypeNode {
+			continue
+		}
+		if node.App.Service == TypeCompute {
+			computes = append(computes, node)
+			continue
+		}
+		project := &Project{Name: ProjectName(node), ServerID: node.ServerID}
+		if err := add(project, node); err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+
+	for _, node := range computes {
+		pageservers := c.Graph.NeighborsOfType(node.Id, TypePageserver)
+		if len(pageservers) == 1 && pageservers[0].ServerID == node.ServerID {
+			if err := add(byNode[pageservers[0].Id], node); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		project := &Project{Name: ProjectName(node), ServerID: node.ServerID}
+		if err := add(project, node); err != nil {
+			return nil, err
+		}
+		projects = append(projects, project)
+	}
+
+	sort.SliceStable(projects, func(i, j int) bool {
+		if projects[i].Priority != projects[j].Priority {
+			return projects[i].Priority < projects[j].Priority
+		}
+		return projects[i].Name < projects[j].Name
+	})
+
+	return projects, nil
+}
+
+// ServiceName is the compose service name of a node, also its hostname inside the project network.
+func ServiceName(node *entity.ServiceNode) string {
+	return strings.ReplaceAll(node.App.Service, "_", "-") + "-" + shortID(node.Id)
+}
+
+// ContainerName is the name the node's container runs under on its server.
+func ContainerName(node *entity.ServiceNode) string {
+	return "sylix-" + ServiceName(node)
+}
+
+// ProjectName is the compose project name of a project led by node.
+func ProjectName(node *entity.ServiceNode) string {
+	return "sylix-" + ServiceName(node)
+}
+
+func shortID(id string) string {
+	id = strings.ReplaceAll(id, "-", "")
+	if len(id) > 8 {
+		return id[:8]
+	}
+	return id
+}
+
+// hexID turns a UUID into the 32 character hex id neon uses for tenants and timelines.
+func hexID(id string) string {
+	return strings.ReplaceAll(id, "-", "")
+}
+
+// escapeService keeps compose from interpolating `$` in values it does not own.
+func escapeService(s *Service) *Service {
+	for key, value := range s.Environment {
+		s.Environment[key] = escape(value)
+	}
+	for i, arg := range s.Command {
+		s.Command[i] = escape(arg)
+	}
+	return s
+}
+
+func escape(s string) string {
+	return strings.ReplaceAll(s, "$", "$$")
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,179 @@
+package compose
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/zhinea/sylix/internal/common/model"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/nodetype"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/topology"
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata with the rendered output")
+
+const testClusterID = "6f1c2b7e-0a4d-4e5f-9b3c-1d2e3f4a5b6c"
+
+// sequentialPorts hands out host ports from 20000 on, keeping preferred and current ports.
+type sequentialPorts struct {
+	next int
+}
+
+func (a *sequentialPorts) Allocate(_ context.Context, req PortRequest) (int, error) {
+	if req.Preferred != 0 {
+		return req.Preferred, nil
+	}
+	if req.Current != 0 {
+		return req.Current, nil
+	}
+	a.next++
+	return 20000 + a.next, nil
+}
+
+func testNode(id, nodeType, serverID string, ordinal int, fields map[string]string) *entity.ServiceNode {
+	clusterID := testClusterID
+	node := &entity.ServiceNode{
+		Model:    model.Model{Id: id},
+		Name:     nodeType + "-" + id[:4],
+		Type:     entity.ServiceTypeNode,
+		App:      entity.ServiceApp{App: "neon", Service: nodeType},
+		ServerID: serverID,
+		ParentID: &clusterID,
+		Ordinal:  ordinal,
+	}
+	for _, key := range sortedKeys(fields) {
+		node.Fields = append(node.Fields, &entity.ServiceNodeField{Key: key, Value: fields[key]})
+	}
+	return node
+}
+
+// testCluster builds a cluster of a storage broker, a safekeeper and a pageserver on one server,
+// and a compute on computeServer, then assigns ports and resolves it as a deployment would.
+func testCluster(t *testing.T, registry *nodetype.Registry, computeServer string) *Cluster {
+	t.Helper()
+
+	storage := map[string]string{"backup_storage_id": "storage-1"}
+	broker := testNode("11111111-1111-4111-8111-111111111111", TypeStorageBroker, "server-1", 1, nil)
+	safekeeper := testNode("22222222-2222-4222-8222-222222222222", TypeSafekeeper, "server-1", 2, storage)
+	pageserver := testNode("33333333-3333-4333-8333-333333333333", TypePageserver, "server-1", 1, storage)
+	compute := testNode("44444444-4444-4444-8444-444444444444", TypeCompute, computeServer, 1, map[string]string{
+		"pg_version":      "postgres-17",
+		"pg_port":         "5432",
+		"expose_internet": "true",
+	})
+	nodes := []*entity.ServiceNode{broker, safekeeper, pageserver, compute}
+
+	var edges []*entity.ServiceEdge
+	for _, pair := range [][2]*entity.ServiceNode{
+		{broker, safekeeper}, {broker, pageserver}, {broker, compute},
+		{safekeeper, compute}, {pageserver, compute},
+	} {
+		edges = append(edges, entity.NewServiceEdge(testClusterID, pair[0].Id, pair[1].Id))
+	}
+
+	servers := []*entity.Server{
+		{Model: model.Model{Id: "server-1"}, IpAddress: "203.0.113.10", InternalIP: "10.0.0.1"},
+		{Model: model.Model{Id: "server-2"}, IpAddress: "203.0.113.20", InternalIP: "10.0.0.2"},
+	}
+
+	allocator := &sequentialPorts{}
+	for _, node := range nodes {
+		nodeType, ok := registry.Get(node.App.Service)
+		if !ok {
+			t.Fatalf("unknown node type %q", node.App.Service)
+		}
+		if err := AssignPorts(context.Background(), node, nodeType, allocator); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	graph := topology.NewGraph(nodes, edges)
+	c := &Cluster{
+		ID:         testClusterID,
+		Name:       "golden",
+		Graph:      graph,
+		Resolution: topology.NewResolver(registry, servers).Resolve(graph),
+		Servers:    make(map[string]*entity.Server),
+		Storages: map[string]*entity.BackupStorage{
+			"storage-1": {
+				Model:     model.Model{Id: "storage-1"},
+				Endpoint:  "s3.example.com",
+				Bucket:    "sylix",
+				Region:    "us-east-1",
+				AccessKey: "access",
+				SecretKey: "secret$key",
+			},
+		},
+	}
+	for _, server := range servers {
+		c.Servers[server.Id] = server
+	}
+	return c
+}
+
+func TestRenderGolden(t *testing.T) {
+	registry, err := nodetype.Load(filepath.Join("..", "..", "..", "..", "..", "nodes.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		golden        string
+		computeServer string
+		// types of the nodes in the project, in order
+		project []string
+	}{
+		{"storage_broker", "server-2", []string{TypeStorageBroker}},
+		{"safekeeper", "server-2", []string{TypeSafekeeper}},
+		{"pageserver", "server-2", []string{TypePageserver}},
+		{"compute", "server-2", []string{TypeCompute}},
+		// a compute on the server of its pageserver is deployed with it
+		{"pageserver_compute", "server-1", []string{TypePageserver, TypeCompute}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.golden, func(t *testing.T) {
+			projects, err := NewRenderer(registry).Render(testCluster(t, registry, tt.computeServer))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var project *Project
+			for _, p := range projects {
+				var types []string
+				for _, node := range p.Nodes {
+					types = append(types, node.App.Service)
+				}
+				if strings.Join(types, ",") == strings.Join(tt.project, ",") {
+					project = p
+				}
+			}
+			if project == nil {
+				t.Fatalf("no project of %v rendered", tt.project)
+			}
+
+			got, err := project.File.Marshal()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			path := filepath.Join("testdata", tt.golden+".golden")
+			if *update {
+				if err := os.WriteFile(path, got, 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("%v, run with -update to create it", err)
+			}
+			if string(got) != string(want) {
+				t.Errorf("rendered %s differs from %s, run with -update if the change is intended:\n%s", tt.golden, path, got)
+			}
+		})
+	}
+}
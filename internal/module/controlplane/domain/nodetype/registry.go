@@ -0,0 +1,257 @@
+package nodetype
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Field types understood by the catalog
+const (
+	FieldTypeText     = "text"
+	FieldTypeNumber   = "number"
+	FieldTypePort     = "port"
+	FieldTypeBoolean  = "boolean"
+	FieldTypeOptions  = "options"
+	FieldTypeRelation = "relation"
+	FieldTypeSchedule = "schedule" // a cron expression, such as 0 3 * * *
+)
+
+// Tables a relation field may point to
+const (
+	RelatedTableServers        = "servers"
+	RelatedTableBackupStorages = "backup_storages"
+)
+
+type Field struct {
+	Key          string   `json:"-"`
+	Description  string   `json:"description"`
+	Type         string   `json:"type"`
+	Required     bool     `json:"required"`
+	Options      []string `json:"options,omitempty"`
+	RelatedTable string   `json:"relatedTable,omitempty"`
+	Default      string   `json:"default,omitempty"`
+}
+
+// PortBinding describes ports a node type needs from (imports) or offers to (exports) a peer.
+// Multiple marks an import that is satisfied by every connected peer, e.g. all safekeepers.
+type PortBinding struct {
+	From     string            `json:"from,omitempty"`
+	To       string            `json:"to,omitempty"`
+	Multiple bool              `json:"multiple,omitempty"`
+	Ports    map[string]string `json:"ports"`
+}
+
+type NodeType struct {
+	Name            string            `json:"name"`
+	Description     string            `json:"description"`
+	Type            string            `json:"type"`
+	PriorityStartup int               `json:"priority_startup"`
+	Fields          map[string]*Field `json:"fields"`
+	Imports         []PortBinding     `json:"imports"`
+	Exports         []PortBinding     `json:"exports"`
+}
+
+// FieldKeys returns the field keys sorted alphabetically.
+func (t *NodeType) FieldKeys() []string {
+	keys := make([]string, 0, len(t.Fields))
+	for key := range t.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+type catalog struct {
+	App   string      `json:"app"`
+	Nodes []*NodeType `json:"nodes"`
+}
+
+// Registry holds the node types of an app, keyed by type.
+type Registry struct {
+	app   string
+	types map[string]*NodeType
+}
+
+// Load reads and validates the catalog at path.
+func Load(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read node catalog: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse decodes and validates a catalog.
+func Parse(data []byte) (*Registry, error) {
+	var c catalog
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("failed to parse node catalog: %w", err)
+	}
+
+	if c.App == "" {
+		return nil, fmt.Errorf("node catalog: app is required")
+	}
+
+	r := &Registry{
+		app:   c.App,
+		types: make(map[string]*NodeType, len(c.Nodes)),
+	}
+	for _, t := range c.Nodes {
+		if t.Type == "" {
+			return nil, fmt.Errorf("node catalog: node %q has no type", t.Name)
+		}
+		if _, ok := r.types[t.Type]; ok {
+			return nil, fmt.Errorf("node catalog: duplicate node type %q", t.Type)
+		}
+		for key, field := range t.Fields {
+			field.Key = key
+		}
+		r.types[t.Type] = t
+	}
+
+	if err := r.validate(); err != nil {
+		return nil, fmt.Errorf("node catalog: %w", err)
+	}
+
+	return r, nil
+}
+
+// App returns the app the catalog describes, e.g. neondb.
+func (r *Registry) App() string {
+	return r.app
+}
+
+func (r *Registry) Get(nodeType string) (*NodeType, bool) {
+	t, ok := r.types[nodeType]
+	return t, ok
+}
+
+// All returns every node type ordered by startup priority.
+func (r *Registry) All() []*NodeType {
+	types := make([]*NodeType, 0, len(r.types))
+	for _, t := range r.types {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		if types[i].PriorityStartup != types[j].PriorityStartup {
+			return types[i].PriorityStartup < types[j].PriorityStartup
+		}
+		return types[i].Type < types[j].Type
+	})
+	return types
+}
+
+func (r *Registry) validate() error {
+	for _, t := range r.types {
+		if t.PriorityStartup <= 0 {
+			return fmt.Errorf("%s: priority_startup must be positive", t.Type)
+		}
+
+		for _, key := range t.FieldKeys() {
+			if err := validateField(t.Fields[key]); err != nil {
+				return fmt.Errorf("%s.%s: %w", t.Type, key, err)
+			}
+		}
+
+		for _, exp := range t.Exports {
+			if exp.To == "" {
+				return fmt.Errorf("%s: export without target", t.Type)
+			}
+			for key := range exp.Ports {
+				if _, _, err := ParsePort(key); err != nil {
+					return fmt.Errorf("%s: %w", t.Type, err)
+				}
+			}
+		}
+
+		// Every import must be offered by the peer it is imported from
+		for _, imp := range t.Imports {
+			peer, ok := r.types[imp.From]
+			if !ok {
+				return fmt.Errorf("%s: imports from unknown node type %q", t.Type, imp.From)
+			}
+			for key := range imp.Ports {
+				if _, _, err := ParsePort(key); err != nil {
+					return fmt.Errorf("%s: %w", t.Type, err)
+				}
+				if !exports(peer.Exports, t.Type, key) {
+					return fmt.Errorf("%s: imports %s from %s, which does not export it", t.Type, key, imp.From)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateField(f *Field) error {
+	switch f.Type {
+	case FieldTypeText, FieldTypeNumber, FieldTypePort, FieldTypeBoolean, FieldTypeSchedule:
+	case FieldTypeOptions:
+		if len(f.Options) == 0 {
+			return fmt.Errorf("options field has no options")
+		}
+		if f.Default != "" && !slices.Contains(f.Options, f.Default) {
+			return fmt.Errorf("default %q is not one of the options", f.Default)
+		}
+	case FieldTypeRelation:
+		if f.RelatedTable != RelatedTableServers && f.RelatedTable != RelatedTableBackupStorages {
+			return fmt.Errorf("unsupported relatedTable %q", f.RelatedTable)
+		}
+	default:
+		return fmt.Errorf("unsupported field type %q", f.Type)
+	}
+	return nil
+}
+
+// Connectable reports whether ports flow between the two node types in either direction.
+func (r *Registry) Connectable(a, b string) bool {
+	for _, pair := range [][2]string{{a, b}, {b, a}} {
+		t, ok := r.types[pair[0]]
+		if !ok {
+			continue
+		}
+		for _, imp := range t.Imports {
+			if imp.From == pair[1] {
+				return true
+			}
+		}
+		for _, exp := range t.Exports {
+			if exp.To == pair[1] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ParsePort splits a port key such as "5454/tcp" into its number and protocol.
+func ParsePort(key string) (int, string, error) {
+	number, protocol, ok := strings.Cut(key, "/")
+	if !ok || (protocol != "tcp" && protocol != "udp") {
+		return 0, "", fmt.Errorf("invalid port %q, expected <port>/<tcp|udp>", key)
+	}
+	port, err := strconv.Atoi(number)
+	if err != nil || port < 1 || port > 65535 {
+		return 0, "", fmt.Errorf("invalid port %q", key)
+	}
+	return port, protocol, nil
+}
+
+// exports reports whether bindings offer the port key to the peer type.
+func exports(bindings []PortBinding, peer, key string) bool {
+	for _, binding := range bindings {
+		if binding.To != peer {
+			continue
+		}
+		if _, ok := binding.Ports[key]; ok {
+			return true
+		}
+	}
+	return false
+}
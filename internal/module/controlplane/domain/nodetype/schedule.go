@@ -0,0 +1,150 @@
+package nodetype
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidSchedule is returned for schedule fields that are not cron expressions.
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+// scheduleMacros are the cron shorthands understood besides the five fields.
+var scheduleMacros = map[string]string{
+	"@hourly":   "0 * * * *",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@weekly":   "0 0 * * 0",
+	"@monthly":  "0 0 1 * *",
+}
+
+// Schedule is the value of a FieldTypeSchedule field, a cron expression: minute, hour, day of
+// month, month and day of week, each a list of values, ranges and steps such as 0,30 or 1-5 or
+// */15. Times are matched in UTC.
+type Schedule struct {
+	minute, hour, day, month, weekday uint64
+	// As in cron, when both days are restricted a time matching either of them matches
+	anyDay, anyWeekday bool
+}
+
+// ParseSchedule parses a cron expression, or one of @hourly, @daily, @weekly and @monthly.
+func ParseSchedule(expr string) (*Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	if macro, ok := scheduleMacros[expr]; ok {
+		expr = macro
+	}
+
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("%w %q: expected 5 fields, got %d", ErrInvalidSchedule, expr, len(fields))
+	}
+
+	// As in cron, a day field starting with * such as */2 counts as unrestricted
+	s := &Schedule{
+		anyDay:     strings.HasPrefix(fields[2], "*"),
+		anyWeekday: strings.HasPrefix(fields[4], "*"),
+	}
+	bounds := []struct {
+		bits     *uint64
+		min, max int
+	}{
+		{&s.minute, 0, 59},
+		{&s.hour, 0, 23},
+		{&s.day, 1, 31},
+		{&s.month, 1, 12},
+		{&s.weekday, 0, 7},
+	}
+	for i, b := range bounds {
+		bits, err := parseScheduleField(fields[i], b.min, b.max)
+		if err != nil {
+			return nil, fmt.Errorf("%w %q: %v", ErrInvalidSchedule, expr, err)
+		}
+		*b.bits = bits
+	}
+	// Sunday is both 0 and 7
+	if s.weekday&(1<<7) != 0 {
+		s.weekday |= 1
+	}
+	return s, nil
+}
+
+// Next returns the first time after after the schedule matches, or the zero time when it never
+// does, as for the 30th of February.
+func (s *Schedule) Next(after time.Time) time.Time {
+	t := after.UTC().Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if s.month&(1<<uint(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if !s.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, time.UTC)
+			continue
+		}
+		if s.hour&(1<<uint(t.Hour())) == 0 {
+			t = t.Truncate(time.Hour).Add(time.Hour)
+			continue
+		}
+		if s.minute&(1<<uint(t.Minute())) == 0 {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+func (s *Schedule) matchDay(t time.Time) bool {
+	day := s.day&(1<<uint(t.Day())) != 0
+	weekday := s.weekday&(1<<uint(t.Weekday())) != 0
+	if s.anyDay || s.anyWeekday {
+		return day && weekday
+	}
+	return day || weekday
+}
+
+// parseScheduleField turns a field of a cron expression into the set of its values.
+func parseScheduleField(field string, min, max int) (uint64, error) {
+	var bits uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, stepText, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepText)
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("invalid step %q", part)
+			}
+			step = n
+		}
+
+		start, end := min, max
+		if rng != "*" {
+			first, last, isRange := strings.Cut(rng, "-")
+			var err error
+			if start, err = strconv.Atoi(first); err != nil {
+				return 0, fmt.Errorf("invalid value %q", part)
+			}
+			end = start
+			if isRange {
+				if end, err = strconv.Atoi(last); err != nil {
+					return 0, fmt.Errorf("invalid value %q", part)
+				}
+			} else if hasStep {
+				// 5/15 runs from 5 to the end of the range
+				end = max
+			}
+			if start < min || end > max || start > end {
+				return 0, fmt.Errorf("%q is out of range %d-%d", part, min, max)
+			}
+		}
+
+		for v := start; v <= end; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+	return bits, nil
+}
@@ -0,0 +1,24 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+)
+
+type BackupJobQuery struct {
+	ServiceNodeID string
+	StorageID     string
+	Page          int
+	Limit         int
+}
+
+type BackupJobRepository interface {
+	Create(ctx context.Context, job *entity.BackupJob) (*entity.BackupJob, error)
+	GetByID(ctx context.Context, id string) (*entity.BackupJob, error)
+	// GetAll returns the jobs matching query, latest first, and how many there are in all
+	GetAll(ctx context.Context, query BackupJobQuery) ([]*entity.BackupJob, int64, error)
+	GetLatest(ctx context.Context, serviceNodeID, trigger string) (*entity.BackupJob, error)
+	GetByStatus(ctx context.Context, status int) ([]*entity.BackupJob, error)
+	Update(ctx context.Context, job *entity.BackupJob) error
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+	"gorm.io/gorm"
+)
+
+type BackupJobRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewBackupJobRepository(db *gorm.DB) BackupJobRepository {
+	return &BackupJobRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *BackupJobRepositoryImpl) Create(ctx context.Context, job *entity.BackupJob) (*entity.BackupJob, error) {
+	if err := r.db.WithContext(ctx).Create(job).Error; err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+func (r *BackupJobRepositoryImpl) GetByID(ctx context.Context, id string) (*entity.BackupJob, error) {
+	var job entity.BackupJob
+	if err := r.db.WithContext(ctx).First(&job, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *BackupJobRepositoryImpl) GetAll(ctx context.Context, query BackupJobQuery) ([]*entity.BackupJob, int64, error) {
+	db := r.db.WithContext(ctx).Model(&entity.BackupJob{})
+	if query.ServiceNodeID != "" {
+		db = db.Where("service_node_id = ?", query.ServiceNodeID)
+	}
+	if query.StorageID != "" {
+		db = db.Where("storage_id = ?", query.StorageID)
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	db = db.Order("started_at desc")
+	if query.Limit > 0 {
+		page := query.Page
+		if page < 1 {
+			page = 1
+		}
+		db = db.Limit(query.Limit).Offset((page - 1) * query.Limit)
+	}
+
+	var jobs []*entity.BackupJob
+	if err := db.Find(&jobs).Error; err != nil {
+		return nil, 0, err
+	}
+	return jobs, total, nil
+}
+
+// GetLatest returns the last job of the node started by trigger.
+func (r *BackupJobRepositoryImpl) GetLatest(ctx context.Context, serviceNodeID, trigger string) (*entity.BackupJob, error) {
+	var job entity.BackupJob
+	err := r.db.WithContext(ctx).
+		Where(&entity.BackupJob{ServiceNodeID: serviceNodeID, Trigger: trigger}).
+		Order("started_at desc").
+		First(&job).Error
+	if err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+func (r *BackupJobRepositoryImpl) GetByStatus(ctx context.Context, status int) ([]*entity.BackupJob, error) {
+	var jobs []*entity.BackupJob
+	if err := r.db.WithContext(ctx).Where("status = ?", status).Order("started_at asc").Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// Update saves the job. Jobs are updated from what the scheduler tracks, so the creation time is
+// left alone.
+func (r *BackupJobRepositoryImpl) Update(ctx context.Context, job *entity.BackupJob) error {
+	return r.db.WithContext(ctx).Omit("CreatedAt").Save(job).Error
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+)
+
+type CertificateAuthorityRepository interface {
+	Create(ctx context.Context, ca *entity.CertificateAuthority) (*entity.CertificateAuthority, error)
+	GetByName(ctx context.Context, name string) (*entity.CertificateAuthority, error)
+	Update(ctx context.Context, ca *entity.CertificateAuthority) error
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+	"gorm.io/gorm"
+)
+
+type CertificateAuthorityRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewCertificateAuthorityRepository(db *gorm.DB) CertificateAuthorityRepository {
+	return &CertificateAuthorityRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *CertificateAuthorityRepositoryImpl) Create(ctx context.Context, ca *entity.CertificateAuthority) (*entity.CertificateAuthority, error) {
+	if err := r.db.WithContext(ctx).Create(ca).Error; err != nil {
+		return nil, err
+	}
+	return ca, nil
+}
+
+func (r *CertificateAuthorityRepositoryImpl) GetByName(ctx context.Context, name string) (*entity.CertificateAuthority, error) {
+	var ca entity.CertificateAuthority
+	if err := r.db.WithContext(ctx).First(&ca, "name = ?", name).Error; err != nil {
+		return nil, err
+	}
+	return &ca, nil
+}
+
+func (r *CertificateAuthorityRepositoryImpl) Update(ctx context.Context, ca *entity.CertificateAuthority) error {
+	return r.db.WithContext(ctx).Save(ca).Error
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+)
+
+type DeploymentRepository interface {
+	Create(ctx context.Context, deployment *entity.Deployment) (*entity.Deployment, error)
+	GetByID(ctx context.Context, id string) (*entity.Deployment, error)
+	GetLatestByClusterID(ctx context.Context, clusterID string) (*entity.Deployment, error)
+	GetUnfinished(ctx context.Context) ([]*entity.Deployment, error)
+	Update(ctx context.Context, deployment *entity.Deployment) error
+	UpdateStep(ctx context.Context, step *entity.DeploymentStep) error
+}
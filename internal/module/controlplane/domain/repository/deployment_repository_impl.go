@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+	"gorm.io/gorm"
+)
+
+type DeploymentRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewDeploymentRepository(db *gorm.DB) DeploymentRepository {
+	return &DeploymentRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *DeploymentRepositoryImpl) preload(db *gorm.DB) *gorm.DB {
+	return db.Preload("Steps", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position asc")
+	})
+}
+
+func (r *DeploymentRepositoryImpl) Create(ctx context.Context, deployment *entity.Deployment) (*entity.Deployment, error) {
+	if err := r.db.WithContext(ctx).Create(deployment).Error; err != nil {
+		return nil, err
+	}
+	return deployment, nil
+}
+
+func (r *DeploymentRepositoryImpl) GetByID(ctx context.Context, id string) (*entity.Deployment, error) {
+	var deployment entity.Deployment
+	if err := r.preload(r.db.WithContext(ctx)).First(&deployment, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &deployment, nil
+}
+
+func (r *DeploymentRepositoryImpl) GetLatestByClusterID(ctx context.Context, clusterID string) (*entity.Deployment, error) {
+	var deployment entity.Deployment
+	if err := r.preload(r.db.WithContext(ctx)).Where("cluster_id = ?", clusterID).Order("created_at desc").First(&deployment).Error; err != nil {
+		return nil, err
+	}
+	return &deployment, nil
+}
+
+// GetUnfinished returns the deployments that are pending or running, oldest first.
+func (r *DeploymentRepositoryImpl) GetUnfinished(ctx context.Context) ([]*entity.Deployment, error) {
+	var deployments []*entity.Deployment
+	err := r.preload(r.db.WithContext(ctx)).
+		Where("status IN ?", []int{entity.DeploymentStatusPending, entity.DeploymentStatusRunning}).
+		Order("created_at asc").
+		Find(&deployments).Error
+	if err != nil {
+		return nil, err
+	}
+	return deployments, nil
+}
+
+func (r *DeploymentRepositoryImpl) Update(ctx context.Context, deployment *entity.Deployment) error {
+	return r.db.WithContext(ctx).Omit("Steps").Save(deployment).Error
+}
+
+func (r *DeploymentRepositoryImpl) UpdateStep(ctx context.Context, step *entity.DeploymentStep) error {
+	return r.db.WithContext(ctx).Save(step).Error
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+)
+
+type IPLeaseRepository interface {
+	Create(ctx context.Context, lease *entity.IPLease) (*entity.IPLease, error)
+	GetByServerID(ctx context.Context, serverID string) (*entity.IPLease, error)
+	GetAll(ctx context.Context) ([]*entity.IPLease, error)
+	DeleteByServerID(ctx context.Context, serverID string) error
+}
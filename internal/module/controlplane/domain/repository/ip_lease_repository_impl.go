@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+	"gorm.io/gorm"
+)
+
+type IPLeaseRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewIPLeaseRepository(db *gorm.DB) IPLeaseRepository {
+	return &IPLeaseRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *IPLeaseRepositoryImpl) Create(ctx context.Context, lease *entity.IPLease) (*entity.IPLease, error) {
+	if err := r.db.WithContext(ctx).Create(lease).Error; err != nil {
+		return nil, err
+	}
+	return lease, nil
+}
+
+func (r *IPLeaseRepositoryImpl) GetByServerID(ctx context.Context, serverID string) (*entity.IPLease, error) {
+	var lease entity.IPLease
+	if err := r.db.WithContext(ctx).First(&lease, "server_id = ?", serverID).Error; err != nil {
+		return nil, err
+	}
+	return &lease, nil
+}
+
+func (r *IPLeaseRepositoryImpl) GetAll(ctx context.Context) ([]*entity.IPLease, error) {
+	var leases []*entity.IPLease
+	if err := r.db.WithContext(ctx).Find(&leases).Error; err != nil {
+		return nil, err
+	}
+	return leases, nil
+}
+
+// DeleteByServerID hard deletes the lease, so the unique address can be leased again.
+func (r *IPLeaseRepositoryImpl) DeleteByServerID(ctx context.Context, serverID string) error {
+	return r.db.WithContext(ctx).Unscoped().Where("server_id = ?", serverID).Delete(&entity.IPLease{}).Error
+}
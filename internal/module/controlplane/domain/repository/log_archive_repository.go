@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+)
+
+type LogArchiveRepository interface {
+	Create(ctx context.Context, archive *entity.LogArchive) (*entity.LogArchive, error)
+	GetByPath(ctx context.Context, path string) (*entity.LogArchive, error)
+	// GetByPrefix returns the archives of the files whose path starts with prefix, oldest first
+	GetByPrefix(ctx context.Context, prefix string) ([]*entity.LogArchive, error)
+	// GetLocal returns the archives whose file is still under logs/, oldest first
+	GetLocal(ctx context.Context) ([]*entity.LogArchive, error)
+	Update(ctx context.Context, archive *entity.LogArchive) error
+}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+	"gorm.io/gorm"
+)
+
+type LogArchiveRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewLogArchiveRepository(db *gorm.DB) LogArchiveRepository {
+	return &LogArchiveRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *LogArchiveRepositoryImpl) Create(ctx context.Context, archive *entity.LogArchive) (*entity.LogArchive, error) {
+	if err := r.db.WithContext(ctx).Create(archive).Error; err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
+
+func (r *LogArchiveRepositoryImpl) GetByPath(ctx context.Context, path string) (*entity.LogArchive, error) {
+	var archive entity.LogArchive
+	if err := r.db.WithContext(ctx).First(&archive, "path = ?", path).Error; err != nil {
+		return nil, err
+	}
+	return &archive, nil
+}
+
+func (r *LogArchiveRepositoryImpl) GetByPrefix(ctx context.Context, prefix string) ([]*entity.LogArchive, error) {
+	// Wildcards in the prefix match themselves
+	escaped := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(prefix)
+
+	var archives []*entity.LogArchive
+	if err := r.db.WithContext(ctx).Where(`path LIKE ? ESCAPE '\'`, escaped+"%").Order("archived_at asc").Find(&archives).Error; err != nil {
+		return nil, err
+	}
+	return archives, nil
+}
+
+func (r *LogArchiveRepositoryImpl) GetLocal(ctx context.Context) ([]*entity.LogArchive, error) {
+	var archives []*entity.LogArchive
+	if err := r.db.WithContext(ctx).Where("local = ?", true).Order("archived_at asc").Find(&archives).Error; err != nil {
+		return nil, err
+	}
+	return archives, nil
+}
+
+func (r *LogArchiveRepositoryImpl) Update(ctx context.Context, archive *entity.LogArchive) error {
+	return r.db.WithContext(ctx).Omit("CreatedAt").Save(archive).Error
+}
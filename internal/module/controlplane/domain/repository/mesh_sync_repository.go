@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+)
+
+type MeshSyncRepository interface {
+	Create(ctx context.Context, report *entity.MeshSyncReport) (*entity.MeshSyncReport, error)
+	GetLatest(ctx context.Context) (*entity.MeshSyncReport, error)
+}
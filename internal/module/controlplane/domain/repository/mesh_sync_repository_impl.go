@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+	"gorm.io/gorm"
+)
+
+type MeshSyncRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewMeshSyncRepository(db *gorm.DB) MeshSyncRepository {
+	return &MeshSyncRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *MeshSyncRepositoryImpl) Create(ctx context.Context, report *entity.MeshSyncReport) (*entity.MeshSyncReport, error) {
+	if err := r.db.WithContext(ctx).Create(report).Error; err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (r *MeshSyncRepositoryImpl) GetLatest(ctx context.Context) (*entity.MeshSyncReport, error) {
+	var report entity.MeshSyncReport
+	err := r.db.WithContext(ctx).
+		Preload("Results", func(db *gorm.DB) *gorm.DB {
+			return db.Order("server_name asc")
+		}).
+		Order("created_at desc").
+		First(&report).Error
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+)
+
+type PortLeaseRepository interface {
+	GetByServerID(ctx context.Context, serverID string) ([]*entity.PortLease, error)
+	GetByServicePort(ctx context.Context, serviceID string, containerPort int, protocol string) (*entity.PortLease, error)
+	Replace(ctx context.Context, lease *entity.PortLease) error
+}
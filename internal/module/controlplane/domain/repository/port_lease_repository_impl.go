@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+	"gorm.io/gorm"
+)
+
+type PortLeaseRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewPortLeaseRepository(db *gorm.DB) PortLeaseRepository {
+	return &PortLeaseRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *PortLeaseRepositoryImpl) GetByServerID(ctx context.Context, serverID string) ([]*entity.PortLease, error) {
+	var leases []*entity.PortLease
+	if err := r.db.WithContext(ctx).Where("server_id = ?", serverID).Find(&leases).Error; err != nil {
+		return nil, err
+	}
+	return leases, nil
+}
+
+func (r *PortLeaseRepositoryImpl) GetByServicePort(ctx context.Context, serviceID string, containerPort int, protocol string) (*entity.PortLease, error) {
+	var lease entity.PortLease
+	err := r.db.WithContext(ctx).
+		Where("service_node_id = ? AND container_port = ? AND protocol = ?", serviceID, containerPort, protocol).
+		First(&lease).Error
+	if err != nil {
+		return nil, err
+	}
+	return &lease, nil
+}
+
+// Replace drops the lease the service held for the same container port, if any, and creates the new one.
+// It fails with gorm.ErrDuplicatedKey when the host port is leased already.
+func (r *PortLeaseRepositoryImpl) Replace(ctx context.Context, lease *entity.PortLease) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().
+			Where("service_node_id = ? AND container_port = ? AND protocol = ?", lease.ServiceNodeID, lease.ContainerPort, lease.Protocol).
+			Delete(&entity.PortLease{}).Error
+		if err != nil {
+			return err
+		}
+		return tx.Create(lease).Error
+	})
+}
@@ -12,4 +12,5 @@ type ServerRepository interface {
 	GetAll(ctx context.Context) ([]*entity.Server, error)
 	Update(ctx context.Context, server *entity.Server) (*entity.Server, error)
 	Delete(ctx context.Context, id string) error
+	UpdateHostKey(ctx context.Context, id, hostKey string) error
 }
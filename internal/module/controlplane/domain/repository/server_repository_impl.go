@@ -40,8 +40,9 @@ func (s *ServerRepositoryImpl) GetAll(ctx context.Context) ([]*entity.Server, er
 	return servers, nil
 }
 
+// Update saves the server except its pinned host key, which only changes through UpdateHostKey.
 func (s *ServerRepositoryImpl) Update(ctx context.Context, server *entity.Server) (*entity.Server, error) {
-	if err := s.db.WithContext(ctx).Save(server).Error; err != nil {
+	if err := s.db.WithContext(ctx).Omit("HostKey").Save(server).Error; err != nil {
 		return nil, err
 	}
 	return server, nil
@@ -50,3 +51,8 @@ func (s *ServerRepositoryImpl) Update(ctx context.Context, server *entity.Server
 func (s *ServerRepositoryImpl) Delete(ctx context.Context, id string) error {
 	return s.db.WithContext(ctx).Delete(&entity.Server{}, "id = ?", id).Error
 }
+
+// UpdateHostKey only touches the pinned host key, so it never races with a full Update of the server.
+func (s *ServerRepositoryImpl) UpdateHostKey(ctx context.Context, id, hostKey string) error {
+	return s.db.WithContext(ctx).Model(&entity.Server{}).Where("id = ?", id).Update("host_key", hostKey).Error
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+)
+
+type ServiceEdgeRepository interface {
+	Create(ctx context.Context, edge *entity.ServiceEdge) (*entity.ServiceEdge, error)
+	Get(ctx context.Context, a, b string) (*entity.ServiceEdge, error)
+	GetByClusterID(ctx context.Context, clusterID string) ([]*entity.ServiceEdge, error)
+	GetByNodeID(ctx context.Context, nodeID string) ([]*entity.ServiceEdge, error)
+	Delete(ctx context.Context, a, b string) error
+}
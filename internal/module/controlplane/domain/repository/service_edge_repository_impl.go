@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+	"gorm.io/gorm"
+)
+
+type ServiceEdgeRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewServiceEdgeRepository(db *gorm.DB) ServiceEdgeRepository {
+	return &ServiceEdgeRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *ServiceEdgeRepositoryImpl) Create(ctx context.Context, edge *entity.ServiceEdge) (*entity.ServiceEdge, error) {
+	if err := r.db.WithContext(ctx).Create(edge).Error; err != nil {
+		return nil, err
+	}
+	return edge, nil
+}
+
+func (r *ServiceEdgeRepositoryImpl) Get(ctx context.Context, a, b string) (*entity.ServiceEdge, error) {
+	pair := entity.NewServiceEdge("", a, b)
+
+	var edge entity.ServiceEdge
+	if err := r.db.WithContext(ctx).First(&edge, "node_a_id = ? AND node_b_id = ?", pair.NodeAID, pair.NodeBID).Error; err != nil {
+		return nil, err
+	}
+	return &edge, nil
+}
+
+func (r *ServiceEdgeRepositoryImpl) GetByClusterID(ctx context.Context, clusterID string) ([]*entity.ServiceEdge, error) {
+	var edges []*entity.ServiceEdge
+	if err := r.db.WithContext(ctx).Where("cluster_id = ?", clusterID).Order("created_at asc").Find(&edges).Error; err != nil {
+		return nil, err
+	}
+	return edges, nil
+}
+
+func (r *ServiceEdgeRepositoryImpl) GetByNodeID(ctx context.Context, nodeID string) ([]*entity.ServiceEdge, error) {
+	var edges []*entity.ServiceEdge
+	if err := r.db.WithContext(ctx).Where("node_a_id = ? OR node_b_id = ?", nodeID, nodeID).Find(&edges).Error; err != nil {
+		return nil, err
+	}
+	return edges, nil
+}
+
+// Delete removes the edge permanently so the pair can be connected again.
+func (r *ServiceEdgeRepositoryImpl) Delete(ctx context.Context, a, b string) error {
+	pair := entity.NewServiceEdge("", a, b)
+
+	result := r.db.WithContext(ctx).Unscoped().Where("node_a_id = ? AND node_b_id = ?", pair.NodeAID, pair.NodeBID).Delete(&entity.ServiceEdge{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
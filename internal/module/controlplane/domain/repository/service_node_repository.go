@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+)
+
+type ServiceNodeQuery struct {
+	Page   int
+	Limit  int
+	Search string
+	Sort   string
+	Order  string
+}
+
+type ServiceNodeRepository interface {
+	Create(ctx context.Context, node *entity.ServiceNode) (*entity.ServiceNode, error)
+	GetByID(ctx context.Context, id string) (*entity.ServiceNode, error)
+	GetByIDs(ctx context.Context, ids []string) ([]*entity.ServiceNode, error)
+	GetByParentID(ctx context.Context, parentID string) ([]*entity.ServiceNode, error)
+	GetAll(ctx context.Context, query ServiceNodeQuery) ([]*entity.ServiceNode, error)
+	// GetWithField returns the nodes on which the field with the given key is set
+	GetWithField(ctx context.Context, key string) ([]*entity.ServiceNode, error)
+	Update(ctx context.Context, node *entity.ServiceNode) (*entity.ServiceNode, error)
+	UpdateStatus(ctx context.Context, id string, status int) error
+	UpdatePorts(ctx context.Context, id string, ports []*entity.ServicePort) error
+	UpdateContainer(ctx context.Context, id string, container entity.ServiceContainer) error
+	Delete(ctx context.Context, id string) error
+}
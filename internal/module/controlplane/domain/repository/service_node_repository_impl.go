@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+	"gorm.io/gorm"
+)
+
+var serviceNodeSortColumns = map[string]string{
+	"name":       "name",
+	"status":     "status",
+	"type":       "type",
+	"created_at": "created_at",
+	"updated_at": "updated_at",
+}
+
+type ServiceNodeRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewServiceNodeRepository(db *gorm.DB) ServiceNodeRepository {
+	return &ServiceNodeRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *ServiceNodeRepositoryImpl) preload(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Fields").
+		Preload("Ports").
+		Preload("Nodes").
+		Preload("Nodes.Fields").
+		Preload("Nodes.Ports")
+}
+
+// Create saves the node. A node created in a cluster gets the ordinal following the highest one of
+// its type there, deleted nodes included.
+func (r *ServiceNodeRepositoryImpl) Create(ctx context.Context, node *entity.ServiceNode) (*entity.ServiceNode, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if node.ParentID != nil {
+			var last int
+			if err := tx.Unscoped().Model(&entity.ServiceNode{}).
+				Where("parent_id = ? AND app_service = ?", *node.ParentID, node.App.Service).
+				Select("COALESCE(MAX(ordinal), 0)").Scan(&last).Error; err != nil {
+				return err
+			}
+			node.Ordinal = last + 1
+		}
+		return tx.Omit("Nodes").Create(node).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+func (r *ServiceNodeRepositoryImpl) GetByID(ctx context.Context, id string) (*entity.ServiceNode, error) {
+	var node entity.ServiceNode
+	if err := r.preload(r.db.WithContext(ctx)).First(&node, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &node, nil
+}
+
+func (r *ServiceNodeRepositoryImpl) GetByIDs(ctx context.Context, ids []string) ([]*entity.ServiceNode, error) {
+	var nodes []*entity.ServiceNode
+	if err := r.preload(r.db.WithContext(ctx)).Where("id IN ?", ids).Find(&nodes).Error; err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+func (r *ServiceNodeRepositoryImpl) GetByParentID(ctx context.Context, parentID string) ([]*entity.ServiceNode, error) {
+	var nodes []*entity.ServiceNode
+	if err := r.preload(r.db.WithContext(ctx)).Where("parent_id = ?", parentID).Order("created_at asc").Find(&nodes).Error; err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+func (r *ServiceNodeRepositoryImpl) GetAll(ctx context.Context, query ServiceNodeQuery) ([]*entity.ServiceNode, error) {
+	db := r.preload(r.db.WithContext(ctx)).Where("parent_id IS NULL")
+
+	if query.Search != "" {
+		db = db.Where("name LIKE ?", "%"+query.Search+"%")
+	}
+
+	sort, ok := serviceNodeSortColumns[query.Sort]
+	if !ok {
+		sort = "created_at"
+	}
+	order := "desc"
+	if strings.EqualFold(query.Order, "asc") {
+		order = "asc"
+	}
+	db = db.Order(sort + " " + order)
+
+	if query.Limit > 0 {
+		page := query.Page
+		if page < 1 {
+			page = 1
+		}
+		db = db.Limit(query.Limit).Offset((page - 1) * query.Limit)
+	}
+
+	var nodes []*entity.ServiceNode
+	if err := db.Find(&nodes).Error; err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// GetWithField returns the nodes on which the field with the given key is set, oldest first.
+func (r *ServiceNodeRepositoryImpl) GetWithField(ctx context.Context, key string) ([]*entity.ServiceNode, error) {
+	fields := r.db.Model(&entity.ServiceNodeField{}).Select("service_node_id").Where("key = ? AND value <> ''", key)
+
+	var nodes []*entity.ServiceNode
+	if err := r.preload(r.db.WithContext(ctx)).Where("id IN (?)", fields).Order("created_at asc").Find(&nodes).Error; err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// Update saves the node and replaces its fields and ports with the given ones.
+func (r *ServiceNodeRepositoryImpl) Update(ctx context.Context, node *entity.ServiceNode) (*entity.ServiceNode, error) {
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Fields", "Ports", "Nodes").Save(node).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Unscoped().Where("service_node_id = ?", node.Id).Delete(&entity.ServiceNodeField{}).Error; err != nil {
+			return err
+		}
+		for _, field := range node.Fields {
+			field.Id = ""
+			field.ServiceNodeID = node.Id
+		}
+		if len(node.Fields) > 0 {
+			if err := tx.Create(&node.Fields).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Unscoped().Where("service_node_id = ?", node.Id).Delete(&entity.ServicePort{}).Error; err != nil {
+			return err
+		}
+		for _, port := range node.Ports {
+			port.Id = ""
+			port.ServiceNodeID = node.Id
+		}
+		if len(node.Ports) > 0 {
+			if err := tx.Create(&node.Ports).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return node, nil
+}
+
+func (r *ServiceNodeRepositoryImpl) UpdateStatus(ctx context.Context, id string, status int) error {
+	return r.db.WithContext(ctx).Model(&entity.ServiceNode{}).Where("id = ?", id).Update("status", status).Error
+}
+
+// UpdatePorts saves the given ports of the node, creating the ones that are new.
+func (r *ServiceNodeRepositoryImpl) UpdatePorts(ctx context.Context, id string, ports []*entity.ServicePort) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, port := range ports {
+			port.ServiceNodeID = id
+			if err := tx.Save(port).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *ServiceNodeRepositoryImpl) UpdateContainer(ctx context.Context, id string, container entity.ServiceContainer) error {
+	return r.db.WithContext(ctx).Model(&entity.ServiceNode{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"container_id":         container.ID,
+		"container_name":       container.Name,
+		"container_image":      container.Image,
+		"container_created_at": container.CreatedAt,
+		"container_updated_at": container.UpdatedAt,
+	}).Error
+}
+
+// Delete removes the node, its direct children and every field, port, edge and port lease attached to them.
+func (r *ServiceNodeRepositoryImpl) Delete(ctx context.Context, id string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var ids []string
+		if err := tx.Model(&entity.ServiceNode{}).Where("id = ? OR parent_id = ?", id, id).Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if err := tx.Unscoped().Where("service_node_id IN ?", ids).Delete(&entity.ServiceNodeField{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("service_node_id IN ?", ids).Delete(&entity.ServicePort{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("node_a_id IN ? OR node_b_id IN ?", ids, ids).Delete(&entity.ServiceEdge{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Where("service_node_id IN ?", ids).Delete(&entity.PortLease{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&entity.ServiceNode{}, "id IN ?", ids).Error
+	})
+}
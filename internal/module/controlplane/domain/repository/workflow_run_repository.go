@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+)
+
+type WorkflowRunRepository interface {
+	Create(ctx context.Context, run *entity.WorkflowRun) (*entity.WorkflowRun, error)
+	GetByID(ctx context.Context, id string) (*entity.WorkflowRun, error)
+	GetByStatus(ctx context.Context, status string) ([]*entity.WorkflowRun, error)
+	Update(ctx context.Context, run *entity.WorkflowRun) error
+	UpdateStep(ctx context.Context, step *entity.WorkflowStepRun) error
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type WorkflowRunRepositoryImpl struct {
+	db *gorm.DB
+}
+
+func NewWorkflowRunRepository(db *gorm.DB) WorkflowRunRepository {
+	return &WorkflowRunRepositoryImpl{
+		db: db,
+	}
+}
+
+// Create saves the run together with its steps.
+func (r *WorkflowRunRepositoryImpl) Create(ctx context.Context, run *entity.WorkflowRun) (*entity.WorkflowRun, error) {
+	if err := r.db.WithContext(ctx).Create(run).Error; err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+func (r *WorkflowRunRepositoryImpl) GetByID(ctx context.Context, id string) (*entity.WorkflowRun, error) {
+	var run entity.WorkflowRun
+	if err := r.withSteps(ctx).First(&run, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
+
+func (r *WorkflowRunRepositoryImpl) GetByStatus(ctx context.Context, status string) ([]*entity.WorkflowRun, error) {
+	var runs []*entity.WorkflowRun
+	if err := r.withSteps(ctx).Where("status = ?", status).Order("started_at asc").Find(&runs).Error; err != nil {
+		return nil, err
+	}
+	return runs, nil
+}
+
+// Update saves the run without its steps, which change through UpdateStep. Runs are updated from
+// what the engine tracks, so the creation time is left alone.
+func (r *WorkflowRunRepositoryImpl) Update(ctx context.Context, run *entity.WorkflowRun) error {
+	return r.db.WithContext(ctx).Omit(clause.Associations, "CreatedAt").Save(run).Error
+}
+
+func (r *WorkflowRunRepositoryImpl) UpdateStep(ctx context.Context, step *entity.WorkflowStepRun) error {
+	return r.db.WithContext(ctx).Omit("CreatedAt").Save(step).Error
+}
+
+func (r *WorkflowRunRepositoryImpl) withSteps(ctx context.Context) *gorm.DB {
+	return r.db.WithContext(ctx).Preload("Steps", func(db *gorm.DB) *gorm.DB {
+		return db.Order("position asc")
+	})
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	pbAgent "github.com/zhinea/sylix/internal/infra/proto/agent"
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+// agentRetryInterval is how long an agent that could not be reached is passed over.
+const agentRetryInterval = time.Minute
+
+// AgentClientService keeps one mTLS gRPC connection per server to the agent running on it.
+type AgentClientService struct {
+	certs *CertificateService
+
+	mu    sync.Mutex
+	conns map[string]*agentConn
+	down  map[string]time.Time
+}
+
+type agentConn struct {
+	conn   *grpc.ClientConn
+	target string
+}
+
+func NewAgentClientService(certs *CertificateService) *AgentClientService {
+	return &AgentClientService{
+		certs: certs,
+		conns: make(map[string]*agentConn),
+		down:  make(map[string]time.Time),
+	}
+}
+
+// Available reports whether the server's agent is installed and was not unreachable just now.
+func (s *AgentClientService) Available(server *entity.Server) bool {
+	if server.Agent.Status != entity.AgentStatusSuccess || server.Agent.Cert == "" || server.Agent.Port == 0 {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	since, ok := s.down[server.Id]
+	return !ok || time.Since(since) > agentRetryInterval
+}
+
+// Client returns a client for the server's agent. The connection is shared and reconnects on its own.
+func (s *AgentClientService) Client(server *entity.Server) (pbAgent.AgentServiceClient, error) {
+	target := net.JoinHostPort(server.IpAddress, strconv.Itoa(server.Agent.Port))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c, ok := s.conns[server.Id]; ok {
+		if c.target == target {
+			return pbAgent.NewAgentServiceClient(c.conn), nil
+		}
+		c.conn.Close()
+		delete(s.conns, server.Id)
+	}
+
+	// Agent certificates are issued for the server's address
+	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(credentials.NewTLS(s.certs.ClientTLSConfig(server.IpAddress))))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create agent client: %w", err)
+	}
+	s.conns[server.Id] = &agentConn{conn: conn, target: target}
+	return pbAgent.NewAgentServiceClient(conn), nil
+}
+
+// Observe records the outcome of a call to the server's agent. While the agent is unreachable
+// callers fall back to SSH.
+func (s *AgentClientService) Observe(serverID string, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if agentUnreachable(err) {
+		s.down[serverID] = time.Now()
+	} else {
+		delete(s.down, serverID)
+	}
+}
+
+// Evict closes the connection to the server's agent, for when the server or its agent changed.
+func (s *AgentClientService) Evict(serverID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if c, ok := s.conns[serverID]; ok {
+		c.conn.Close()
+		delete(s.conns, serverID)
+	}
+	delete(s.down, serverID)
+}
+
+func (s *AgentClientService) Close() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for id, c := range s.conns {
+		c.conn.Close()
+		delete(s.conns, id)
+	}
+}
+
+// agentUnreachable reports whether err means the agent could not be reached at all, as opposed to a
+// call the agent answered with an error.
+func agentUnreachable(err error) bool {
+	if err == nil {
+		return false
+	}
+	st, ok := status.FromError(err)
+	return ok && st.Code() == codes.Unavailable
+}
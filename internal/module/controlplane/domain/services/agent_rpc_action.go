@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zhinea/sylix/internal/common/workflow"
+	pbAgent "github.com/zhinea/sylix/internal/infra/proto/agent"
+	pbCommon "github.com/zhinea/sylix/internal/infra/proto/common"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/repository"
+)
+
+// Methods of the agent an agent_rpc workflow step can call.
+const (
+	AgentRPCHealth        = "health"
+	AgentRPCSystemInfo    = "system_info"
+	AgentRPCExec          = "exec"           // runs Command with sh -c, in Params["container"] when set
+	AgentRPCStopCompose   = "stop_compose"   // stops Params["project"]
+	AgentRPCRemoveCompose = "remove_compose" // removes Params["project"], with its volumes when Params["volumes"] is "true"
+)
+
+// AgentRPCAction carries out agent_rpc workflow steps by calling the agent of the server the workflow
+// runs on, which the engine's target identifies by id.
+type AgentRPCAction struct {
+	repo   repository.ServerRepository
+	agents *AgentClientService
+}
+
+func NewAgentRPCAction(repo repository.ServerRepository, agents *AgentClientService) *AgentRPCAction {
+	return &AgentRPCAction{
+		repo:   repo,
+		agents: agents,
+	}
+}
+
+func (a *AgentRPCAction) Execute(ctx context.Context, env *workflow.Env, step workflow.Step) error {
+	if env.Target == "" {
+		return fmt.Errorf("agent_rpc needs the id of the server the workflow runs on")
+	}
+
+	server, err := a.repo.GetByID(ctx, env.Target)
+	if err != nil {
+		return fmt.Errorf("failed to get server: %w", err)
+	}
+
+	client, err := a.agents.Client(server)
+	if err != nil {
+		return err
+	}
+
+	err = a.call(ctx, env, client, step)
+	a.agents.Observe(server.Id, err)
+	return err
+}
+
+func (a *AgentRPCAction) call(ctx context.Context, env *workflow.Env, client pbAgent.AgentServiceClient, step workflow.Step) error {
+	switch step.Method {
+	case AgentRPCHealth:
+		resp, err := client.Health(ctx, &pbCommon.Empty{})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(env.Out, "agent %s is %s, docker available: %t\n", resp.Version, resp.Status, resp.Docker)
+		return nil
+
+	case AgentRPCSystemInfo:
+		resp, err := client.SystemInfo(ctx, &pbCommon.Empty{})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(env.Out, "%s %s %s, %d cpus, docker %s\n", resp.Hostname, resp.Os, resp.Arch, resp.Cpus, resp.DockerVersion)
+		return nil
+
+	case AgentRPCExec:
+		resp, err := client.Exec(ctx, &pbAgent.ExecRequest{
+			Container: step.Params["container"],
+			Command:   []string{"sh", "-c", step.Command},
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(env.Out, resp.Stdout)
+		fmt.Fprint(env.Err, resp.Stderr)
+		if resp.ExitCode != 0 {
+			return fmt.Errorf("command exited with code %d", resp.ExitCode)
+		}
+		return nil
+
+	case AgentRPCStopCompose:
+		resp, err := client.StopCompose(ctx, &pbAgent.ComposeProject{Project: step.Params["project"]})
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(env.Out, resp.Output)
+		return nil
+
+	case AgentRPCRemoveCompose:
+		resp, err := client.RemoveCompose(ctx, &pbAgent.RemoveComposeRequest{
+			Project: step.Params["project"],
+			Volumes: step.Params["volumes"] == "true",
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(env.Out, resp.Output)
+		return nil
+
+	default:
+		return fmt.Errorf("unknown agent method: %s", step.Method)
+	}
+}
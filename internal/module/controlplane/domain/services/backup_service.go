@@ -2,7 +2,11 @@ package services
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"io"
 	"strings"
 
 	"github.com/minio/minio-go/v7"
@@ -112,21 +116,7 @@ func (s *BackupService) fetchServers(ctx context.Context, ids []string) ([]*enti
 }
 
 func (s *BackupService) TestConnection(ctx context.Context, backup *entity.BackupStorage) error {
-	endpoint := backup.Endpoint
-	secure := true
-	if strings.HasPrefix(endpoint, "http://") {
-		endpoint = strings.TrimPrefix(endpoint, "http://")
-		secure = false
-	} else if strings.HasPrefix(endpoint, "https://") {
-		endpoint = strings.TrimPrefix(endpoint, "https://")
-		secure = true
-	}
-
-	minioClient, err := minio.New(endpoint, &minio.Options{
-		Creds:  credentials.NewStaticV4(backup.AccessKey, backup.SecretKey, ""),
-		Secure: secure,
-		Region: backup.Region,
-	})
+	minioClient, err := newMinioClient(backup)
 	if err != nil {
 		return err
 	}
@@ -142,3 +132,45 @@ func (s *BackupService) TestConnection(ctx context.Context, backup *entity.Backu
 
 	return nil
 }
+
+// backupPartSize is the size of the parts backups of unknown size are uploaded in, each held in
+// memory while it is sent.
+const backupPartSize = 16 << 20
+
+// Upload streams body to key in the bucket of the storage, returning the size and the SHA-256
+// checksum, hex encoded, of what was uploaded. Nothing is left in the bucket when body fails.
+func (s *BackupService) Upload(ctx context.Context, backup *entity.BackupStorage, key string, body io.Reader) (int64, string, error) {
+	minioClient, err := newMinioClient(backup)
+	if err != nil {
+		return 0, "", err
+	}
+
+	hash := sha256.New()
+	info, err := minioClient.PutObject(ctx, backup.Bucket, key, io.TeeReader(body, hash), -1, minio.PutObjectOptions{
+		ContentType: "application/gzip",
+		PartSize:    backupPartSize,
+	})
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to upload %s: %w", key, err)
+	}
+	return info.Size, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// newMinioClient connects to the S3 endpoint of the storage, over TLS unless it is an http:// URL.
+func newMinioClient(backup *entity.BackupStorage) (*minio.Client, error) {
+	endpoint := backup.Endpoint
+	secure := true
+	if strings.HasPrefix(endpoint, "http://") {
+		endpoint = strings.TrimPrefix(endpoint, "http://")
+		secure = false
+	} else if strings.HasPrefix(endpoint, "https://") {
+		endpoint = strings.TrimPrefix(endpoint, "https://")
+		secure = true
+	}
+
+	return minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(backup.AccessKey, backup.SecretKey, ""),
+		Secure: secure,
+		Region: backup.Region,
+	})
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/zhinea/sylix/internal/common/logger"
+	"github.com/zhinea/sylix/internal/common/util"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/repository"
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+// certRenewBefore is how long before expiry certificates are issued anew.
+const certRenewBefore = 30 * 24 * time.Hour
+
+// CertificateService holds the cluster CA. It issues the certificates agents serve with and the
+// client certificate the controlplane authenticates to them with.
+type CertificateService struct {
+	repo repository.CertificateAuthorityRepository
+
+	ca     *entity.CertificateAuthority
+	caCert *x509.Certificate
+	roots  *x509.CertPool
+	client tls.Certificate
+}
+
+func NewCertificateService(repo repository.CertificateAuthorityRepository) *CertificateService {
+	return &CertificateService{
+		repo: repo,
+	}
+}
+
+// Init loads the cluster CA, creating and persisting it on first boot, and makes sure the
+// controlplane's client certificate is valid.
+func (s *CertificateService) Init(ctx context.Context) error {
+	ca, err := s.repo.GetByName(ctx, entity.ClusterCA)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		ca, err = s.createCA(ctx)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to load cluster CA: %w", err)
+	}
+
+	caCert, err := parseCertificate(ca.Cert)
+	if err != nil {
+		return fmt.Errorf("failed to parse cluster CA: %w", err)
+	}
+
+	if !s.validFor(ca.ClientCert, caCert, "", x509.ExtKeyUsageClientAuth) {
+		certPEM, keyPEM, err := util.GenerateCert([]byte(ca.Cert), []byte(ca.Key), util.ControlplaneCertName, x509.ExtKeyUsageClientAuth)
+		if err != nil {
+			return fmt.Errorf("failed to issue controlplane certificate: %w", err)
+		}
+		ca.ClientCert, ca.ClientKey = string(certPEM), string(keyPEM)
+		if err := s.repo.Update(ctx, ca); err != nil {
+			return fmt.Errorf("failed to save controlplane certificate: %w", err)
+		}
+	}
+
+	client, err := tls.X509KeyPair([]byte(ca.ClientCert), []byte(ca.ClientKey))
+	if err != nil {
+		return fmt.Errorf("failed to load controlplane certificate: %w", err)
+	}
+
+	s.roots = x509.NewCertPool()
+	s.roots.AddCert(caCert)
+	s.ca, s.caCert, s.client = ca, caCert, client
+	return nil
+}
+
+// CACertificate returns the CA certificate in PEM format, as agents need it to verify clients.
+func (s *CertificateService) CACertificate() string {
+	return s.ca.Cert
+}
+
+// IssueServerCertificate sets a certificate for the server's agent, unless it already has one that
+// is signed by the CA, valid for its address and not about to expire. It reports whether it issued one.
+func (s *CertificateService) IssueServerCertificate(server *entity.Server) (bool, error) {
+	if s.ca == nil {
+		return false, fmt.Errorf("certificate service is not initialized")
+	}
+	if server.Agent.Key != "" && s.validFor(server.Agent.Cert, s.caCert, server.IpAddress, x509.ExtKeyUsageServerAuth) {
+		return false, nil
+	}
+
+	// Only good for serving, so an agent's certificate cannot be used to call the other agents
+	certPEM, keyPEM, err := util.GenerateCert([]byte(s.ca.Cert), []byte(s.ca.Key), server.IpAddress, x509.ExtKeyUsageServerAuth)
+	if err != nil {
+		return false, fmt.Errorf("failed to issue agent certificate: %w", err)
+	}
+	server.Agent.Cert, server.Agent.Key = string(certPEM), string(keyPEM)
+
+	logger.Log.Info("Issued agent certificate", zap.String("server_id", server.Id), zap.String("ip", server.IpAddress))
+	return true, nil
+}
+
+// ClientTLSConfig returns the TLS configuration to dial the agent serving under serverName with.
+func (s *CertificateService) ClientTLSConfig(serverName string) *tls.Config {
+	return &tls.Config{
+		RootCAs:      s.roots,
+		Certificates: []tls.Certificate{s.client},
+		ServerName:   serverName,
+		MinVersion:   tls.VersionTLS12,
+	}
+}
+
+func (s *CertificateService) createCA(ctx context.Context) (*entity.CertificateAuthority, error) {
+	certPEM, keyPEM, err := util.GenerateCA()
+	if err != nil {
+		return nil, err
+	}
+
+	ca, err := s.repo.Create(ctx, &entity.CertificateAuthority{
+		Name: entity.ClusterCA,
+		Cert: string(certPEM),
+		Key:  string(keyPEM),
+	})
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		// created by another controlplane in the meantime
+		return s.repo.GetByName(ctx, entity.ClusterCA)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Log.Info("Created cluster CA")
+	return ca, nil
+}
+
+// validFor reports whether certPEM is signed by ca, only good for usage, not about to expire and,
+// when host is set, valid for host. Certificates issued for both client and server use are not
+// valid anymore, so they are issued anew.
+func (s *CertificateService) validFor(certPEM string, ca *x509.Certificate, host string, usage x509.ExtKeyUsage) bool {
+	if certPEM == "" {
+		return false
+	}
+	cert, err := parseCertificate(certPEM)
+	if err != nil || cert.CheckSignatureFrom(ca) != nil {
+		return false
+	}
+	if len(cert.ExtKeyUsage) != 1 || cert.ExtKeyUsage[0] != usage {
+		return false
+	}
+	if time.Until(cert.NotAfter) < certRenewBefore {
+		return false
+	}
+	if host == "" {
+		return true
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		for _, addr := range cert.IPAddresses {
+			if addr.Equal(ip) {
+				return true
+			}
+		}
+		return false
+	}
+	return cert.VerifyHostname(host) == nil
+}
+
+func parseCertificate(certPEM string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(certPEM))
+	if block == nil {
+		return nil, fmt.Errorf("no certificate found")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zhinea/sylix/internal/common/util"
+)
+
+const (
+	// containerLogsCacheTTL is how long fetched logs serve the pages read after them, so paging
+	// through logs does not run docker logs on the server for every page.
+	containerLogsCacheTTL = 5 * time.Second
+	// containerLogsCacheSize bounds how many fetches are cached.
+	containerLogsCacheSize = 256
+)
+
+// ContainerLogsQuery selects the lines of container logs: the last Tail of those written between
+// Since and Until, which are RFC 3339 timestamps or durations such as 10m, as docker logs takes them.
+type ContainerLogsQuery struct {
+	Tail  int
+	Since string
+	Until string
+}
+
+// ContainerService runs docker commands against containers on managed servers.
+type ContainerService struct {
+	remote *RemoteService
+
+	mu   sync.Mutex
+	logs map[containerLogsKey]*containerLogs
+}
+
+type containerLogsKey struct {
+	serverID  string
+	container string
+	since     string
+	until     string
+}
+
+// containerLogs are the last tail lines of logs fetched at once.
+type containerLogs struct {
+	lines   []string
+	tail    int
+	fetched time.Time
+}
+
+func NewContainerService(remote *RemoteService) *ContainerService {
+	return &ContainerService{
+		remote: remote,
+		logs:   make(map[containerLogsKey]*containerLogs),
+	}
+}
+
+// Logs returns the lines of the container's logs the query selects, oldest first, with stdout and
+// stderr merged. Logs fetched moments ago that hold those lines are used instead of fetching them again.
+func (s *ContainerService) Logs(ctx context.Context, serverID, container string, query ContainerLogsQuery) ([]string, error) {
+	key := containerLogsKey{serverID: serverID, container: container, since: query.Since, until: query.Until}
+
+	s.mu.Lock()
+	cached, ok := s.logs[key]
+	s.mu.Unlock()
+	if ok && time.Since(cached.fetched) < containerLogsCacheTTL && cached.tail >= query.Tail {
+		return cached.lines[max(len(cached.lines)-query.Tail, 0):], nil
+	}
+
+	cmd := fmt.Sprintf("docker logs --tail %d", query.Tail)
+	if query.Since != "" {
+		cmd += " --since " + util.ShellQuote(query.Since)
+	}
+	if query.Until != "" {
+		cmd += " --until " + util.ShellQuote(query.Until)
+	}
+	cmd += " " + util.ShellQuote(container) + " 2>&1"
+
+	out, err := s.remote.RunByID(ctx, serverID, cmd)
+	if err != nil {
+		return nil, err
+	}
+	lines := strings.Split(strings.TrimSuffix(out, "\n"), "\n")
+	if out == "" {
+		lines = nil
+	}
+
+	s.mu.Lock()
+	s.logs[key] = &containerLogs{lines: lines, tail: query.Tail, fetched: time.Now()}
+	s.evictLogs()
+	s.mu.Unlock()
+
+	return lines, nil
+}
+
+// FollowLogs passes the last tail lines of the container's logs to fn, then the lines written
+// after them, until ctx is done or the container stops.
+func (s *ContainerService) FollowLogs(ctx context.Context, serverID, container string, tail int, fn func(stream, line string) error) error {
+	return s.remote.FollowContainerLogs(ctx, serverID, container, tail, fn)
+}
+
+// evictLogs drops expired fetches, and the oldest ones past containerLogsCacheSize. s.mu must be held.
+func (s *ContainerService) evictLogs() {
+	for key, logs := range s.logs {
+		if time.Since(logs.fetched) >= containerLogsCacheTTL {
+			delete(s.logs, key)
+		}
+	}
+	for len(s.logs) > containerLogsCacheSize {
+		var oldest containerLogsKey
+		var fetched time.Time
+		for key, logs := range s.logs {
+			if fetched.IsZero() || logs.fetched.Before(fetched) {
+				oldest, fetched = key, logs.fetched
+			}
+		}
+		delete(s.logs, oldest)
+	}
+}
+
+func (s *ContainerService) Start(ctx context.Context, serverID, container string) error {
+	return s.run(ctx, serverID, "docker start "+util.ShellQuote(container))
+}
+
+func (s *ContainerService) Stop(ctx context.Context, serverID, container string) error {
+	return s.run(ctx, serverID, "docker stop "+util.ShellQuote(container))
+}
+
+func (s *ContainerService) Restart(ctx context.Context, serverID, container string) error {
+	return s.run(ctx, serverID, "docker restart "+util.ShellQuote(container))
+}
+
+// Remove force-removes the container together with its anonymous volumes.
+func (s *ContainerService) Remove(ctx context.Context, serverID, container string) error {
+	return s.run(ctx, serverID, "docker rm -f -v "+util.ShellQuote(container))
+}
+
+func (s *ContainerService) run(ctx context.Context, serverID, cmd string) error {
+	if _, err := s.remote.RunByID(ctx, serverID, cmd); err != nil {
+		return err
+	}
+	return nil
+}
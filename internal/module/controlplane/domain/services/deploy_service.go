@@ -0,0 +1,98 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/zhinea/sylix/internal/common/util"
+)
+
+// ProjectsDir is where compose projects are written on managed servers.
+const ProjectsDir = "/opt/sylix/projects"
+
+const healthPollInterval = 5 * time.Second
+
+// DeployService runs compose projects on managed servers.
+type DeployService struct {
+	remote *RemoteService
+}
+
+func NewDeployService(remote *RemoteService) *DeployService {
+	return &DeployService{
+		remote: remote,
+	}
+}
+
+// Up writes the compose file of the project to the server and starts it, streaming the output to out.
+func (s *DeployService) Up(ctx context.Context, serverID, project string, compose []byte, out io.Writer) error {
+	return s.remote.ComposeUp(ctx, serverID, project, compose, out)
+}
+
+// ContainerInfo returns the id and image of a container.
+func (s *DeployService) ContainerInfo(ctx context.Context, serverID, container string) (string, string, error) {
+	output, err := s.remote.RunByID(ctx, serverID, "docker inspect -f '{{.Id}} {{.Config.Image}}' "+util.ShellQuote(container))
+	if err != nil {
+		return "", "", err
+	}
+	id, image, _ := strings.Cut(strings.TrimSpace(output), " ")
+	return id, image, nil
+}
+
+// WaitHealthy polls the containers until all of them are healthy, or running when they have no
+// healthcheck. It fails as soon as one is unhealthy or exited, or when timeout passes.
+func (s *DeployService) WaitHealthy(ctx context.Context, serverID string, containers []string, timeout time.Duration) error {
+	server, err := s.remote.Server(ctx, serverID)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(healthPollInterval)
+	defer ticker.Stop()
+
+	pending := containers
+	for {
+		var waiting []string
+		for _, container := range pending {
+			state, err := s.remote.Run(ctx, server, "docker inspect -f '{{if .State.Health}}{{.State.Health.Status}}{{else}}{{.State.Status}}{{end}}' "+util.ShellQuote(container))
+			if err != nil {
+				return err
+			}
+
+			switch strings.TrimSpace(state) {
+			case "healthy", "running":
+			case "unhealthy", "exited", "dead":
+				return fmt.Errorf("container %s is %s", container, strings.TrimSpace(state))
+			default:
+				waiting = append(waiting, container)
+			}
+		}
+		if len(waiting) == 0 {
+			return nil
+		}
+		pending = waiting
+
+		select {
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				return fmt.Errorf("containers did not become healthy in %s: %s", timeout, strings.Join(pending, ", "))
+			}
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// CheckPort verifies that host:port accepts TCP connections, as seen from the server itself.
+func (s *DeployService) CheckPort(ctx context.Context, serverID, host string, port int) error {
+	probe := fmt.Sprintf("exec 3<>/dev/tcp/%s/%d", host, port)
+	if _, err := s.remote.RunByID(ctx, serverID, "timeout 5 bash -c "+util.ShellQuote(probe)); err != nil {
+		return fmt.Errorf("port %s:%d is not reachable", host, port)
+	}
+	return nil
+}
@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/netip"
+	"sync"
+
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/repository"
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+	"gorm.io/gorm"
+)
+
+var ErrPoolExhausted = errors.New("no free address left in the WireGuard pool")
+
+const DefaultWireGuardCIDR = "10.0.0.0/24"
+
+// ParsePool parses the CIDR of the WireGuard pool. An empty value yields the default pool.
+func ParsePool(value string) (netip.Prefix, error) {
+	if value == "" {
+		value = DefaultWireGuardCIDR
+	}
+
+	prefix, err := netip.ParsePrefix(value)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid WireGuard CIDR %q: %w", value, err)
+	}
+	// leave room for at least one address besides network and broadcast
+	if prefix.Bits() > prefix.Addr().BitLen()-2 {
+		return netip.Prefix{}, fmt.Errorf("WireGuard CIDR %q is too small", value)
+	}
+	return prefix.Masked(), nil
+}
+
+// IPAMService leases WireGuard addresses to servers. Leases are unique per address and per server
+// in the database, and allocations within a process are serialized.
+type IPAMService struct {
+	repo       repository.IPLeaseRepository
+	serverRepo repository.ServerRepository
+	prefix     netip.Prefix
+	mu         sync.Mutex
+}
+
+func NewIPAMService(repo repository.IPLeaseRepository, serverRepo repository.ServerRepository, prefix netip.Prefix) *IPAMService {
+	return &IPAMService{
+		repo:       repo,
+		serverRepo: serverRepo,
+		prefix:     prefix,
+	}
+}
+
+// Prefix returns the pool addresses are leased from.
+func (s *IPAMService) Prefix() netip.Prefix {
+	return s.prefix
+}
+
+// Allocate returns the address leased to the server, leasing one when it has none. An address the
+// server already uses is kept when it belongs to the pool and is still free.
+func (s *IPAMService) Allocate(ctx context.Context, server *entity.Server) (netip.Addr, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lease, err := s.repo.GetByServerID(ctx, server.Id)
+	if err == nil {
+		return netip.ParseAddr(lease.Address)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return netip.Addr{}, err
+	}
+
+	leased, err := s.leased(ctx, server.Id)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+
+	if current, err := netip.ParseAddr(server.InternalIP); err == nil && s.usable(current) && !leased[current] {
+		if ok, err := s.lease(ctx, server.Id, current); err != nil {
+			return netip.Addr{}, err
+		} else if ok {
+			return current, nil
+		}
+	}
+
+	for addr := s.prefix.Addr(); s.prefix.Contains(addr); addr = addr.Next() {
+		if !s.usable(addr) || leased[addr] {
+			continue
+		}
+
+		ok, err := s.lease(ctx, server.Id, addr)
+		if err != nil {
+			return netip.Addr{}, err
+		}
+		if ok {
+			return addr, nil
+		}
+	}
+
+	return netip.Addr{}, fmt.Errorf("%w %s", ErrPoolExhausted, s.prefix)
+}
+
+// Release frees the address leased to the server.
+func (s *IPAMService) Release(ctx context.Context, serverID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.repo.DeleteByServerID(ctx, serverID)
+}
+
+// leased returns the addresses taken by servers other than serverID: those leased, and those
+// servers were given before leases were recorded and have not been leased yet.
+func (s *IPAMService) leased(ctx context.Context, serverID string) (map[netip.Addr]bool, error) {
+	leases, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	servers, err := s.serverRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	leased := make(map[netip.Addr]bool, len(leases)+len(servers))
+	for _, lease := range leases {
+		if addr, err := netip.ParseAddr(lease.Address); err == nil {
+			leased[addr] = true
+		}
+	}
+	for _, other := range servers {
+		if other.Id == serverID {
+			continue
+		}
+		if addr, err := netip.ParseAddr(other.InternalIP); err == nil {
+			leased[addr] = true
+		}
+	}
+	return leased, nil
+}
+
+// lease records the lease and reports false when the address was taken in the meantime.
+func (s *IPAMService) lease(ctx context.Context, serverID string, addr netip.Addr) (bool, error) {
+	_, err := s.repo.Create(ctx, &entity.IPLease{
+		Address:  addr.String(),
+		ServerID: serverID,
+	})
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// usable reports whether addr can be leased to a server: inside the pool and neither the network
+// nor broadcast address.
+func (s *IPAMService) usable(addr netip.Addr) bool {
+	if !s.prefix.Contains(addr) {
+		return false
+	}
+	return addr != s.prefix.Addr() && addr != lastAddr(s.prefix)
+}
+
+// lastAddr returns the highest address of the prefix, the broadcast address for IPv4.
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	bytes := prefix.Addr().AsSlice()
+	for bit := prefix.Bits(); bit < len(bytes)*8; bit++ {
+		bytes[bit/8] |= 1 << (7 - bit%8)
+	}
+	addr, _ := netip.AddrFromSlice(bytes)
+	return addr
+}
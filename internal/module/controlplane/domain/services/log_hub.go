@@ -0,0 +1,387 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zhinea/sylix/internal/common/workflow"
+)
+
+const (
+	// logPollInterval is how often a tailed file is checked for new lines.
+	logPollInterval = 250 * time.Millisecond
+	// logReadChunk bounds how much of a file one check reads at once.
+	logReadChunk = 1 << 20
+	// logQueueLimit is how many events a viewer may fall behind before it is dropped.
+	logQueueLimit = 10000
+)
+
+// ErrLogViewerTooSlow ends subscriptions that fell too far behind. The viewer can subscribe again
+// from the offset of the last line it got.
+var ErrLogViewerTooSlow = errors.New("log viewer fell too far behind")
+
+// LogEvent is one of: a line of a tailed file, a step of the run writing the file changing status,
+// or the file starting over after it was truncated.
+type LogEvent struct {
+	Line string
+	// Offset is where the next line starts in the file, to subscribe again from
+	Offset int64
+	Step   *LogStepEvent
+	// Reset is set when the file was truncated, the lines after it are read from its beginning
+	Reset bool
+}
+
+// LogStepEvent reports a step of a workflow or deployment starting or finishing.
+type LogStepEvent struct {
+	Index  int
+	Name   string
+	Host   string
+	Status string
+	Error  string
+	Time   time.Time
+}
+
+// workflowStepEvent converts a step event of the workflow engine.
+func workflowStepEvent(event workflow.StepEvent) LogStepEvent {
+	return LogStepEvent{
+		Index:  event.Index,
+		Name:   event.Name,
+		Host:   event.Host,
+		Status: string(event.Status),
+		Error:  event.Error,
+		Time:   event.Time,
+	}
+}
+
+// LogHub tails log files for the viewers watching them. However many viewers watch a file, it is
+// read once and every line is fanned out to all of them. Runs writing a file publish the status
+// changes of their steps to its viewers, in order with the lines written before.
+type LogHub struct {
+	mu    sync.Mutex
+	tails map[string]*logTail
+}
+
+// logTail reads the lines appended to a file for its subscribers.
+type logTail struct {
+	path string
+	stop chan struct{}
+
+	mu     sync.Mutex
+	offset int64
+	subs   map[*LogSubscription]struct{}
+}
+
+func NewLogHub() *LogHub {
+	return &LogHub{
+		tails: make(map[string]*logTail),
+	}
+}
+
+// Subscribe starts watching the file at path from offset. A negative offset, or one past the
+// lines read so far, only watches lines appended from now on. The file need not exist yet.
+func (h *LogHub) Subscribe(path string, offset int64) (*LogSubscription, error) {
+	path = filepath.Clean(path)
+
+	h.mu.Lock()
+	tail, ok := h.tails[path]
+	if !ok {
+		start, err := lineStart(path)
+		if err != nil {
+			h.mu.Unlock()
+			return nil, err
+		}
+		tail = &logTail{
+			path:   path,
+			stop:   make(chan struct{}),
+			offset: start,
+			subs:   make(map[*LogSubscription]struct{}),
+		}
+		h.tails[path] = tail
+		go tail.run()
+	}
+
+	sub := &LogSubscription{
+		hub:    h,
+		tail:   tail,
+		notify: make(chan struct{}, 1),
+	}
+	tail.mu.Lock()
+	tail.subs[sub] = struct{}{}
+	until := tail.offset
+	tail.mu.Unlock()
+	h.mu.Unlock()
+
+	// Lines written before the subscription are read from the file, the tail passes on the rest
+	if offset >= 0 && offset < until {
+		backlog, err := openBacklog(path, offset, until)
+		if err != nil {
+			sub.Close()
+			return nil, err
+		}
+		sub.backlog = backlog
+	}
+	return sub, nil
+}
+
+// PublishStep passes the step event on to the viewers of the file at path, after the lines
+// written to it so far.
+func (h *LogHub) PublishStep(path string, event LogStepEvent) {
+	h.mu.Lock()
+	tail, ok := h.tails[filepath.Clean(path)]
+	h.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	tail.mu.Lock()
+	defer tail.mu.Unlock()
+	tail.read()
+	tail.broadcast(LogEvent{Offset: tail.offset, Step: &event})
+}
+
+func (h *LogHub) unsubscribe(sub *LogSubscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	tail := sub.tail
+	tail.mu.Lock()
+	delete(tail.subs, sub)
+	empty := len(tail.subs) == 0
+	tail.mu.Unlock()
+
+	if empty && h.tails[tail.path] == tail {
+		delete(h.tails, tail.path)
+		close(tail.stop)
+	}
+}
+
+func (t *logTail) run() {
+	ticker := time.NewTicker(logPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.stop:
+			return
+		case <-ticker.C:
+			t.mu.Lock()
+			t.read()
+			t.mu.Unlock()
+		}
+	}
+}
+
+// read passes the complete lines appended since the last read on to the subscribers. t.mu must be held.
+func (t *logTail) read() {
+	file, err := os.Open(t.path)
+	if err != nil {
+		// Not written yet, or removed
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return
+	}
+	if info.Size() < t.offset {
+		t.offset = 0
+		t.broadcast(LogEvent{Reset: true})
+	}
+
+	buf := make([]byte, min(info.Size()-t.offset, logReadChunk))
+	var partial []byte
+	for t.offset < info.Size() {
+		n, err := file.ReadAt(buf, t.offset+int64(len(partial)))
+		if n == 0 {
+			return
+		}
+
+		data := append(partial, buf[:n]...)
+		partial = nil
+		for {
+			i := bytes.IndexByte(data, '\n')
+			if i < 0 {
+				break
+			}
+			t.offset += int64(i + 1)
+			t.broadcast(LogEvent{Line: strings.TrimSuffix(string(data[:i]), "\r"), Offset: t.offset})
+			data = data[i+1:]
+		}
+		// An unterminated line is read again once it is complete
+		partial = data
+		if err != nil {
+			return
+		}
+	}
+}
+
+// broadcast queues the event for every subscriber. t.mu must be held.
+func (t *logTail) broadcast(event LogEvent) {
+	for sub := range t.subs {
+		sub.push(event)
+	}
+}
+
+// LogSubscription is a viewer's watch of a log file.
+type LogSubscription struct {
+	hub     *LogHub
+	tail    *logTail
+	backlog *logBacklog
+
+	mu     sync.Mutex
+	queue  []LogEvent
+	err    error
+	notify chan struct{}
+	closed bool
+}
+
+// Next returns the next event, waiting for one until ctx is done.
+func (s *LogSubscription) Next(ctx context.Context) (LogEvent, error) {
+	if s.backlog != nil {
+		event, ok, err := s.backlog.next()
+		if err != nil {
+			return LogEvent{}, err
+		}
+		if ok {
+			return event, nil
+		}
+		s.backlog.close()
+		s.backlog = nil
+	}
+
+	for {
+		s.mu.Lock()
+		if len(s.queue) > 0 {
+			event := s.queue[0]
+			s.queue = s.queue[1:]
+			s.mu.Unlock()
+			return event, nil
+		}
+		err := s.err
+		s.mu.Unlock()
+		if err != nil {
+			return LogEvent{}, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return LogEvent{}, ctx.Err()
+		case <-s.notify:
+		}
+	}
+}
+
+// Close stops watching the file.
+func (s *LogSubscription) Close() {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.closed = true
+	s.mu.Unlock()
+
+	if s.backlog != nil {
+		s.backlog.close()
+	}
+	s.hub.unsubscribe(s)
+}
+
+func (s *LogSubscription) push(event LogEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.err != nil {
+		return
+	}
+	if len(s.queue) >= logQueueLimit {
+		s.queue = nil
+		s.err = ErrLogViewerTooSlow
+	} else {
+		s.queue = append(s.queue, event)
+	}
+
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+// logBacklog reads the lines of a file between two offsets.
+type logBacklog struct {
+	file   *os.File
+	reader *bufio.Reader
+	offset int64
+}
+
+func openBacklog(path string, from, until int64) (*logBacklog, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return &logBacklog{
+		file:   file,
+		reader: bufio.NewReader(io.NewSectionReader(file, from, until-from)),
+		offset: from,
+	}, nil
+}
+
+func (b *logBacklog) next() (LogEvent, bool, error) {
+	line, err := b.reader.ReadString('\n')
+	if line == "" && err == io.EOF {
+		return LogEvent{}, false, nil
+	}
+	if err != nil && err != io.EOF {
+		return LogEvent{}, false, err
+	}
+
+	b.offset += int64(len(line))
+	line = strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+	return LogEvent{Line: line, Offset: b.offset}, true, nil
+}
+
+func (b *logBacklog) close() {
+	b.file.Close()
+}
+
+// lineStart returns the offset after the last complete line of the file at path, 0 when it does
+// not exist.
+func lineStart(path string) (int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	buf := make([]byte, 4096)
+	end := info.Size()
+	for end > 0 {
+		start := max(end-int64(len(buf)), 0)
+		n, err := file.ReadAt(buf[:end-start], start)
+		if err != nil && err != io.EOF {
+			return 0, err
+		}
+		if i := bytes.LastIndexByte(buf[:n], '\n'); i >= 0 {
+			return start + int64(i) + 1, nil
+		}
+		end = start
+	}
+	return 0, nil
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// LogRecord is a record of a JSON log written by zap, such as the controlplane's own log.
+type LogRecord struct {
+	Time       time.Time
+	Level      string
+	Message    string
+	Logger     string
+	Caller     string
+	Stacktrace string
+	// Fields holds the other fields of the record, values that are not JSON strings as JSON
+	Fields map[string]string
+
+	File string
+	Line int
+}
+
+// LogQuery selects records of a JSON log. Empty criteria select every record.
+type LogQuery struct {
+	// Level is the lowest level of the records
+	Level string
+	// Since and Until bound the time of the records, Until excluded
+	Since time.Time
+	Until time.Time
+	// Fields must all be set on the records with these values, such as server_id
+	Fields map[string]string
+	// Message is text the message of the records contains, whatever its case
+	Message string
+}
+
+// LogQueryResult is a page of the records matching a query.
+type LogQueryResult struct {
+	Records      []LogRecord
+	TotalRecords int
+	Page         int
+	TotalPages   int
+}
+
+// Query returns a page of the records of the JSON log files at paths matching query, the files
+// read in the order given. Pages count from the first record or, when reverse, from the latest
+// one. Lines that are not zap records are left out.
+func (r *LogReader) Query(ctx context.Context, paths []string, query LogQuery, page, pageSize int, reverse bool) (*LogQueryResult, error) {
+	match, err := query.matcher()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := scanPage(ctx, paths, page, pageSize, reverse, func(path string, n int, line string) (LogRecord, bool) {
+		record, ok := parseLogRecord(line)
+		if !ok || !match(record) {
+			return LogRecord{}, false
+		}
+		record.File = path
+		record.Line = n
+		return *record, true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &LogQueryResult{
+		Records:      result.items,
+		TotalRecords: result.total,
+		Page:         result.page,
+		TotalPages:   result.totalPages,
+	}, nil
+}
+
+// Prune returns the rotated backups of the log at path that can hold records matching the query,
+// leaving out those rotated before Since.
+func (q LogQuery) Prune(path string, backups []string) []string {
+	if q.Since.IsZero() {
+		return backups
+	}
+	name := filepath.Base(path)
+	prefix := strings.TrimSuffix(name, filepath.Ext(name)) + "-"
+	var pruned []string
+	for _, backup := range backups {
+		// A backup was rotated after its last record, older ones hold nothing since then
+		rotated, ok := backupTime(filepath.Base(backup), prefix, filepath.Ext(name))
+		if ok && rotated.Before(q.Since) {
+			continue
+		}
+		pruned = append(pruned, backup)
+	}
+	return pruned
+}
+
+// matcher compiles the query into a function telling whether a record matches it.
+func (q LogQuery) matcher() (func(record *LogRecord) bool, error) {
+	level := zapcore.DebugLevel
+	if q.Level != "" {
+		var err error
+		level, err = zapcore.ParseLevel(q.Level)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidLogFilter, err)
+		}
+	}
+	if !q.Since.IsZero() && !q.Until.IsZero() && !q.Since.Before(q.Until) {
+		return nil, fmt.Errorf("%w: since must be before until", ErrInvalidLogFilter)
+	}
+	message := strings.ToLower(q.Message)
+
+	return func(record *LogRecord) bool {
+		recordLevel, err := zapcore.ParseLevel(record.Level)
+		if err != nil || recordLevel < level {
+			return false
+		}
+		if !q.Since.IsZero() && record.Time.Before(q.Since) {
+			return false
+		}
+		if !q.Until.IsZero() && !record.Time.Before(q.Until) {
+			return false
+		}
+		for key, value := range q.Fields {
+			if v, ok := record.Fields[key]; !ok || v != value {
+				return false
+			}
+		}
+		return message == "" || strings.Contains(strings.ToLower(record.Message), message)
+	}, nil
+}
+
+// parseLogRecord parses a line written by zap's production JSON encoder.
+func parseLogRecord(line string) (*LogRecord, bool) {
+	if !strings.HasPrefix(line, "{") {
+		return nil, false
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(line))
+	decoder.UseNumber()
+	var fields map[string]any
+	if err := decoder.Decode(&fields); err != nil {
+		return nil, false
+	}
+
+	record := &LogRecord{Fields: make(map[string]string)}
+	for key, value := range fields {
+		text, ok := value.(string)
+		switch key {
+		case "level":
+			record.Level = text
+		case "ts":
+			// Seconds since the epoch, as zapcore.EpochTimeEncoder writes them
+			number, isNumber := value.(json.Number)
+			seconds, err := number.Float64()
+			if !isNumber || err != nil {
+				return nil, false
+			}
+			record.Time = time.Unix(0, int64(seconds*float64(time.Second)))
+		case "msg":
+			record.Message = text
+		case "logger":
+			record.Logger = text
+		case "caller":
+			record.Caller = text
+		case "stacktrace":
+			record.Stacktrace = text
+		default:
+			if ok {
+				record.Fields[key] = text
+			} else if number, isNumber := value.(json.Number); isNumber {
+				record.Fields[key] = number.String()
+			} else {
+				data, _ := json.Marshal(value)
+				record.Fields[key] = string(data)
+			}
+		}
+	}
+	if record.Level == "" || record.Time.IsZero() {
+		return nil, false
+	}
+	return record, true
+}
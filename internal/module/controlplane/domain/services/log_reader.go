@@ -0,0 +1,570 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	// logIndexStride is how many lines apart the offsets kept by a line index are. Reading a line
+	// seeks to the closest offset before it and skips at most this many lines.
+	logIndexStride = 64
+	// logIndexCacheSize is how many files the line indexes are kept for.
+	logIndexCacheSize = 128
+	// DefaultLogPageSize is the page size of log reads and searches that do not ask for one.
+	DefaultLogPageSize = 100
+	// MaxLogPageSize bounds the lines of one page.
+	MaxLogPageSize = 5000
+
+	// logBackupTimeFormat is the timestamp lumberjack puts in the names of rotated files.
+	logBackupTimeFormat = "2006-01-02T15-04-05.000"
+)
+
+var ErrInvalidLogFilter = errors.New("invalid log filter")
+
+// LogPage is a page of the lines of a log file.
+type LogPage struct {
+	Lines      []string
+	TotalLines int
+	Page       int
+	TotalPages int
+}
+
+// LogFilter selects lines of log files. A line matches when it contains Query, or matches it as
+// a regular expression with Regex, and, with Level, is a JSON record of that level or above.
+type LogFilter struct {
+	Query      string
+	Regex      bool
+	IgnoreCase bool
+	Level      string
+}
+
+// LogMatch is a line matching a search, Line counting from 1 in File.
+type LogMatch struct {
+	File string
+	Line int
+	Text string
+}
+
+// LogSearchResult is a page of the lines matching a search.
+type LogSearchResult struct {
+	Matches      []LogMatch
+	TotalMatches int
+	Page         int
+	TotalPages   int
+}
+
+// LogReader reads pages of log files without loading them whole. It keeps an index of where
+// every logIndexStride-th line starts in recently read files, extended as the files grow, so a
+// page only reads its own lines. Files ending in .gz, such as rotated logs, are read decompressed.
+type LogReader struct {
+	mu      sync.Mutex
+	indexes map[string]*lineIndex
+}
+
+// lineIndex locates the lines of a file. Offsets of compressed files are in the decompressed data.
+type lineIndex struct {
+	mu   sync.Mutex
+	used time.Time
+
+	info    os.FileInfo // the file indexed, to notice it being replaced
+	size    int64       // bytes of the file read so far
+	marks   []int64     // marks[k] is where line k*logIndexStride starts
+	lines   int         // complete lines
+	end     int64       // where the line after the last complete one starts
+	partial bool        // whether an unterminated line follows end
+}
+
+func NewLogReader() *LogReader {
+	return &LogReader{
+		indexes: make(map[string]*lineIndex),
+	}
+}
+
+// Page returns a page of the lines of the file at path, pages counting from the start of the file
+// or, when reverse, from its end, so that page 1 holds the latest lines. The lines of a page are
+// in file order either way.
+func (r *LogReader) Page(path string, page, pageSize int, reverse bool) (*LogPage, error) {
+	pageSize = logPageSize(pageSize)
+
+	idx, err := r.index(path)
+	if err != nil {
+		return nil, err
+	}
+
+	total := idx.lines
+	if idx.partial {
+		total++
+	}
+	totalPages, page := pages(total, page, pageSize)
+
+	start := (page - 1) * pageSize
+	end := min(start+pageSize, total)
+	if reverse {
+		end = total - (page-1)*pageSize
+		start = max(end-pageSize, 0)
+	}
+
+	lines, err := readLines(path, idx, start, end-start)
+	if err != nil {
+		return nil, err
+	}
+	return &LogPage{
+		Lines:      lines,
+		TotalLines: total,
+		Page:       page,
+		TotalPages: totalPages,
+	}, nil
+}
+
+// Search returns a page of the lines of the files matching filter, the files read in the order
+// given. Pages count from the first match or, when reverse, from the last one.
+func (r *LogReader) Search(ctx context.Context, paths []string, filter LogFilter, page, pageSize int, reverse bool) (*LogSearchResult, error) {
+	match, err := filter.matcher()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := scanPage(ctx, paths, page, pageSize, reverse, func(path string, n int, line string) (LogMatch, bool) {
+		if !match(line) {
+			return LogMatch{}, false
+		}
+		return LogMatch{File: path, Line: n, Text: line}, true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &LogSearchResult{
+		Matches:      result.items,
+		TotalMatches: result.total,
+		Page:         result.page,
+		TotalPages:   result.totalPages,
+	}, nil
+}
+
+// matchPage is a page of the items scanPage found.
+type matchPage[T any] struct {
+	items      []T
+	total      int
+	page       int
+	totalPages int
+}
+
+// scanPage reads the lines of the files in order and returns a page of the items match finds in
+// them. Pages count from the first item or, when reverse, from the last one.
+func scanPage[T any](ctx context.Context, paths []string, page, pageSize int, reverse bool, match func(path string, n int, line string) (T, bool)) (*matchPage[T], error) {
+	pageSize = logPageSize(pageSize)
+	page = max(page, 1)
+
+	// Going forward only the items of the page are kept. Going back the page is only known once
+	// all items are counted, so the last page*pageSize of them are kept.
+	keep := page * pageSize
+	var items []T
+	total := 0
+	for _, path := range paths {
+		err := scanLines(ctx, path, func(n int, line string) {
+			item, ok := match(path, n, line)
+			if !ok {
+				return
+			}
+			total++
+			if !reverse {
+				if total > (page-1)*pageSize && total <= keep {
+					items = append(items, item)
+				}
+				return
+			}
+			items = append(items, item)
+			if len(items) >= 2*keep {
+				items = append(items[:0], items[len(items)-keep:]...)
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	totalPages, clamped := pages(total, page, pageSize)
+	if !reverse {
+		if clamped != page {
+			// Past the last page, which is read again
+			return scanPage(ctx, paths, clamped, pageSize, reverse, match)
+		}
+		return &matchPage[T]{items: items, total: total, page: page, totalPages: totalPages}, nil
+	}
+
+	// items holds at least the last min(total, keep) items, the page is counted from the end
+	end := len(items) - (clamped-1)*pageSize
+	start := max(end-pageSize, 0)
+	return &matchPage[T]{items: items[start:end], total: total, page: clamped, totalPages: totalPages}, nil
+}
+
+// LogBackups returns the rotated backups lumberjack left of the log file at path, oldest first.
+func LogBackups(path string) ([]string, error) {
+	dir, name := filepath.Split(path)
+	ext := filepath.Ext(name)
+	prefix := strings.TrimSuffix(name, ext) + "-"
+
+	entries, err := os.ReadDir(filepath.Clean(dir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	type backup struct {
+		path string
+		time time.Time
+	}
+	var backups []backup
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		t, ok := backupTime(entry.Name(), prefix, ext)
+		if !ok {
+			continue
+		}
+		backups = append(backups, backup{path: filepath.Join(dir, entry.Name()), time: t})
+	}
+
+	sort.Slice(backups, func(i, j int) bool { return backups[i].time.Before(backups[j].time) })
+	paths := make([]string, len(backups))
+	for i, b := range backups {
+		paths[i] = b.path
+	}
+	return paths, nil
+}
+
+// backupTime returns when the file of a rotated backup was rotated, which is after the last
+// line written to it. Its name is the log file's with the time added before the extension.
+func backupTime(name, prefix, ext string) (time.Time, bool) {
+	stamp, ok := strings.CutPrefix(name, prefix)
+	if !ok {
+		return time.Time{}, false
+	}
+	stamp, ok = strings.CutSuffix(strings.TrimSuffix(stamp, ".gz"), ext)
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(logBackupTimeFormat, stamp)
+	return t, err == nil
+}
+
+// index returns the line index of the file at path, brought up to date.
+func (r *LogReader) index(path string) (*lineIndex, error) {
+	path = filepath.Clean(path)
+
+	r.mu.Lock()
+	idx, ok := r.indexes[path]
+	if !ok {
+		idx = &lineIndex{}
+		r.indexes[path] = idx
+	}
+	idx.used = time.Now()
+	r.evict()
+	r.mu.Unlock()
+
+	idx.mu.Lock()
+	defer idx.mu.Unlock()
+	if err := idx.update(path); err != nil {
+		return nil, err
+	}
+	// Callers get a copy, later updates only append to the marks they share
+	return &lineIndex{
+		marks:   idx.marks,
+		lines:   idx.lines,
+		end:     idx.end,
+		partial: idx.partial,
+	}, nil
+}
+
+// evict drops the least recently used indexes past logIndexCacheSize. r.mu must be held.
+func (r *LogReader) evict() {
+	for len(r.indexes) > logIndexCacheSize {
+		var oldest string
+		var used time.Time
+		for path, idx := range r.indexes {
+			if oldest == "" || idx.used.Before(used) {
+				oldest, used = path, idx.used
+			}
+		}
+		delete(r.indexes, oldest)
+	}
+}
+
+// update indexes the lines appended to the file since the last update, or the whole file when it
+// was replaced, truncated or is compressed and changed. idx.mu must be held.
+func (idx *lineIndex) update(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return err
+	}
+
+	compressed := strings.HasSuffix(path, ".gz")
+	same := idx.info != nil && os.SameFile(idx.info, info)
+	switch {
+	case same && info.Size() == idx.size && info.ModTime().Equal(idx.info.ModTime()):
+		return nil
+	case same && !compressed && info.Size() > idx.size && endsLine(file, idx.end):
+		// Appended to, indexing goes on from the last complete line
+	default:
+		idx.marks = []int64{0}
+		idx.lines = 0
+		idx.end = 0
+	}
+	idx.info = info
+
+	var reader io.Reader = file
+	if compressed {
+		gz, err := gzip.NewReader(file)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		reader = gz
+	} else if _, err := file.Seek(idx.end, io.SeekStart); err != nil {
+		return err
+	}
+
+	offset := idx.end
+	buf := make([]byte, 64*1024)
+	for {
+		n, err := reader.Read(buf)
+		data := buf[:n]
+		for {
+			i := bytes.IndexByte(data, '\n')
+			if i < 0 {
+				break
+			}
+			offset += int64(i + 1)
+			data = data[i+1:]
+			idx.lines++
+			idx.end = offset
+			if idx.lines%logIndexStride == 0 {
+				idx.marks = append(idx.marks, offset)
+			}
+		}
+		offset += int64(len(data))
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	idx.partial = offset > idx.end
+	idx.size = info.Size()
+	if !compressed {
+		idx.size = offset
+	}
+	return nil
+}
+
+// endsLine reports whether a line ends right before offset, as it does in a file that was only
+// appended to since offset was indexed.
+func endsLine(file *os.File, offset int64) bool {
+	if offset == 0 {
+		return true
+	}
+	b := make([]byte, 1)
+	_, err := file.ReadAt(b, offset-1)
+	return err == nil && b[0] == '\n'
+}
+
+// readLines reads count lines of the file at path from line start, located with idx.
+func readLines(path string, idx *lineIndex, start, count int) ([]string, error) {
+	if count <= 0 {
+		return []string{}, nil
+	}
+
+	reader, err := openLog(path)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	mark := start / logIndexStride
+	if err := reader.skip(idx.marks[mark]); err != nil {
+		return nil, err
+	}
+
+	buf := bufio.NewReaderSize(reader, 64*1024)
+	lines := make([]string, 0, count)
+	for n := mark * logIndexStride; len(lines) < count; n++ {
+		line, err := buf.ReadString('\n')
+		if line == "" && err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		if n >= start {
+			lines = append(lines, trimLine(line))
+		}
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+	}
+	return lines, nil
+}
+
+// scanLines calls fn with every line of the file at path and its number, counting from 1.
+func scanLines(ctx context.Context, path string, fn func(n int, line string)) error {
+	reader, err := openLog(path)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	buf := bufio.NewReaderSize(reader, 64*1024)
+	for n := 1; ; n++ {
+		if n%10000 == 0 {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+		}
+
+		line, err := buf.ReadString('\n')
+		if line != "" {
+			fn(n, trimLine(line))
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// logFile reads a log file, decompressed when its name ends in .gz.
+type logFile struct {
+	io.Reader
+	file *os.File
+	gz   *gzip.Reader
+}
+
+func openLog(path string) (*logFile, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasSuffix(path, ".gz") {
+		return &logFile{Reader: file, file: file}, nil
+	}
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &logFile{Reader: gz, file: file, gz: gz}, nil
+}
+
+// skip moves to offset. Compressed files can only be read up to it.
+func (f *logFile) skip(offset int64) error {
+	if f.gz == nil {
+		_, err := f.file.Seek(offset, io.SeekStart)
+		return err
+	}
+	_, err := io.CopyN(io.Discard, f.gz, offset)
+	return err
+}
+
+func (f *logFile) Close() error {
+	if f.gz != nil {
+		f.gz.Close()
+	}
+	return f.file.Close()
+}
+
+// matcher compiles the filter into a function telling whether a line matches it.
+func (f LogFilter) matcher() (func(line string) bool, error) {
+	matchText := func(string) bool { return true }
+	switch {
+	case f.Query == "":
+	case f.Regex:
+		expr := f.Query
+		if f.IgnoreCase {
+			expr = "(?i)" + expr
+		}
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidLogFilter, err)
+		}
+		matchText = re.MatchString
+	case f.IgnoreCase:
+		query := strings.ToLower(f.Query)
+		matchText = func(line string) bool { return strings.Contains(strings.ToLower(line), query) }
+	default:
+		matchText = func(line string) bool { return strings.Contains(line, f.Query) }
+	}
+
+	if f.Level == "" {
+		return matchText, nil
+	}
+	level, err := zapcore.ParseLevel(f.Level)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidLogFilter, err)
+	}
+	return func(line string) bool {
+		lineLevel, ok := recordLevel(line)
+		return ok && lineLevel >= level && matchText(line)
+	}, nil
+}
+
+// recordLevel returns the level of a JSON log record, as written by zap.
+func recordLevel(line string) (zapcore.Level, bool) {
+	if !strings.HasPrefix(line, "{") {
+		return 0, false
+	}
+	var record struct {
+		Level string `json:"level"`
+	}
+	if err := json.Unmarshal([]byte(line), &record); err != nil || record.Level == "" {
+		return 0, false
+	}
+	level, err := zapcore.ParseLevel(record.Level)
+	return level, err == nil
+}
+
+func logPageSize(pageSize int) int {
+	if pageSize <= 0 {
+		return DefaultLogPageSize
+	}
+	return min(pageSize, MaxLogPageSize)
+}
+
+// pages returns how many pages total items make and page clamped to them.
+func pages(total, page, pageSize int) (int, int) {
+	totalPages := max((total+pageSize-1)/pageSize, 1)
+	return totalPages, min(max(page, 1), totalPages)
+}
+
+func trimLine(line string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r")
+}
@@ -0,0 +1,427 @@
+package services
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/zhinea/sylix/internal/common/logger"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/repository"
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+)
+
+const (
+	DefaultLogRetentionDays = 7
+	DefaultLogLocalCapMB    = 1024
+
+	// logRetentionInterval is how often the retention policy is applied.
+	logRetentionInterval = time.Hour
+	// logArchiveCacheTTL is how long downloaded archives are kept for further reads.
+	logArchiveCacheTTL = 24 * time.Hour
+	// logArchivePrefix is where archives go in the bucket, followed by their path under logs/.
+	logArchivePrefix = "sylix/"
+)
+
+// LogRetentionPolicy tells what happens to log files that are no longer written to.
+type LogRetentionPolicy struct {
+	// CompressAfter is how long a file must not have been written to before it is compressed
+	CompressAfter time.Duration
+	// LocalCap is how many bytes the logs may take on disk before uploaded files are deleted,
+	// oldest first. Files that are not uploaded are never deleted. 0 disables the cap.
+	LocalCap int64
+	// StorageID is the backup storage compressed files are uploaded to, none when empty
+	StorageID string
+}
+
+// ParseLogRetention parses the number of days after which logs are compressed, the cap in MB on
+// the disk space they take and the backup storage they are uploaded to. Empty values yield the
+// defaults, and no uploads.
+func ParseLogRetention(days, capMB, storageID string) (LogRetentionPolicy, error) {
+	policy := LogRetentionPolicy{
+		CompressAfter: DefaultLogRetentionDays * 24 * time.Hour,
+		LocalCap:      DefaultLogLocalCapMB << 20,
+		StorageID:     strings.TrimSpace(storageID),
+	}
+	if days != "" {
+		n, err := strconv.Atoi(strings.TrimSpace(days))
+		if err != nil || n < 1 {
+			return LogRetentionPolicy{}, fmt.Errorf("invalid log retention %q, expected a number of days", days)
+		}
+		policy.CompressAfter = time.Duration(n) * 24 * time.Hour
+	}
+	if capMB != "" {
+		n, err := strconv.ParseInt(strings.TrimSpace(capMB), 10, 64)
+		if err != nil || n < 0 {
+			return LogRetentionPolicy{}, fmt.Errorf("invalid log cap %q, expected a number of MB", capMB)
+		}
+		policy.LocalCap = n << 20
+	}
+	return policy, nil
+}
+
+// LogRetentionService keeps the logs under root from filling the disk. Files not written to for
+// a while are compressed into rotated backups, named as lumberjack names them so they are read
+// and searched along with the file. Compressed files are uploaded to backup storage, after which
+// their local copies may be deleted to stay under the cap. Reading a deleted file downloads it.
+type LogRetentionService struct {
+	repo        repository.LogArchiveRepository
+	storageRepo repository.BackupStorageRepository
+	policy      LogRetentionPolicy
+	root        string
+	cacheDir    string
+	// active are files held open by their writers, such as the controlplane's own log
+	active map[string]bool
+
+	applyMu sync.Mutex
+	fetchMu sync.Mutex
+}
+
+func NewLogRetentionService(
+	repo repository.LogArchiveRepository,
+	storageRepo repository.BackupStorageRepository,
+	policy LogRetentionPolicy,
+	root, cacheDir string,
+	active ...string,
+) *LogRetentionService {
+	s := &LogRetentionService{
+		repo:        repo,
+		storageRepo: storageRepo,
+		policy:      policy,
+		root:        filepath.Clean(root),
+		cacheDir:    cacheDir,
+		active:      make(map[string]bool),
+	}
+	for _, path := range active {
+		s.active[filepath.Clean(path)] = true
+	}
+	return s
+}
+
+// Start applies the policy now and then every logRetentionInterval.
+func (s *LogRetentionService) Start() {
+	go func() {
+		ticker := time.NewTicker(logRetentionInterval)
+		defer ticker.Stop()
+
+		for {
+			if err := s.Apply(context.Background()); err != nil {
+				logger.Log.Error("Failed to apply log retention", zap.Error(err))
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// Apply compresses stale logs, uploads compressed ones and deletes uploaded ones past the cap.
+func (s *LogRetentionService) Apply(ctx context.Context) error {
+	s.applyMu.Lock()
+	defer s.applyMu.Unlock()
+
+	if err := s.compress(); err != nil {
+		return err
+	}
+	if err := s.upload(ctx); err != nil {
+		return err
+	}
+	if err := s.enforceCap(ctx); err != nil {
+		return err
+	}
+	s.cleanCache()
+	return nil
+}
+
+// Archives returns the uploaded files whose path starts with prefix, oldest first.
+func (s *LogRetentionService) Archives(ctx context.Context, prefix string) ([]*entity.LogArchive, error) {
+	return s.repo.GetByPrefix(ctx, prefix)
+}
+
+// Backups returns the rotated backups of the file at path, oldest first, as LogBackups does but
+// including those only left in backup storage.
+func (s *LogRetentionService) Backups(ctx context.Context, path string) ([]string, error) {
+	backups, err := LogBackups(path)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, name := filepath.Split(filepath.Clean(path))
+	ext := filepath.Ext(name)
+	prefix := strings.TrimSuffix(name, ext) + "-"
+	archives, err := s.repo.GetByPrefix(ctx, filepath.Join(dir, prefix))
+	if err != nil {
+		return nil, err
+	}
+	for _, archive := range archives {
+		if archive.Local || filepath.Dir(archive.Path) != filepath.Clean(dir) {
+			continue
+		}
+		if _, ok := backupTime(filepath.Base(archive.Path), prefix, ext); ok {
+			backups = append(backups, archive.Path)
+		}
+	}
+
+	// The times in the names sort as the names do
+	sort.Slice(backups, func(i, j int) bool { return filepath.Base(backups[i]) < filepath.Base(backups[j]) })
+	return backups, nil
+}
+
+// Fetch returns where the file at path can be read: path itself while it is on disk, otherwise
+// a copy of its archive downloaded from backup storage.
+func (s *LogRetentionService) Fetch(ctx context.Context, path string) (string, error) {
+	path = filepath.Clean(path)
+	if _, err := os.Stat(path); err == nil {
+		return path, nil
+	}
+
+	archive, err := s.repo.GetByPath(ctx, path)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return "", &fs.PathError{Op: "open", Path: path, Err: fs.ErrNotExist}
+		}
+		return "", err
+	}
+
+	s.fetchMu.Lock()
+	defer s.fetchMu.Unlock()
+
+	cached := filepath.Join(s.cacheDir, filepath.FromSlash(archive.Key))
+	if _, err := os.Stat(cached); err == nil {
+		// Kept for as long as it is read
+		now := time.Now()
+		os.Chtimes(cached, now, now)
+		return cached, nil
+	}
+
+	storage, err := s.storageRepo.GetByID(ctx, archive.StorageID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get backup storage of %s: %w", path, err)
+	}
+	client, err := newMinioClient(storage)
+	if err != nil {
+		return "", err
+	}
+	if err := client.FGetObject(ctx, archive.Bucket, archive.Key, cached, minio.GetObjectOptions{}); err != nil {
+		return "", fmt.Errorf("failed to download %s: %w", archive.Key, err)
+	}
+	return cached, nil
+}
+
+// compress turns the files not written to within CompressAfter into compressed backups.
+func (s *LogRetentionService) compress() error {
+	cutoff := time.Now().Add(-s.policy.CompressAfter)
+	compressed := 0
+	err := s.walk(func(path string, info fs.FileInfo) error {
+		// .tmp files are left by compressions that were interrupted
+		if strings.HasSuffix(path, ".gz") || strings.HasSuffix(path, ".tmp") || s.active[path] || !info.ModTime().Before(cutoff) {
+			return nil
+		}
+		if err := compressLog(path, info); err != nil {
+			return err
+		}
+		compressed++
+		return nil
+	})
+	if compressed > 0 {
+		logger.Log.Info("Compressed stale logs", zap.Int("files", compressed))
+	}
+	return err
+}
+
+// upload sends the compressed files that are not archived yet to the backup storage.
+func (s *LogRetentionService) upload(ctx context.Context) error {
+	if s.policy.StorageID == "" {
+		return nil
+	}
+	storage, err := s.storageRepo.GetByID(ctx, s.policy.StorageID)
+	if err != nil {
+		return fmt.Errorf("failed to get log archive storage: %w", err)
+	}
+	client, err := newMinioClient(storage)
+	if err != nil {
+		return err
+	}
+
+	uploaded := 0
+	err = s.walk(func(path string, info fs.FileInfo) error {
+		if !strings.HasSuffix(path, ".gz") {
+			return nil
+		}
+		if _, err := s.repo.GetByPath(ctx, path); !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		key := logArchivePrefix + filepath.ToSlash(path)
+		if _, err := client.FPutObject(ctx, storage.Bucket, key, path, minio.PutObjectOptions{ContentType: "application/gzip"}); err != nil {
+			return fmt.Errorf("failed to upload %s: %w", path, err)
+		}
+		_, err := s.repo.Create(ctx, &entity.LogArchive{
+			Path:       path,
+			StorageID:  storage.Id,
+			Bucket:     storage.Bucket,
+			Key:        key,
+			Size:       info.Size(),
+			Local:      true,
+			ModifiedAt: info.ModTime(),
+			ArchivedAt: time.Now(),
+		})
+		if err != nil {
+			return err
+		}
+		uploaded++
+		return nil
+	})
+	if uploaded > 0 {
+		logger.Log.Info("Uploaded logs to backup storage", zap.Int("files", uploaded), zap.String("storage_id", storage.Id))
+	}
+	return err
+}
+
+// enforceCap deletes the local copies of uploaded files, oldest first, until the logs take no
+// more than LocalCap.
+func (s *LogRetentionService) enforceCap(ctx context.Context) error {
+	if s.policy.LocalCap <= 0 {
+		return nil
+	}
+
+	var total int64
+	if err := s.walk(func(_ string, info fs.FileInfo) error {
+		total += info.Size()
+		return nil
+	}); err != nil {
+		return err
+	}
+	if total <= s.policy.LocalCap {
+		return nil
+	}
+
+	archives, err := s.repo.GetLocal(ctx)
+	if err != nil {
+		return err
+	}
+	removed := 0
+	for _, archive := range archives {
+		if total <= s.policy.LocalCap {
+			break
+		}
+		info, err := os.Stat(archive.Path)
+		if err == nil {
+			if err := os.Remove(archive.Path); err != nil {
+				return err
+			}
+			total -= info.Size()
+			removed++
+		} else if !os.IsNotExist(err) {
+			return err
+		}
+
+		archive.Local = false
+		if err := s.repo.Update(ctx, archive); err != nil {
+			return err
+		}
+	}
+
+	if removed > 0 {
+		logger.Log.Info("Deleted local copies of archived logs", zap.Int("files", removed))
+	}
+	if total > s.policy.LocalCap {
+		logger.Log.Warn("Logs still exceed their cap, the rest is not archived yet", zap.Int64("bytes", total), zap.Int64("cap", s.policy.LocalCap))
+	}
+	return nil
+}
+
+// cleanCache deletes the downloaded archives that were not read within logArchiveCacheTTL.
+func (s *LogRetentionService) cleanCache() {
+	s.fetchMu.Lock()
+	defer s.fetchMu.Unlock()
+
+	cutoff := time.Now().Add(-logArchiveCacheTTL)
+	filepath.WalkDir(s.cacheDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return nil
+		}
+		if info, err := entry.Info(); err == nil && info.ModTime().Before(cutoff) {
+			os.Remove(path)
+		}
+		return nil
+	})
+}
+
+// walk calls fn with every regular file under root.
+func (s *LogRetentionService) walk(fn func(path string, info fs.FileInfo) error) error {
+	err := filepath.WalkDir(s.root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		return fn(path, info)
+	})
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// compressLog replaces the file at path by a compressed backup named after the time it was last
+// written, such as provisioning-2006-01-02T15-04-05.000.log.gz for provisioning.log.
+func compressLog(path string, info fs.FileInfo) error {
+	ext := filepath.Ext(path)
+	stamp := info.ModTime().UTC().Format(logBackupTimeFormat)
+	dest := strings.TrimSuffix(path, ext) + "-" + stamp + ext + ".gz"
+	if _, err := os.Stat(dest); err == nil {
+		return fmt.Errorf("failed to compress %s: %s already exists", path, dest)
+	}
+
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	tmp := dest + ".tmp"
+	dst, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	gz := gzip.NewWriter(dst)
+	_, err = io.Copy(gz, src)
+	if err == nil {
+		err = gz.Close()
+	}
+	if closeErr := dst.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		// Backups sort by the time of their last line
+		err = os.Chtimes(tmp, info.ModTime(), info.ModTime())
+	}
+	if err == nil {
+		err = os.Rename(tmp, dest)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to compress %s: %w", path, err)
+	}
+
+	return os.Remove(path)
+}
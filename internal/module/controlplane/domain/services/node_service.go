@@ -3,31 +3,83 @@ package services
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/zhinea/sylix/internal/common/config"
 	"github.com/zhinea/sylix/internal/common/logger"
 	"github.com/zhinea/sylix/internal/common/util"
+	"github.com/zhinea/sylix/internal/common/workflow"
+	pbCommon "github.com/zhinea/sylix/internal/infra/proto/common"
 	"github.com/zhinea/sylix/internal/module/controlplane/domain/repository"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/wireguard"
+	cpWorkflow "github.com/zhinea/sylix/internal/module/controlplane/domain/workflow"
 	"github.com/zhinea/sylix/internal/module/controlplane/entity"
 	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// meshSyncWorkers bounds how many servers a mesh sync configures at the same time.
+const meshSyncWorkers = 8
+
+const (
+	agentStartTimeout = time.Minute
+	agentPollInterval = 2 * time.Second
 )
 
 type NodeService struct {
-	repo repository.ServerRepository
+	repo       repository.ServerRepository
+	meshRepo   repository.MeshSyncRepository
+	backupRepo repository.BackupStorageRepository
+	ipam       *IPAMService
+	ssh        *SSHService
+	certs      *CertificateService
+	agents     *AgentClientService
+	runs       *WorkflowRunService
+	logs       *LogHub
+	// agentURL is where the agent binary is downloaded from during installation
+	agentURL string
+
+	// syncMu serializes mesh syncs, two overlapping syncs could push stale peer lists.
+	syncMu sync.Mutex
 }
 
-func NewNodeService(repo repository.ServerRepository) *NodeService {
-	return &NodeService{
-		repo: repo,
-	}
+func NewNodeService(
+	repo repository.ServerRepository,
+	meshRepo repository.MeshSyncRepository,
+	backupRepo repository.BackupStorageRepository,
+	ipam *IPAMService,
+	ssh *SSHService,
+	certs *CertificateService,
+	agents *AgentClientService,
+	runs *WorkflowRunService,
+	logs *LogHub,
+	agentURL string,
+) *NodeService {
+	s := &NodeService{
+		repo:       repo,
+		meshRepo:   meshRepo,
+		backupRepo: backupRepo,
+		ipam:       ipam,
+		ssh:        ssh,
+		certs:      certs,
+		agents:     agents,
+		runs:       runs,
+		logs:       logs,
+		agentURL:   agentURL,
+	}
+	runs.RegisterResumer(cpWorkflow.KindAgentInstall, s.resumeProvisioning)
+	return s
 }
 
-func (s *NodeService) CheckConnection(server *entity.Server) error {
-	client, err := util.NewSSHClient(server.IpAddress, server.Port, server.Credential.Username, server.Credential.Password, server.Credential.SSHKey)
+// CheckConnection dials the server, pinning its host key when none is pinned yet.
+func (s *NodeService) CheckConnection(ctx context.Context, server *entity.Server) error {
+	client, err := s.ssh.Connect(ctx, server)
 	if err != nil {
 		return err
 	}
@@ -41,15 +93,86 @@ func (s *NodeService) CheckConnection(server *entity.Server) error {
 	return nil
 }
 
-// Install provisions the node with Docker and WireGuard
+// Install installs the agent on the node and provisions it with Docker and WireGuard
 func (s *NodeService) Install(ctx context.Context, server *entity.Server) {
-	go s.runProvisioning(ctx, server)
+	go s.runProvisioning(ctx, server, nil)
+}
+
+// PlanInstall returns what the agent install workflow would do on the server, without installing
+// anything. A certificate that is not issued yet shows up as a placeholder.
+func (s *NodeService) PlanInstall(ctx context.Context, server *entity.Server) (*workflow.Plan, error) {
+	planned := *server
+	if planned.Agent.Cert == "" || planned.Agent.Key == "" {
+		planned.Agent.Cert = "<issued when the agent is installed>"
+		planned.Agent.Key = "<issued when the agent is installed>"
+	}
+	params, err := s.agentInstallParams(ctx, &planned)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := s.ssh.Connect(ctx, server)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	engine := workflow.NewEngine(client, io.Discard, func(string) {})
+	engine.SetTarget(server.Id)
+	return engine.Plan(ctx, cpWorkflow.NewAgentInstallWorkflow(params))
+}
+
+// resumeProvisioning continues the provisioning of the server an interrupted agent install run was for.
+func (s *NodeService) resumeProvisioning(ctx context.Context, run *workflow.Run) error {
+	server, err := s.repo.GetByID(ctx, run.Target)
+	if err != nil {
+		if failErr := s.runs.Fail(ctx, run, fmt.Errorf("failed to get server: %w", err)); failErr != nil {
+			return failErr
+		}
+		return err
+	}
+
+	s.runProvisioning(ctx, server, run)
+	return nil
+}
+
+// FailInterruptedInstalls marks servers whose installation a restart interrupted without leaving a run
+// to resume as failed, so they can be installed again instead of showing as installing forever.
+func (s *NodeService) FailInterruptedInstalls(ctx context.Context) error {
+	servers, err := s.repo.GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, server := range servers {
+		switch server.Agent.Status {
+		case entity.AgentStatusInstalling, entity.AgentStatusConfiguring, entity.AgentStatusFinalizingSetup:
+		default:
+			continue
+		}
+
+		running, err := s.runs.Running(ctx, cpWorkflow.KindAgentInstall, server.Id)
+		if err != nil {
+			return err
+		}
+		if running {
+			continue
+		}
+
+		logger.Log.Warn("Marking interrupted installation failed", zap.String("server_id", server.Id))
+		s.updateStatus(ctx, server.Id, entity.AgentStatusFailed)
+	}
+	return nil
 }
 
-func (s *NodeService) runProvisioning(ctx context.Context, server *entity.Server) {
+// runProvisioning installs the agent and sets up the node. With resume set, the agent install
+// workflow continues that run instead of starting over.
+func (s *NodeService) runProvisioning(ctx context.Context, server *entity.Server, resume *workflow.Run) {
 	logger.Log.Info("Starting node provisioning", zap.String("server_id", server.Id), zap.String("ip", server.IpAddress))
 
-	s.updateStatus(ctx, server.Id, entity.AgentStatusInstalling) // reusing AgentStatus for now
+	if resume == nil {
+		s.updateStatus(ctx, server.Id, entity.AgentStatusInstalling)
+	}
 
 	// Create logs directory
 	logDir := fmt.Sprintf("logs/servers/%s", server.Id)
@@ -58,101 +181,115 @@ func (s *NodeService) runProvisioning(ctx context.Context, server *entity.Server
 		// Continue anyway, but logging might fail
 	}
 
-	logFile, err := os.OpenFile(filepath.Join(logDir, "provisioning.log"), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	// Command output is streamed into the log file as it is produced
+	var logWriter io.Writer = io.Discard
+	flags := os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+	if resume != nil {
+		flags = os.O_CREATE | os.O_WRONLY | os.O_APPEND
+	}
+	logPath := filepath.Join(logDir, "provisioning.log")
+	logFile, err := os.OpenFile(logPath, flags, 0644)
 	if err != nil {
 		logger.Log.Error("Failed to create log file", zap.Error(err))
 		// Continue anyway
 	} else {
 		defer logFile.Close()
+		logWriter = logFile
 	}
 
 	// Helper to write to log file
 	writeLog := func(msg string) {
-		if logFile != nil {
-			timestamp := time.Now().Format(time.RFC3339)
-			logFile.WriteString(fmt.Sprintf("[%s] %s\n", timestamp, msg))
-		}
+		timestamp := time.Now().Format(time.RFC3339)
+		fmt.Fprintf(logWriter, "[%s] %s\n", timestamp, msg)
 	}
 
-	client, err := util.NewSSHClient(server.IpAddress, server.Port, server.Credential.Username, server.Credential.Password, server.Credential.SSHKey)
-	if err != nil {
-		logger.Log.Error("Failed to connect via SSH", zap.Error(err))
-		writeLog(fmt.Sprintf("Failed to connect via SSH: %v", err))
+	fail := func(msg string, err error) {
+		logger.Log.Error(msg, zap.String("server_id", server.Id), zap.Error(err))
+		writeLog(fmt.Sprintf("%s: %v", msg, err))
 		s.updateStatus(ctx, server.Id, entity.AgentStatusFailed)
-		return
 	}
-	defer client.Close()
-
-	// Helper to run command and stream logs
-	runCmd := func(cmd string) error {
-		writeLog(fmt.Sprintf("Running command: %s", cmd))
-		logger.Log.Info("Running command", zap.String("cmd", cmd), zap.String("server_id", server.Id))
 
-		if logFile != nil {
-			// Create a multi-writer if we want to log to stdout too, but for now just file is requested for "logs"
-			// We use the logFile directly for stdout/stderr of the command
-			// But we need to be careful not to mix interleaved writes if we use goroutines (RunCommandStream doesn't use goroutines for writers)
-			return client.RunCommandStream(cmd, logFile, logFile)
+	// A resumed run that cannot go on is failed, so it is not resumed again on the next start
+	failRun := func(err error) {
+		if resume == nil {
+			return
+		}
+		if err := s.runs.Fail(ctx, resume, err); err != nil {
+			logger.Log.Error("Failed to mark workflow run failed", zap.String("run_id", resume.ID), zap.Error(err))
 		}
+	}
 
-		// Fallback if log file failed
-		_, err := client.RunCommand(cmd)
-		return err
+	client, err := s.ssh.Connect(ctx, server)
+	if err != nil {
+		failRun(err)
+		fail("Failed to connect via SSH", err)
+		return
 	}
+	defer client.Close()
 
-	// 1. Update & Install Dependencies
-	writeLog("Installing dependencies...")
-	cmds := []string{
-		"apt-get update",
-		"apt-get install -y curl wget gnupg lsb-release wireguard", // Install WireGuard directly
+	// 1. Install the agent
+	params, err := s.agentInstallParams(ctx, server)
+	if err != nil {
+		failRun(err)
+		fail("Failed to prepare agent installation", err)
+		return
 	}
 
-	for _, cmd := range cmds {
-		if err := runCmd(cmd); err != nil {
-			logger.Log.Error("Failed to run command", zap.String("cmd", cmd), zap.Error(err))
-			writeLog(fmt.Sprintf("Failed to run command: %v", err))
-			s.updateStatus(ctx, server.Id, entity.AgentStatusFailed)
-			return
+	engine := workflow.NewEngine(client, logWriter, writeLog)
+	engine.SetTarget(server.Id)
+	engine.SetStore(s.runs)
+	engine.OnStage(func(stage string) {
+		if status, ok := agentInstallStatus[stage]; ok {
+			s.updateStatus(ctx, server.Id, status)
 		}
+	})
+	engine.OnStep(func(event workflow.StepEvent) {
+		s.logs.PublishStep(logPath, workflowStepEvent(event))
+	})
+
+	wf := cpWorkflow.NewAgentInstallWorkflow(params)
+	if resume != nil {
+		err = engine.Resume(ctx, wf, resume)
+	} else {
+		err = engine.Run(ctx, wf)
+	}
+	if err != nil {
+		fail("Failed to install agent", err)
+		return
 	}
 
-	// 2. Install Docker (if not present)
-	writeLog("Checking Docker...")
-	if _, err := client.RunCommand("docker --version"); err != nil {
-		writeLog("Installing Docker...")
-		installDockerCmd := "curl -fsSL https://get.docker.com | sh"
-		if err := runCmd(installDockerCmd); err != nil {
-			logger.Log.Error("Failed to install Docker", zap.Error(err))
-			writeLog(fmt.Sprintf("Failed to install Docker: %v", err))
-			s.updateStatus(ctx, server.Id, entity.AgentStatusFailed)
-			return
-		}
+	// 2. Install WireGuard
+	writeLog("Installing WireGuard...")
+	if err := client.RunCommandStream("apt-get update && apt-get install -y wireguard", logWriter, logWriter); err != nil {
+		fail("Failed to install WireGuard", err)
+		return
 	}
 
 	// 2.5 Configure Docker Daemon (MTU)
 	writeLog("Configuring Docker Daemon...")
 	if err := s.configureDockerDaemon(client); err != nil {
-		logger.Log.Error("Failed to configure Docker Daemon", zap.Error(err))
-		writeLog(fmt.Sprintf("Failed to configure Docker Daemon: %v", err))
-		s.updateStatus(ctx, server.Id, entity.AgentStatusFailed)
+		fail("Failed to configure Docker Daemon", err)
 		return
 	}
 
 	// 3. Setup WireGuard
 	writeLog("Setting up WireGuard...")
-	if err := s.setupWireGuard(ctx, client, server); err != nil {
-		logger.Log.Error("Failed to setup WireGuard", zap.Error(err))
-		writeLog(fmt.Sprintf("Failed to setup WireGuard: %v", err))
-		s.updateStatus(ctx, server.Id, entity.AgentStatusFailed)
+	if err := s.setupWireGuard(ctx, client, server, logWriter, writeLog); err != nil {
+		fail("Failed to setup WireGuard", err)
 		return
 	}
 
 	// 4. Setup Swarm
 	writeLog("Setting up Swarm...")
 	if err := s.setupSwarm(ctx, client, server); err != nil {
-		logger.Log.Error("Failed to setup Swarm", zap.Error(err))
-		writeLog(fmt.Sprintf("Failed to setup Swarm: %v", err))
-		s.updateStatus(ctx, server.Id, entity.AgentStatusFailed)
+		fail("Failed to setup Swarm", err)
+		return
+	}
+
+	// 5. Wait for the agent to answer over mTLS
+	writeLog("Waiting for the agent...")
+	if err := s.waitForAgent(ctx, server); err != nil {
+		fail("Agent did not become healthy", err)
 		return
 	}
 
@@ -160,8 +297,92 @@ func (s *NodeService) runProvisioning(ctx context.Context, server *entity.Server
 	writeLog("Node provisioning completed successfully")
 	logger.Log.Info("Node provisioning completed successfully", zap.String("server_id", server.Id))
 
-	// 5. Sync Mesh (Update all nodes with new peer)
-	go s.SyncMesh(context.Background())
+	// 6. Sync Mesh (Update all nodes with new peer)
+	go func() {
+		if _, err := s.SyncMesh(context.Background()); err != nil {
+			logger.Log.Error("Failed to sync mesh", zap.Error(err))
+		}
+	}()
+}
+
+// agentInstallStatus maps the stages of the agent install workflow to the agent status reported meanwhile.
+var agentInstallStatus = map[string]int{
+	cpWorkflow.StageInstall:   entity.AgentStatusInstalling,
+	cpWorkflow.StageConfigure: entity.AgentStatusConfiguring,
+	cpWorkflow.StageFinalize:  entity.AgentStatusFinalizingSetup,
+}
+
+// agentInstallParams renders what the agent install workflow writes to the server: the certificates
+// issued for it and its configuration, including the backup storages attached to the server.
+func (s *NodeService) agentInstallParams(ctx context.Context, server *entity.Server) (cpWorkflow.AgentInstallParams, error) {
+	if server.Agent.Cert == "" || server.Agent.Key == "" {
+		return cpWorkflow.AgentInstallParams{}, fmt.Errorf("no agent certificate issued for server")
+	}
+
+	storages, err := s.backupRepo.GetByServerID(ctx, server.Id)
+	if err != nil {
+		return cpWorkflow.AgentInstallParams{}, fmt.Errorf("failed to get backup storages: %w", err)
+	}
+
+	cfg := config.DefaultAgentConfig()
+	if server.Agent.Port != 0 {
+		cfg.Server.Port = server.Agent.Port
+	}
+	for _, storage := range storages {
+		cfg.Storage = append(cfg.Storage, config.StorageConfig{
+			ID:        storage.Id,
+			Name:      storage.Name,
+			Endpoint:  storage.Endpoint,
+			Region:    storage.Region,
+			Bucket:    storage.Bucket,
+			AccessKey: storage.AccessKey,
+			SecretKey: storage.SecretKey,
+		})
+	}
+
+	content, err := yaml.Marshal(cfg)
+	if err != nil {
+		return cpWorkflow.AgentInstallParams{}, fmt.Errorf("failed to render agent config: %w", err)
+	}
+
+	return cpWorkflow.AgentInstallParams{
+		DownloadURL:   s.agentURL,
+		ServerCert:    server.Agent.Cert,
+		ServerKey:     server.Agent.Key,
+		CACert:        s.certs.CACertificate(),
+		ConfigContent: string(content),
+		AgentPort:     cfg.Server.Port,
+	}, nil
+}
+
+// waitForAgent polls the agent's health until it answers or agentStartTimeout passes.
+func (s *NodeService) waitForAgent(ctx context.Context, server *entity.Server) error {
+	// The agent restarted with new certificates, a connection made before is of no use
+	s.agents.Evict(server.Id)
+
+	agent, err := s.agents.Client(server)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, agentStartTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(agentPollInterval)
+	defer ticker.Stop()
+
+	for {
+		_, err := agent.Health(ctx, &pbCommon.Empty{})
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-ticker.C:
+		}
+	}
 }
 
 func (s *NodeService) setupSwarm(ctx context.Context, client *util.SSHClient, server *entity.Server) error {
@@ -229,37 +450,22 @@ func (s *NodeService) joinSwarm(ctx context.Context, client *util.SSHClient, ser
 	return nil
 }
 
-func (s *NodeService) setupWireGuard(ctx context.Context, client *util.SSHClient, server *entity.Server) error {
+func (s *NodeService) setupWireGuard(ctx context.Context, client *util.SSHClient, server *entity.Server, logWriter io.Writer, writeLog func(string)) error {
 	// 1. Generate Keys
-	privKey, err := client.RunCommand("wg genkey")
-	if err != nil {
-		return fmt.Errorf("failed to generate private key: %w", err)
+	engine := workflow.NewEngine(client, logWriter, writeLog)
+	engine.SetTarget(server.Id)
+	if err := engine.Run(ctx, cpWorkflow.NewWireGuardKeysWorkflow()); err != nil {
+		return fmt.Errorf("failed to generate keys: %w", err)
 	}
-	privKey = strings.TrimSpace(privKey)
+	privKey, _ := engine.Var(cpWorkflow.WireGuardPrivateKey)
+	pubKey, _ := engine.Var(cpWorkflow.WireGuardPublicKey)
 
-	pubKey, err := client.RunCommand(fmt.Sprintf("echo '%s' | wg pubkey", privKey))
-	if err != nil {
-		return fmt.Errorf("failed to generate public key: %w", err)
-	}
-	pubKey = strings.TrimSpace(pubKey)
-
-	// 2. Assign IP (Simple Strategy: 10.0.0.(count+2))
-	// TODO: Implement robust IPAM
-	servers, err := s.repo.GetAll(ctx)
+	// 2. Assign IP from the WireGuard pool
+	addr, err := s.ipam.Allocate(ctx, server)
 	if err != nil {
-		return fmt.Errorf("failed to get servers for IP allocation: %w", err)
-	}
-
-	// Check if IP is already assigned to this server
-	internalIP := server.InternalIP
-	if internalIP == "" {
-		// Very naive allocation, assumes no gaps and sequential IDs roughly map to count
-		// In production, use a bitmap or DB table for IP pool.
-		// We start from 10.0.0.2 (assuming .1 is gateway/controlplane if needed, or just start .1)
-		// Let's use 10.0.0.(len+1)
-		// We need to be careful about race conditions here.
-		internalIP = fmt.Sprintf("10.0.0.%d", len(servers)+1)
+		return fmt.Errorf("failed to allocate internal IP: %w", err)
 	}
+	internalIP := addr.String()
 
 	// 3. Save to DB
 	server.WireGuard.PrivateKey = privKey
@@ -271,15 +477,11 @@ func (s *NodeService) setupWireGuard(ctx context.Context, client *util.SSHClient
 		return fmt.Errorf("failed to save WG keys: %w", err)
 	}
 
-	// 4. Create Config
-	config := fmt.Sprintf(`[Interface]
-PrivateKey = %s
-Address = %s/24
-ListenPort = %d
-`, privKey, internalIP, server.WireGuard.ListenPort)
+	// 4. Create Config, peers are added by the mesh sync
+	config := s.wireGuardConfig(server, nil)
 
 	// 5. Push Config
-	if err := client.WriteFile("/etc/wireguard/wg0.conf", []byte(config), 0600); err != nil {
+	if err := client.WriteFile("/etc/wireguard/wg0.conf", []byte(config.String()), 0600); err != nil {
 		return fmt.Errorf("failed to write wg0.conf: %w", err)
 	}
 
@@ -318,12 +520,17 @@ func (s *NodeService) configureDockerDaemon(client *util.SSHClient) error {
 	return nil
 }
 
-func (s *NodeService) SyncMesh(ctx context.Context) {
+// SyncMesh brings the WireGuard configuration of every server with keys and an address in line with
+// the current set of servers. Servers are synced in parallel; peer changes are applied live and
+// wg-quick is only restarted when the interface itself changed or is down. The report is persisted.
+func (s *NodeService) SyncMesh(ctx context.Context) (*entity.MeshSyncReport, error) {
+	s.syncMu.Lock()
+	defer s.syncMu.Unlock()
+
 	// 1. Get all servers
 	servers, err := s.repo.GetAll(ctx)
 	if err != nil {
-		logger.Log.Error("Failed to get servers for mesh sync", zap.Error(err))
-		return
+		return nil, fmt.Errorf("failed to get servers: %w", err)
 	}
 
 	// 2. Filter valid peers (must have WG keys and IP)
@@ -334,61 +541,154 @@ func (s *NodeService) SyncMesh(ctx context.Context) {
 		}
 	}
 
-	// 3. Sync each node
-	// TODO: Use worker pool for parallelism
-	for _, target := range validPeers {
-		if err := s.syncNode(ctx, target, validPeers); err != nil {
-			logger.Log.Error("Failed to sync node", zap.String("server_id", target.Id), zap.Error(err))
-			// Continue to next node
-		}
+	// 3. Sync each node, at most meshSyncWorkers at a time
+	results := make([]*entity.MeshSyncResult, len(validPeers))
+	sem := make(chan struct{}, meshSyncWorkers)
+	var wg sync.WaitGroup
+	for i, target := range validPeers {
+		wg.Add(1)
+		go func(i int, target *entity.Server) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			results[i] = s.syncNode(ctx, target, validPeers)
+		}(i, target)
+	}
+	wg.Wait()
+
+	report := &entity.MeshSyncReport{
+		Status:     meshSyncStatus(results),
+		Results:    results,
+		FinishedAt: time.Now(),
+	}
+	if _, err := s.meshRepo.Create(ctx, report); err != nil {
+		return report, fmt.Errorf("failed to save mesh sync report: %w", err)
 	}
+
+	return report, nil
+}
+
+// LatestMeshSync returns the report of the last mesh sync.
+func (s *NodeService) LatestMeshSync(ctx context.Context) (*entity.MeshSyncReport, error) {
+	return s.meshRepo.GetLatest(ctx)
+}
+
+func (s *NodeService) syncNode(ctx context.Context, target *entity.Server, peers []*entity.Server) *entity.MeshSyncResult {
+	started := time.Now()
+	result := &entity.MeshSyncResult{
+		ServerID:   target.Id,
+		ServerName: target.Name,
+	}
+
+	if err := s.applyMesh(ctx, target, peers, result); err != nil {
+		logger.Log.Error("Failed to sync node", zap.String("server_id", target.Id), zap.Error(err))
+		result.Action = entity.MeshSyncActionFailed
+		result.Error = err.Error()
+	}
+
+	result.DurationMs = time.Since(started).Milliseconds()
+	return result
 }
 
-func (s *NodeService) syncNode(ctx context.Context, target *entity.Server, peers []*entity.Server) error {
-	client, err := util.NewSSHClient(target.IpAddress, target.Port, target.Credential.Username, target.Credential.Password, target.Credential.SSHKey)
+func (s *NodeService) applyMesh(ctx context.Context, target *entity.Server, peers []*entity.Server, result *entity.MeshSyncResult) error {
+	client, err := s.ssh.Connect(ctx, target)
 	if err != nil {
 		return err
 	}
 	defer client.Close()
 
-	// Build Config
-	var configBuilder strings.Builder
+	desired := s.wireGuardConfig(target, peers)
+	rendered := desired.String()
+
+	// A missing or unreadable config is treated as empty, which rewrites it and restarts the interface.
+	var current *wireguard.Config
+	existing, err := client.RunCommand("cat /etc/wireguard/wg0.conf")
+	if err == nil {
+		if current, err = wireguard.Parse(existing); err != nil {
+			logger.Log.Warn("Failed to parse wg0.conf, rewriting it", zap.String("server_id", target.Id), zap.Error(err))
+			current = nil
+		}
+	}
+
+	diff := wireguard.Compare(current, desired)
+	result.PeersAdded = len(diff.Added)
+	result.PeersRemoved = len(diff.Removed)
+	result.PeersUpdated = len(diff.Updated)
+
+	_, downErr := client.RunCommand("wg show wg0 > /dev/null")
+	up := downErr == nil
+
+	result.Action = entity.MeshSyncActionUnchanged
+	if current != nil && existing == rendered && up {
+		return nil
+	}
+
+	// Write Config
+	if err := client.WriteFile("/etc/wireguard/wg0.conf", []byte(rendered), 0600); err != nil {
+		return fmt.Errorf("failed to write wg0.conf: %w", err)
+	}
+
+	switch {
+	case diff.InterfaceChanged || !up:
+		if _, err := client.RunCommand("systemctl restart wg-quick@wg0"); err != nil {
+			return fmt.Errorf("failed to restart wireguard: %w", err)
+		}
+		result.Action = entity.MeshSyncActionRestarted
+	case !diff.Empty():
+		// syncconf only touches peers that changed, established sessions stay up
+		if _, err := client.RunCommand("bash -c 'wg syncconf wg0 <(wg-quick strip wg0)'"); err != nil {
+			return fmt.Errorf("failed to sync wireguard peers: %w", err)
+		}
+		result.Action = entity.MeshSyncActionSynced
+	}
+
+	return nil
+}
 
-	// Interface
-	configBuilder.WriteString(fmt.Sprintf(`[Interface]
-PrivateKey = %s
-Address = %s/24
-ListenPort = %d
-`, target.WireGuard.PrivateKey, target.InternalIP, target.WireGuard.ListenPort))
+// wireGuardConfig builds the wg0.conf of target, with every other server as a peer.
+func (s *NodeService) wireGuardConfig(target *entity.Server, peers []*entity.Server) *wireguard.Config {
+	config := &wireguard.Config{
+		Interface: wireguard.Interface{
+			PrivateKey: target.WireGuard.PrivateKey,
+			Address:    fmt.Sprintf("%s/%d", target.InternalIP, s.ipam.Prefix().Bits()),
+			ListenPort: target.WireGuard.ListenPort,
+		},
+	}
 
-	// Peers
 	for _, peer := range peers {
 		if peer.Id == target.Id {
 			continue // Skip self
 		}
 
-		// Peer Block
 		// Endpoint is the Public IP : ListenPort
-		// AllowedIPs is the Internal IP / 32 (Host route)
-		configBuilder.WriteString(fmt.Sprintf(`
-[Peer]
-# %s
-PublicKey = %s
-AllowedIPs = %s/32
-Endpoint = %s:%d
-PersistentKeepalive = 25
-`, peer.Name, peer.WireGuard.PublicKey, peer.InternalIP, peer.IpAddress, peer.WireGuard.ListenPort))
+		// AllowedIPs is the Internal IP / 32, or / 128 in an IPv6 pool (Host route)
+		config.Peers = append(config.Peers, wireguard.Peer{
+			Name:                peer.Name,
+			PublicKey:           peer.WireGuard.PublicKey,
+			AllowedIPs:          fmt.Sprintf("%s/%d", peer.InternalIP, s.ipam.Prefix().Addr().BitLen()),
+			Endpoint:            fmt.Sprintf("%s:%d", peer.IpAddress, peer.WireGuard.ListenPort),
+			PersistentKeepalive: 25,
+		})
 	}
 
-	// Write Config
-	if err := client.WriteFile("/etc/wireguard/wg0.conf", []byte(configBuilder.String()), 0600); err != nil {
-		return fmt.Errorf("failed to write wg0.conf: %w", err)
-	}
+	return config
+}
 
-	// Reload
-	if _, err := client.RunCommand("systemctl restart wg-quick@wg0"); err != nil {
-		return fmt.Errorf("failed to restart wireguard: %w", err)
+func meshSyncStatus(results []*entity.MeshSyncResult) int {
+	failed := 0
+	for _, result := range results {
+		if result.Action == entity.MeshSyncActionFailed {
+			failed++
+		}
 	}
 
-	return nil
+	switch {
+	case failed == 0:
+		return entity.MeshSyncStatusSucceeded
+	case failed == len(results):
+		return entity.MeshSyncStatusFailed
+	default:
+		return entity.MeshSyncStatusPartial
+	}
 }
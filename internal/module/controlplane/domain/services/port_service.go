@@ -0,0 +1,185 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/compose"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/repository"
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+	"gorm.io/gorm"
+)
+
+var ErrPortInUse = errors.New("port is already in use")
+
+const (
+	DefaultPortRangeStart = 20000
+	DefaultPortRangeEnd   = 29999
+)
+
+// ParsePortRange parses a range such as "20000-29999". An empty value yields the default range.
+func ParsePortRange(value string) (int, int, error) {
+	if value == "" {
+		return DefaultPortRangeStart, DefaultPortRangeEnd, nil
+	}
+
+	first, last, ok := strings.Cut(value, "-")
+	start, startErr := strconv.Atoi(strings.TrimSpace(first))
+	end, endErr := strconv.Atoi(strings.TrimSpace(last))
+	if !ok || startErr != nil || endErr != nil || start < 1 || end > 65535 || start > end {
+		return 0, 0, fmt.Errorf("invalid port range %q, expected <start>-<end>", value)
+	}
+	return start, end, nil
+}
+
+// PortService leases host ports on managed servers out of a range. Leases are persisted with a
+// unique index per server, port and protocol, so two services never get the same host port, even
+// across controlplane instances. Within a process allocations on a server are serialized.
+type PortService struct {
+	repo       repository.PortLeaseRepository
+	remote     *RemoteService
+	start, end int
+
+	mu    sync.Mutex
+	locks map[string]*sync.Mutex
+}
+
+func NewPortService(repo repository.PortLeaseRepository, remote *RemoteService, start, end int) *PortService {
+	return &PortService{
+		repo:   repo,
+		remote: remote,
+		start:  start,
+		end:    end,
+		locks:  make(map[string]*sync.Mutex),
+	}
+}
+
+// Allocate leases a host port for the request. A port the service already leases for the same
+// container port is returned as is; a new lease replaces the old one.
+func (s *PortService) Allocate(ctx context.Context, req compose.PortRequest) (int, error) {
+	lock := s.lock(req.ServerID)
+	lock.Lock()
+	defer lock.Unlock()
+
+	current, err := s.repo.GetByServicePort(ctx, req.ServiceID, req.ContainerPort, req.Protocol)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return 0, err
+	}
+	if current != nil && current.ServerID == req.ServerID && (req.Preferred == 0 || req.Preferred == current.Port) {
+		return current.Port, nil
+	}
+
+	leases, err := s.repo.GetByServerID(ctx, req.ServerID)
+	if err != nil {
+		return 0, err
+	}
+	leased := make(map[int]bool, len(leases))
+	for _, lease := range leases {
+		if lease.Protocol == req.Protocol {
+			leased[lease.Port] = true
+		}
+	}
+
+	// A port published before leases existed is kept without asking the server, the service's own
+	// container is most likely the one listening on it.
+	if req.Preferred == 0 && req.Current > 0 && !leased[req.Current] {
+		if err := s.lease(ctx, req, req.Current); err == nil {
+			return req.Current, nil
+		} else if !errors.Is(err, ErrPortInUse) {
+			return 0, err
+		}
+	}
+
+	listening, err := s.listening(ctx, req.ServerID, req.Protocol)
+	if err != nil {
+		return 0, err
+	}
+
+	if req.Preferred > 0 {
+		if leased[req.Preferred] || listening[req.Preferred] {
+			return 0, fmt.Errorf("%w: %d/%s on server %s", ErrPortInUse, req.Preferred, req.Protocol, req.ServerID)
+		}
+		if err := s.lease(ctx, req, req.Preferred); err != nil {
+			return 0, err
+		}
+		return req.Preferred, nil
+	}
+
+	size := s.end - s.start + 1
+	offset := rand.Intn(size)
+	for i := 0; i < size; i++ {
+		port := s.start + (offset+i)%size
+		if leased[port] || listening[port] {
+			continue
+		}
+
+		err := s.lease(ctx, req, port)
+		if errors.Is(err, ErrPortInUse) {
+			// taken by another controlplane in the meantime
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		return port, nil
+	}
+
+	return 0, fmt.Errorf("no free port left in %d-%d on server %s", s.start, s.end, req.ServerID)
+}
+
+func (s *PortService) lease(ctx context.Context, req compose.PortRequest, port int) error {
+	err := s.repo.Replace(ctx, &entity.PortLease{
+		ServerID:      req.ServerID,
+		Port:          port,
+		Protocol:      req.Protocol,
+		ServiceNodeID: req.ServiceID,
+		ContainerPort: req.ContainerPort,
+	})
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return fmt.Errorf("%w: %d/%s on server %s", ErrPortInUse, port, req.Protocol, req.ServerID)
+	}
+	return err
+}
+
+// listening returns the ports something listens on on the server for the protocol.
+func (s *PortService) listening(ctx context.Context, serverID, protocol string) (map[int]bool, error) {
+	flag := "-t"
+	if protocol == "udp" {
+		flag = "-u"
+	}
+	output, err := s.remote.RunByID(ctx, serverID, "ss -Hln "+flag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list listening ports: %w", err)
+	}
+
+	ports := make(map[int]bool)
+	for _, line := range strings.Split(output, "\n") {
+		// State Recv-Q Send-Q Local-Address:Port Peer-Address:Port
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+		local := fields[3]
+		if port, err := strconv.Atoi(local[strings.LastIndex(local, ":")+1:]); err == nil {
+			ports[port] = true
+		}
+	}
+	return ports, nil
+}
+
+func (s *PortService) lock(serverID string) *sync.Mutex {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lock, ok := s.locks[serverID]
+	if !ok {
+		lock = &sync.Mutex{}
+		s.locks[serverID] = lock
+	}
+	return lock
+}
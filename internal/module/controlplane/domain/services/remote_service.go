@@ -0,0 +1,328 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zhinea/sylix/internal/common/logger"
+	"github.com/zhinea/sylix/internal/common/util"
+	pbAgent "github.com/zhinea/sylix/internal/infra/proto/agent"
+	pbCommon "github.com/zhinea/sylix/internal/infra/proto/common"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/repository"
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+	"go.uber.org/zap"
+)
+
+// RemoteService runs commands on managed servers. Servers whose agent is installed are reached
+// through the agent, the others over SSH. SSH is also used while an agent cannot be reached.
+type RemoteService struct {
+	repo   repository.ServerRepository
+	ssh    *SSHService
+	agents *AgentClientService
+}
+
+func NewRemoteService(repo repository.ServerRepository, ssh *SSHService, agents *AgentClientService) *RemoteService {
+	return &RemoteService{
+		repo:   repo,
+		ssh:    ssh,
+		agents: agents,
+	}
+}
+
+// Server loads a server to run commands on.
+func (s *RemoteService) Server(ctx context.Context, serverID string) (*entity.Server, error) {
+	server, err := s.repo.GetByID(ctx, serverID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get server: %w", err)
+	}
+	return server, nil
+}
+
+// Run runs a shell command on the server and returns its stdout. A non-zero exit status is an error.
+func (s *RemoteService) Run(ctx context.Context, server *entity.Server, cmd string) (string, error) {
+	if s.agents.Available(server) {
+		out, err := s.runAgent(ctx, server, cmd)
+		if !s.fallback(server, err) {
+			return out, err
+		}
+	}
+
+	client, err := s.ssh.Connect(ctx, server)
+	if err != nil {
+		return "", err
+	}
+	defer client.Close()
+
+	return client.RunCommand(cmd)
+}
+
+// RunByID loads the server and runs the shell command on it.
+func (s *RemoteService) RunByID(ctx context.Context, serverID, cmd string) (string, error) {
+	server, err := s.Server(ctx, serverID)
+	if err != nil {
+		return "", err
+	}
+	return s.Run(ctx, server, cmd)
+}
+
+// Ping measures how long the server takes to answer a trivial request.
+func (s *RemoteService) Ping(ctx context.Context, server *entity.Server) (time.Duration, error) {
+	if s.agents.Available(server) {
+		client, err := s.agents.Client(server)
+		if err != nil {
+			return 0, err
+		}
+
+		start := time.Now()
+		_, err = client.Health(ctx, &pbCommon.Empty{})
+		if !s.fallback(server, err) {
+			return time.Since(start), err
+		}
+	}
+
+	client, err := s.ssh.Connect(ctx, server)
+	if err != nil {
+		return 0, err
+	}
+	defer client.Close()
+
+	start := time.Now()
+	if _, err := client.RunCommand("echo 'ping'"); err != nil {
+		return 0, err
+	}
+	return time.Since(start), nil
+}
+
+// ComposeUp writes the compose file of the project to the server and starts it, streaming the output to out.
+func (s *RemoteService) ComposeUp(ctx context.Context, serverID, project string, compose []byte, out io.Writer) error {
+	server, err := s.Server(ctx, serverID)
+	if err != nil {
+		return err
+	}
+
+	if s.agents.Available(server) {
+		started, err := s.composeUpAgent(ctx, server, project, compose, out)
+		if started || !s.fallback(server, err) {
+			return err
+		}
+	}
+
+	client, err := s.ssh.Connect(ctx, server)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	dir := path.Join(ProjectsDir, project)
+	file := path.Join(dir, "docker-compose.yml")
+
+	if _, err := client.RunCommand("mkdir -p " + util.ShellQuote(dir)); err != nil {
+		return err
+	}
+	if err := client.WriteFile(util.ShellQuote(file), compose, 0600); err != nil {
+		return err
+	}
+
+	cmd := fmt.Sprintf("docker compose -p %s -f %s up -d --remove-orphans", util.ShellQuote(project), util.ShellQuote(file))
+	return client.RunCommandStream(cmd, out, out)
+}
+
+// FollowContainerLogs streams the logs of the container to fn line by line, with the stream,
+// stdout or stderr, each came from. It returns once ctx is done or the container stops.
+func (s *RemoteService) FollowContainerLogs(ctx context.Context, serverID, container string, tail int, fn func(stream, line string) error) error {
+	server, err := s.Server(ctx, serverID)
+	if err != nil {
+		return err
+	}
+
+	if s.agents.Available(server) {
+		started, err := s.followLogsAgent(ctx, server, container, tail, fn)
+		if started || !s.fallback(server, err) {
+			return err
+		}
+	}
+
+	// A follow may run for hours, so it gets a connection of its own
+	client, err := s.ssh.ConnectDedicated(ctx, server)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	stdout, stderr := newLineWriters(fn)
+	cmd := fmt.Sprintf("docker logs --follow --tail %d %s", tail, util.ShellQuote(container))
+	err = client.RunCommandStreamContext(ctx, cmd, stdout, stderr)
+	if err == nil {
+		err = errors.Join(stdout.Flush(), stderr.Flush())
+	}
+	return err
+}
+
+// Stream runs a shell command on the server, copying its stdout to out as it is written. The
+// output may be binary, which the agent's Exec cannot carry, so it always runs over SSH. The
+// command is stopped once ctx is done.
+func (s *RemoteService) Stream(ctx context.Context, serverID, cmd string, out io.Writer) error {
+	server, err := s.Server(ctx, serverID)
+	if err != nil {
+		return err
+	}
+
+	client, err := s.ssh.Connect(ctx, server)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	var stderr bytes.Buffer
+	if err := client.RunCommandStreamContext(ctx, cmd, out, &stderr); err != nil {
+		if stderr.Len() > 0 {
+			return fmt.Errorf("%w, stderr: %s", err, strings.TrimSpace(stderr.String()))
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *RemoteService) runAgent(ctx context.Context, server *entity.Server, cmd string) (string, error) {
+	client, err := s.agents.Client(server)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := client.Exec(ctx, &pbAgent.ExecRequest{Command: []string{"sh", "-c", cmd}})
+	if err != nil {
+		return "", err
+	}
+	if resp.ExitCode != 0 {
+		return "", fmt.Errorf("failed to run command: %s, stderr: %s, exit code: %d", cmd, resp.Stderr, resp.ExitCode)
+	}
+	return resp.Stdout, nil
+}
+
+// composeUpAgent deploys the project through the agent. started reports whether the agent produced
+// any output, after which falling back to SSH would deploy twice.
+func (s *RemoteService) composeUpAgent(ctx context.Context, server *entity.Server, project string, compose []byte, out io.Writer) (bool, error) {
+	client, err := s.agents.Client(server)
+	if err != nil {
+		return false, err
+	}
+
+	stream, err := client.DeployCompose(ctx, &pbAgent.DeployComposeRequest{
+		Project: project,
+		Compose: compose,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	started := false
+	for {
+		line, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return true, nil
+		}
+		if err != nil {
+			return started, err
+		}
+		started = true
+		fmt.Fprintln(out, line.Line)
+	}
+}
+
+// followLogsAgent follows the logs through the agent. started reports whether the agent sent any
+// line, after which falling back to SSH would send them twice.
+func (s *RemoteService) followLogsAgent(ctx context.Context, server *entity.Server, container string, tail int, fn func(stream, line string) error) (bool, error) {
+	client, err := s.agents.Client(server)
+	if err != nil {
+		return false, err
+	}
+
+	stream, err := client.ContainerLogs(ctx, &pbAgent.ContainerLogsRequest{
+		Container: container,
+		Tail:      int32(tail),
+		Follow:    true,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	started := false
+	for {
+		line, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return true, nil
+		}
+		if err != nil {
+			return started, err
+		}
+		started = true
+		if err := fn(line.Stream, line.Line); err != nil {
+			return true, err
+		}
+	}
+}
+
+// lineWriter splits what is written to it into lines for fn. Writes fail once fn does. The stdout
+// and stderr writers of a command share a lock, as SSH copies each stream in its own goroutine and
+// fn, such as a gRPC stream's Send, must not run concurrently.
+type lineWriter struct {
+	mu      *sync.Mutex
+	stream  string
+	fn      func(stream, line string) error
+	partial []byte
+}
+
+func newLineWriters(fn func(stream, line string) error) (stdout, stderr *lineWriter) {
+	mu := &sync.Mutex{}
+	return &lineWriter{mu: mu, stream: "stdout", fn: fn},
+		&lineWriter{mu: mu, stream: "stderr", fn: fn}
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.partial = append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i < 0 {
+			return len(p), nil
+		}
+		line := strings.TrimSuffix(string(w.partial[:i]), "\r")
+		w.partial = w.partial[i+1:]
+		if err := w.fn(w.stream, line); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// Flush passes on the last line when it was not terminated.
+func (w *lineWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.partial) == 0 {
+		return nil
+	}
+	line := string(w.partial)
+	w.partial = nil
+	return w.fn(w.stream, line)
+}
+
+// fallback records the outcome of an agent call and reports whether it should be retried over SSH.
+func (s *RemoteService) fallback(server *entity.Server, err error) bool {
+	s.agents.Observe(server.Id, err)
+	if !agentUnreachable(err) {
+		return false
+	}
+
+	logger.Log.Warn("Agent unreachable, falling back to SSH", zap.String("server_id", server.Id), zap.Error(err))
+	return true
+}
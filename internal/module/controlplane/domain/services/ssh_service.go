@@ -0,0 +1,135 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/zhinea/sylix/internal/common/logger"
+	"github.com/zhinea/sylix/internal/common/util"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/repository"
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/ssh"
+)
+
+// SSHService opens SSH connections to managed servers. Connections to existing servers are pooled
+// per server. The host key a server presents on its first connection is pinned, every later
+// connection must present the same key.
+type SSHService struct {
+	repo repository.ServerRepository
+	pool *util.SSHPool
+}
+
+func NewSSHService(repo repository.ServerRepository, pool *util.SSHPool) *SSHService {
+	return &SSHService{
+		repo: repo,
+		pool: pool,
+	}
+}
+
+// Connect leases a connection to the server, dialing it when none is pooled. A host key pinned on
+// first use is stored on the server, and persisted when the server already exists.
+func (s *SSHService) Connect(ctx context.Context, server *entity.Server) (*util.SSHClient, error) {
+	if server.Id == "" {
+		return s.dial(ctx, server, server.HostKey)
+	}
+	return s.pool.Get(server.Id, credentialFingerprint(server), func() (*util.SSHClient, error) {
+		return s.dial(ctx, server, server.HostKey)
+	})
+}
+
+// ConnectDedicated dials a connection to the server of its own, for commands that run until ctx is
+// done and must not hold the sessions of the pooled connection. Closing it closes the connection.
+func (s *SSHService) ConnectDedicated(ctx context.Context, server *entity.Server) (*util.SSHClient, error) {
+	if server.Id == "" {
+		return s.dial(ctx, server, server.HostKey)
+	}
+	return s.pool.Dedicated(server.Id, func() (*util.SSHClient, error) {
+		return s.dial(ctx, server, server.HostKey)
+	})
+}
+
+// ConnectByID loads the server and dials it.
+func (s *SSHService) ConnectByID(ctx context.Context, serverID string) (*util.SSHClient, error) {
+	server, err := s.repo.GetByID(ctx, serverID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get server: %w", err)
+	}
+	return s.Connect(ctx, server)
+}
+
+// TrustHostKey replaces the pinned host key of the server with the key it presents now, provided it
+// has the expected SHA256 fingerprint. The old key stays pinned when the server cannot be reached
+// or presents another key.
+func (s *SSHService) TrustHostKey(ctx context.Context, server *entity.Server, fingerprint string) error {
+	var presented ssh.PublicKey
+	client, err := s.connect(ctx, server, util.TrustFingerprint(fingerprint, func(key ssh.PublicKey) {
+		presented = key
+	}), &presented)
+	if err != nil {
+		return err
+	}
+	s.pool.Evict(server.Id)
+	return client.Close()
+}
+
+// Evict drops the pooled connection to the server, for when its address or credentials changed.
+func (s *SSHService) Evict(serverID string) {
+	s.pool.Evict(serverID)
+}
+
+func (s *SSHService) PoolStats() util.SSHPoolStats {
+	return s.pool.Stats()
+}
+
+// dial connects to the server verifying its key against known, pinning the presented key when known is empty.
+func (s *SSHService) dial(ctx context.Context, server *entity.Server, known string) (*util.SSHClient, error) {
+	var presented ssh.PublicKey
+	hostKey, err := util.TrustOnFirstUse(known, func(key ssh.PublicKey) {
+		presented = key
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.connect(ctx, server, hostKey, &presented)
+}
+
+// connect dials the server verifying its key with hostKey, and pins the key hostKey stored in
+// presented, if any.
+func (s *SSHService) connect(ctx context.Context, server *entity.Server, hostKey ssh.HostKeyCallback, presented *ssh.PublicKey) (*util.SSHClient, error) {
+	client, err := util.NewSSHClient(server.IpAddress, server.Port, server.Credential.Username, server.Credential.Password, server.Credential.SSHKey, hostKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only pin once authentication succeeded, so a wrong address with bad credentials pins nothing.
+	if presented := *presented; presented != nil {
+		server.HostKey = util.MarshalHostKey(presented)
+		if server.Id != "" {
+			if err := s.repo.UpdateHostKey(ctx, server.Id, server.HostKey); err != nil {
+				client.Close()
+				return nil, fmt.Errorf("failed to pin host key: %w", err)
+			}
+			logger.Log.Info("Pinned SSH host key", zap.String("server_id", server.Id), zap.String("fingerprint", ssh.FingerprintSHA256(presented)))
+		}
+	}
+
+	return client, nil
+}
+
+// credentialFingerprint identifies what a pooled connection was dialed with, so a connection made
+// with outdated credentials is never reused.
+func credentialFingerprint(server *entity.Server) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%d\x00%s\x00", server.IpAddress, server.Port, server.Credential.Username)
+	if server.Credential.Password != nil {
+		h.Write([]byte(*server.Credential.Password))
+	}
+	h.Write([]byte{0})
+	if server.Credential.SSHKey != nil {
+		h.Write([]byte(*server.Credential.SSHKey))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
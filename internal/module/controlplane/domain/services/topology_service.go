@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/nodetype"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/repository"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/topology"
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrSelfEdge          = errors.New("a node cannot be connected to itself")
+	ErrEdgeExists        = errors.New("nodes are already connected")
+	ErrNotSameCluster    = errors.New("nodes must belong to the same cluster")
+	ErrNodesUnrelated    = errors.New("node types do not exchange any ports")
+	ErrEdgeNotNodeToNode = errors.New("only nodes can be connected")
+)
+
+// TopologyService manages the edges between the nodes of a cluster and resolves their ports.
+type TopologyService struct {
+	nodeRepo   repository.ServiceNodeRepository
+	edgeRepo   repository.ServiceEdgeRepository
+	serverRepo repository.ServerRepository
+	registry   *nodetype.Registry
+}
+
+func NewTopologyService(
+	nodeRepo repository.ServiceNodeRepository,
+	edgeRepo repository.ServiceEdgeRepository,
+	serverRepo repository.ServerRepository,
+	registry *nodetype.Registry,
+) *TopologyService {
+	return &TopologyService{
+		nodeRepo:   nodeRepo,
+		edgeRepo:   edgeRepo,
+		serverRepo: serverRepo,
+		registry:   registry,
+	}
+}
+
+func (s *TopologyService) Connect(ctx context.Context, sourceID, targetID string) (*entity.ServiceEdge, error) {
+	if sourceID == targetID {
+		return nil, ErrSelfEdge
+	}
+
+	source, err := s.nodeRepo.GetByID(ctx, sourceID)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.nodeRepo.GetByID(ctx, targetID)
+	if err != nil {
+		return nil, err
+	}
+
+	if source.Type != entity.ServiceTypeNode || target.Type != entity.ServiceTypeNode {
+		return nil, ErrEdgeNotNodeToNode
+	}
+	if source.ParentID == nil || target.ParentID == nil || *source.ParentID != *target.ParentID {
+		return nil, ErrNotSameCluster
+	}
+	if !s.registry.Connectable(source.App.Service, target.App.Service) {
+		return nil, fmt.Errorf("%w: %s and %s", ErrNodesUnrelated, source.App.Service, target.App.Service)
+	}
+
+	if _, err := s.edgeRepo.Get(ctx, sourceID, targetID); err == nil {
+		return nil, ErrEdgeExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	return s.edgeRepo.Create(ctx, entity.NewServiceEdge(*source.ParentID, sourceID, targetID))
+}
+
+func (s *TopologyService) Disconnect(ctx context.Context, sourceID, targetID string) error {
+	return s.edgeRepo.Delete(ctx, sourceID, targetID)
+}
+
+// Graph loads the nodes and edges of a cluster.
+func (s *TopologyService) Graph(ctx context.Context, clusterID string) (*topology.Graph, []*entity.ServiceEdge, error) {
+	if _, err := s.nodeRepo.GetByID(ctx, clusterID); err != nil {
+		return nil, nil, err
+	}
+
+	nodes, err := s.nodeRepo.GetByParentID(ctx, clusterID)
+	if err != nil {
+		return nil, nil, err
+	}
+	edges, err := s.edgeRepo.GetByClusterID(ctx, clusterID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return topology.NewGraph(nodes, edges), edges, nil
+}
+
+// Resolve computes the upstream endpoints of every node's imports in the graph.
+func (s *TopologyService) Resolve(ctx context.Context, graph *topology.Graph) (*topology.Resolution, error) {
+	servers, err := s.serverRepo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return topology.NewResolver(s.registry, servers).Resolve(graph), nil
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/zhinea/sylix/internal/common/logger"
+	"github.com/zhinea/sylix/internal/common/model"
+	"github.com/zhinea/sylix/internal/common/workflow"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/repository"
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+	"go.uber.org/zap"
+)
+
+// WorkflowResumer rebuilds the workflow of an interrupted run and resumes it.
+type WorkflowResumer func(ctx context.Context, run *workflow.Run) error
+
+// WorkflowRunService records workflow runs in the database and, at startup, resumes the runs a
+// restart interrupted or fails them when their kind cannot be resumed.
+type WorkflowRunService struct {
+	repo repository.WorkflowRunRepository
+
+	mu       sync.Mutex
+	resumers map[string]WorkflowResumer
+}
+
+func NewWorkflowRunService(repo repository.WorkflowRunRepository) *WorkflowRunService {
+	return &WorkflowRunService{
+		repo:     repo,
+		resumers: make(map[string]WorkflowResumer),
+	}
+}
+
+// RegisterResumer makes Recover resume interrupted runs of workflows of kind with resumer.
+func (s *WorkflowRunService) RegisterResumer(kind string, resumer WorkflowResumer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.resumers[kind] = resumer
+}
+
+func (s *WorkflowRunService) SaveRun(ctx context.Context, run *workflow.Run) error {
+	if run.ID != "" {
+		return s.repo.Update(ctx, &entity.WorkflowRun{
+			Model:      model.Model{Id: run.ID},
+			Kind:       run.Kind,
+			Name:       run.Name,
+			Target:     run.Target,
+			Status:     string(run.Status),
+			Error:      run.Error,
+			StartedAt:  run.StartedAt,
+			FinishedAt: run.FinishedAt,
+		})
+	}
+
+	record := &entity.WorkflowRun{
+		Kind:       run.Kind,
+		Name:       run.Name,
+		Target:     run.Target,
+		Status:     string(run.Status),
+		Error:      run.Error,
+		StartedAt:  run.StartedAt,
+		FinishedAt: run.FinishedAt,
+	}
+	for _, step := range run.Steps {
+		record.Steps = append(record.Steps, stepRunToEntity(run, step))
+	}
+
+	created, err := s.repo.Create(ctx, record)
+	if err != nil {
+		return err
+	}
+	run.ID = created.Id
+	for i, step := range created.Steps {
+		run.Steps[i].ID = step.Id
+	}
+	return nil
+}
+
+func (s *WorkflowRunService) SaveStep(ctx context.Context, run *workflow.Run, step *workflow.StepRun) error {
+	return s.repo.UpdateStep(ctx, stepRunToEntity(run, step))
+}
+
+// Running reports whether a run of kind on target is in progress.
+func (s *WorkflowRunService) Running(ctx context.Context, kind, target string) (bool, error) {
+	runs, err := s.repo.GetByStatus(ctx, string(workflow.RunRunning))
+	if err != nil {
+		return false, err
+	}
+	for _, run := range runs {
+		if run.Kind == kind && run.Target == target {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Recover handles the runs left running by the previous process. Runs with a registered resumer are
+// resumed in the background, the others are marked failed.
+func (s *WorkflowRunService) Recover(ctx context.Context) error {
+	runs, err := s.repo.GetByStatus(ctx, string(workflow.RunRunning))
+	if err != nil {
+		return fmt.Errorf("failed to get interrupted workflow runs: %w", err)
+	}
+
+	for _, record := range runs {
+		run := workflowRunFromEntity(record)
+
+		s.mu.Lock()
+		resumer, ok := s.resumers[run.Kind]
+		s.mu.Unlock()
+
+		if !ok {
+			logger.Log.Warn("Failing interrupted workflow run", zap.String("run_id", run.ID), zap.String("workflow", run.Name))
+			if err := s.Fail(ctx, run, fmt.Errorf("interrupted by a controlplane restart")); err != nil {
+				logger.Log.Error("Failed to mark workflow run failed", zap.String("run_id", run.ID), zap.Error(err))
+			}
+			continue
+		}
+
+		logger.Log.Info("Resuming interrupted workflow run", zap.String("run_id", run.ID), zap.String("workflow", run.Name), zap.String("target", run.Target))
+		go func() {
+			if err := resumer(context.Background(), run); err != nil {
+				logger.Log.Error("Failed to resume workflow run", zap.String("run_id", run.ID), zap.Error(err))
+			}
+		}()
+	}
+	return nil
+}
+
+// Fail marks the run failed with err, for runs that cannot go on. A step caught running fails as well.
+func (s *WorkflowRunService) Fail(ctx context.Context, run *workflow.Run, err error) error {
+	now := time.Now()
+	for _, step := range run.Steps {
+		if step.Status != workflow.StepRunning {
+			continue
+		}
+		step.Status = workflow.StepFailed
+		step.Error = err.Error()
+		step.FinishedAt = now
+		if err := s.SaveStep(ctx, run, step); err != nil {
+			return err
+		}
+	}
+
+	run.Status = workflow.RunFailed
+	run.Error = err.Error()
+	run.FinishedAt = now
+	return s.SaveRun(ctx, run)
+}
+
+func stepRunToEntity(run *workflow.Run, step *workflow.StepRun) *entity.WorkflowStepRun {
+	return &entity.WorkflowStepRun{
+		Model:      model.Model{Id: step.ID},
+		RunID:      run.ID,
+		Position:   step.Index,
+		Name:       step.Name,
+		Host:       step.Host,
+		Status:     string(step.Status),
+		Attempts:   step.Attempts,
+		Error:      step.Error,
+		Output:     step.Output,
+		StartedAt:  step.StartedAt,
+		FinishedAt: step.FinishedAt,
+	}
+}
+
+func workflowRunFromEntity(record *entity.WorkflowRun) *workflow.Run {
+	run := &workflow.Run{
+		ID:         record.Id,
+		Kind:       record.Kind,
+		Name:       record.Name,
+		Target:     record.Target,
+		Status:     workflow.RunStatus(record.Status),
+		Error:      record.Error,
+		StartedAt:  record.StartedAt,
+		FinishedAt: record.FinishedAt,
+	}
+	for _, step := range record.Steps {
+		run.Steps = append(run.Steps, &workflow.StepRun{
+			ID:         step.Id,
+			Index:      step.Position,
+			Name:       step.Name,
+			Host:       step.Host,
+			Status:     workflow.StepStatus(step.Status),
+			Attempts:   step.Attempts,
+			Error:      step.Error,
+			Output:     step.Output,
+			StartedAt:  step.StartedAt,
+			FinishedAt: step.FinishedAt,
+		})
+	}
+	return run
+}
@@ -0,0 +1,227 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/zhinea/sylix/internal/common/logger"
+	"github.com/zhinea/sylix/internal/common/util"
+	"github.com/zhinea/sylix/internal/common/workflow"
+	"go.uber.org/zap"
+)
+
+// DefaultWorkflowsDir is where workflow definitions are loaded from unless configured otherwise.
+const DefaultWorkflowsDir = "workflows"
+
+// kindDefinition prefixes the kind of runs of workflow definitions. Their params are not recorded,
+// so an interrupted run is failed instead of resumed.
+const kindDefinition = "definition:"
+
+var (
+	ErrWorkflowNotFound = errors.New("workflow not found")
+	ErrWorkflowRunning  = errors.New("workflow is already running on the server")
+	// ErrInvalidWorkflowRun wraps what is wrong with the params or hosts a run was given
+	ErrInvalidWorkflowRun = errors.New("invalid workflow run")
+)
+
+// WorkflowService holds the workflows operators defined in YAML and runs them against servers.
+type WorkflowService struct {
+	ssh  *SSHService
+	runs *WorkflowRunService
+	logs *LogHub
+
+	mu          sync.RWMutex
+	definitions map[string]*workflow.Definition
+}
+
+func NewWorkflowService(ssh *SSHService, runs *WorkflowRunService, logs *LogHub) *WorkflowService {
+	return &WorkflowService{
+		ssh:         ssh,
+		runs:        runs,
+		logs:        logs,
+		definitions: make(map[string]*workflow.Definition),
+	}
+}
+
+// Load replaces the definitions with those in dir. Actions must be registered before, steps using
+// an unknown action fail validation.
+func (s *WorkflowService) Load(dir string) error {
+	defs, err := workflow.LoadDefinitions(dir, workflow.DefaultRegistry)
+	if err != nil {
+		return err
+	}
+
+	definitions := make(map[string]*workflow.Definition, len(defs))
+	for _, def := range defs {
+		definitions[def.Name] = def
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.definitions = definitions
+	return nil
+}
+
+// All returns the definitions ordered by name.
+func (s *WorkflowService) All() []*workflow.Definition {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	defs := make([]*workflow.Definition, 0, len(s.definitions))
+	for _, def := range s.definitions {
+		defs = append(defs, def)
+	}
+	sort.Slice(defs, func(i, j int) bool {
+		return defs[i].Name < defs[j].Name
+	})
+	return defs
+}
+
+func (s *WorkflowService) Get(name string) (*workflow.Definition, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	def, ok := s.definitions[name]
+	return def, ok
+}
+
+// Run starts the workflow against the server in the background and returns its run together with
+// the file of the server's logs its output goes to. hosts gives the id of the server each host of
+// the workflow runs on.
+func (s *WorkflowService) Run(ctx context.Context, name, serverID string, params, hosts map[string]string) (*workflow.Run, string, error) {
+	wf, clients, err := s.prepare(ctx, name, serverID, params, hosts)
+	if err != nil {
+		return nil, "", err
+	}
+
+	running, err := s.runs.Running(ctx, wf.Kind, serverID)
+	if err == nil && running {
+		err = ErrWorkflowRunning
+	}
+	if err != nil {
+		closeClients(clients)
+		return nil, "", err
+	}
+
+	run := workflow.NewRun(wf, serverID)
+	if err := s.runs.SaveRun(ctx, run); err != nil {
+		closeClients(clients)
+		return nil, "", fmt.Errorf("failed to save workflow run: %w", err)
+	}
+
+	logName := fmt.Sprintf("workflow-%s.log", wf.Name)
+	go func() {
+		defer closeClients(clients)
+		s.execute(context.Background(), wf, run, clients, hosts, filepath.Join(fmt.Sprintf("logs/servers/%s", serverID), logName))
+	}()
+	return run, logName, nil
+}
+
+// Plan returns what Run would do with the same arguments. Only the conditions of the steps run.
+func (s *WorkflowService) Plan(ctx context.Context, name, serverID string, params, hosts map[string]string) (*workflow.Plan, error) {
+	wf, clients, err := s.prepare(ctx, name, serverID, params, hosts)
+	if err != nil {
+		return nil, err
+	}
+	defer closeClients(clients)
+
+	engine := workflow.NewEngine(clients[""], io.Discard, func(string) {})
+	engine.SetTarget(serverID)
+	for host, id := range hosts {
+		engine.AddHost(host, clients[host], id)
+	}
+	return engine.Plan(ctx, wf)
+}
+
+// prepare checks the params and hosts given for a run of the workflow, builds the workflow from its
+// definition and connects to the servers, keyed by host with "" for the server itself.
+func (s *WorkflowService) prepare(ctx context.Context, name, serverID string, params, hosts map[string]string) (workflow.Workflow, map[string]*util.SSHClient, error) {
+	def, ok := s.Get(name)
+	if !ok {
+		return workflow.Workflow{}, nil, ErrWorkflowNotFound
+	}
+
+	vars, secrets, err := def.Vars(params)
+	if err != nil {
+		return workflow.Workflow{}, nil, fmt.Errorf("%w: %v", ErrInvalidWorkflowRun, err)
+	}
+	for _, host := range def.Hosts {
+		if hosts[host] == "" {
+			return workflow.Workflow{}, nil, fmt.Errorf("%w: no server given for host %s", ErrInvalidWorkflowRun, host)
+		}
+	}
+	for host := range hosts {
+		if !slices.Contains(def.Hosts, host) {
+			return workflow.Workflow{}, nil, fmt.Errorf("%w: unknown host: %s", ErrInvalidWorkflowRun, host)
+		}
+	}
+
+	// Connect up front, so unreachable servers are reported to the caller
+	client, err := s.ssh.ConnectByID(ctx, serverID)
+	if err != nil {
+		return workflow.Workflow{}, nil, err
+	}
+	clients := map[string]*util.SSHClient{"": client}
+	for host, id := range hosts {
+		c, err := s.ssh.ConnectByID(ctx, id)
+		if err != nil {
+			closeClients(clients)
+			return workflow.Workflow{}, nil, fmt.Errorf("host %s: %w", host, err)
+		}
+		clients[host] = c
+	}
+
+	wf := def.Workflow
+	wf.Kind = kindDefinition + def.Name
+	wf.Vars, wf.Secrets = vars, secrets
+	return wf, clients, nil
+}
+
+func (s *WorkflowService) execute(ctx context.Context, wf workflow.Workflow, run *workflow.Run, clients map[string]*util.SSHClient, hosts map[string]string, logPath string) {
+	if err := os.MkdirAll(filepath.Dir(logPath), 0755); err != nil {
+		logger.Log.Error("Failed to create log directory", zap.Error(err))
+	}
+
+	var logWriter io.Writer = io.Discard
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		logger.Log.Error("Failed to create log file", zap.Error(err))
+	} else {
+		defer logFile.Close()
+		logWriter = logFile
+	}
+
+	writeLog := func(msg string) {
+		timestamp := time.Now().Format(time.RFC3339)
+		fmt.Fprintf(logWriter, "[%s] %s\n", timestamp, msg)
+	}
+
+	engine := workflow.NewEngine(clients[""], logWriter, writeLog)
+	engine.SetTarget(run.Target)
+	engine.SetStore(s.runs)
+	engine.OnStep(func(event workflow.StepEvent) {
+		s.logs.PublishStep(logPath, workflowStepEvent(event))
+	})
+	for host, id := range hosts {
+		engine.AddHost(host, clients[host], id)
+	}
+
+	// The run is new, resuming it runs every step
+	if err := engine.Resume(ctx, wf, run); err != nil {
+		logger.Log.Error("Workflow failed", zap.String("workflow", wf.Name), zap.String("server_id", run.Target), zap.Error(err))
+		writeLog(fmt.Sprintf("Workflow '%s' failed: %v", wf.Name, err))
+	}
+}
+
+func closeClients(clients map[string]*util.SSHClient) {
+	for _, client := range clients {
+		client.Close()
+	}
+}
@@ -0,0 +1,67 @@
+package topology
+
+import "github.com/zhinea/sylix/internal/module/controlplane/entity"
+
+// Graph is the undirected graph of service nodes in a cluster.
+type Graph struct {
+	order     []*entity.ServiceNode
+	nodes     map[string]*entity.ServiceNode
+	adjacency map[string]map[string]bool
+}
+
+// NewGraph builds a graph from nodes and edges. Edges whose ends are not among nodes are ignored.
+func NewGraph(nodes []*entity.ServiceNode, edges []*entity.ServiceEdge) *Graph {
+	g := &Graph{
+		order:     nodes,
+		nodes:     make(map[string]*entity.ServiceNode, len(nodes)),
+		adjacency: make(map[string]map[string]bool, len(nodes)),
+	}
+	for _, node := range nodes {
+		g.nodes[node.Id] = node
+		g.adjacency[node.Id] = make(map[string]bool)
+	}
+	for _, edge := range edges {
+		if g.nodes[edge.NodeAID] == nil || g.nodes[edge.NodeBID] == nil {
+			continue
+		}
+		g.adjacency[edge.NodeAID][edge.NodeBID] = true
+		g.adjacency[edge.NodeBID][edge.NodeAID] = true
+	}
+	return g
+}
+
+func (g *Graph) Node(id string) (*entity.ServiceNode, bool) {
+	node, ok := g.nodes[id]
+	return node, ok
+}
+
+// Nodes returns the nodes in the order the graph was built with.
+func (g *Graph) Nodes() []*entity.ServiceNode {
+	return g.order
+}
+
+// Neighbors returns the nodes connected to id, in graph order.
+func (g *Graph) Neighbors(id string) []*entity.ServiceNode {
+	var neighbors []*entity.ServiceNode
+	for _, node := range g.order {
+		if g.adjacency[id][node.Id] {
+			neighbors = append(neighbors, node)
+		}
+	}
+	return neighbors
+}
+
+// NeighborsOfType returns the neighbors of id running the given node type.
+func (g *Graph) NeighborsOfType(id, nodeType string) []*entity.ServiceNode {
+	var neighbors []*entity.ServiceNode
+	for _, node := range g.Neighbors(id) {
+		if node.App.Service == nodeType {
+			neighbors = append(neighbors, node)
+		}
+	}
+	return neighbors
+}
+
+func (g *Graph) Connected(a, b string) bool {
+	return g.adjacency[a][b]
+}
@@ -0,0 +1,173 @@
+package topology
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/nodetype"
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+)
+
+// Endpoint is an address a node can reach an upstream port on.
+type Endpoint struct {
+	ServiceID string
+	Host      string
+	Port      int
+}
+
+// Import is an imported port of a node together with the upstreams that satisfy it.
+type Import struct {
+	ServiceID string
+	From      string
+	Port      int
+	Protocol  string
+	Upstreams []Endpoint
+}
+
+// Problem is an import that could not be resolved.
+type Problem struct {
+	ServiceID string
+	Port      string
+	Message   string
+}
+
+type Resolution struct {
+	Imports  []Import
+	Problems []Problem
+}
+
+// Valid reports whether every import of every node was resolved.
+func (r *Resolution) Valid() bool {
+	return len(r.Problems) == 0
+}
+
+// Upstreams returns the endpoints resolved for the given import of a node.
+func (r *Resolution) Upstreams(serviceID, from string, port int) []Endpoint {
+	for _, imp := range r.Imports {
+		if imp.ServiceID == serviceID && imp.From == from && imp.Port == port {
+			return imp.Upstreams
+		}
+	}
+	return nil
+}
+
+// Resolver computes, for each node, the upstream host:port of every port it imports.
+type Resolver struct {
+	registry *nodetype.Registry
+	servers  map[string]*entity.Server
+}
+
+func NewResolver(registry *nodetype.Registry, servers []*entity.Server) *Resolver {
+	r := &Resolver{
+		registry: registry,
+		servers:  make(map[string]*entity.Server, len(servers)),
+	}
+	for _, server := range servers {
+		r.servers[server.Id] = server
+	}
+	return r
+}
+
+func (r *Resolver) Resolve(g *Graph) *Resolution {
+	res := &Resolution{}
+
+	for _, node := range g.Nodes() {
+		if node.Type != entity.ServiceTypeNode {
+			continue
+		}
+
+		nodeType, ok := r.registry.Get(node.App.Service)
+		if !ok {
+			res.Problems = append(res.Problems, Problem{
+				ServiceID: node.Id,
+				Message:   fmt.Sprintf("unknown node type %q", node.App.Service),
+			})
+			continue
+		}
+
+		for _, binding := range nodeType.Imports {
+			peers := g.NeighborsOfType(node.Id, binding.From)
+
+			for _, key := range sortedPorts(binding.Ports) {
+				port, protocol, _ := nodetype.ParsePort(key)
+
+				switch {
+				case len(peers) == 0:
+					res.Problems = append(res.Problems, Problem{
+						ServiceID: node.Id,
+						Port:      key,
+						Message:   fmt.Sprintf("%s needs %s from a %s, but none is connected", node.Name, key, binding.From),
+					})
+					continue
+				case len(peers) > 1 && !binding.Multiple:
+					res.Problems = append(res.Problems, Problem{
+						ServiceID: node.Id,
+						Port:      key,
+						Message:   fmt.Sprintf("%s needs %s from exactly one %s, but %d are connected", node.Name, key, binding.From, len(peers)),
+					})
+					continue
+				}
+
+				imp := Import{
+					ServiceID: node.Id,
+					From:      binding.From,
+					Port:      port,
+					Protocol:  protocol,
+				}
+				for _, peer := range peers {
+					endpoint, err := r.endpoint(peer, port, protocol)
+					if err != nil {
+						res.Problems = append(res.Problems, Problem{
+							ServiceID: node.Id,
+							Port:      key,
+							Message:   err.Error(),
+						})
+						continue
+					}
+					imp.Upstreams = append(imp.Upstreams, endpoint)
+				}
+				if len(imp.Upstreams) > 0 {
+					res.Imports = append(res.Imports, imp)
+				}
+			}
+		}
+	}
+
+	return res
+}
+
+// endpoint addresses the peer's port through its server.
+func (r *Resolver) endpoint(peer *entity.ServiceNode, port int, protocol string) (Endpoint, error) {
+	server, ok := r.servers[peer.ServerID]
+	if !ok {
+		return Endpoint{}, fmt.Errorf("server of %s not found", peer.Name)
+	}
+
+	published := port
+	if out := peer.OutPort(port, protocol); out != nil && out.PublishedPort > 0 {
+		published = out.PublishedPort
+	}
+
+	return Endpoint{
+		ServiceID: peer.Id,
+		Host:      Host(server),
+		Port:      published,
+	}, nil
+}
+
+// Host returns the address other nodes reach a server on, preferring the WireGuard address.
+func Host(server *entity.Server) string {
+	if server.InternalIP != "" {
+		return server.InternalIP
+	}
+	return server.IpAddress
+}
+
+func sortedPorts(ports map[string]string) []string {
+	keys := make([]string, 0, len(ports))
+	for key := range ports {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
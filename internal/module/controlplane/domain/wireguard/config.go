@@ -0,0 +1,171 @@
+package wireguard
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type Interface struct {
+	PrivateKey string
+	Address    string
+	ListenPort int
+}
+
+type Peer struct {
+	Name                string
+	PublicKey           string
+	AllowedIPs          string
+	Endpoint            string
+	PersistentKeepalive int
+}
+
+// Config is a wg-quick configuration, as written to /etc/wireguard/wg0.conf.
+type Config struct {
+	Interface Interface
+	Peers     []Peer
+}
+
+func (c *Config) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "[Interface]\nPrivateKey = %s\nAddress = %s\nListenPort = %d\n",
+		c.Interface.PrivateKey, c.Interface.Address, c.Interface.ListenPort)
+
+	for _, peer := range c.Peers {
+		b.WriteString("\n[Peer]\n")
+		if peer.Name != "" {
+			fmt.Fprintf(&b, "# %s\n", peer.Name)
+		}
+		fmt.Fprintf(&b, "PublicKey = %s\nAllowedIPs = %s\nEndpoint = %s\n", peer.PublicKey, peer.AllowedIPs, peer.Endpoint)
+		if peer.PersistentKeepalive > 0 {
+			fmt.Fprintf(&b, "PersistentKeepalive = %d\n", peer.PersistentKeepalive)
+		}
+	}
+
+	return b.String()
+}
+
+// Parse reads a wg-quick configuration. Keys it does not know are ignored; the comment right after
+// a [Peer] header is taken as the peer's name.
+func Parse(data string) (*Config, error) {
+	config := &Config{}
+	var peer *Peer
+	section := ""
+
+	scanner := bufio.NewScanner(strings.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "#"):
+			if peer != nil && peer.Name == "" && peer.PublicKey == "" {
+				peer.Name = strings.TrimSpace(strings.TrimPrefix(line, "#"))
+			}
+			continue
+		case strings.EqualFold(line, "[Interface]"):
+			section, peer = "interface", nil
+			continue
+		case strings.EqualFold(line, "[Peer]"):
+			section = "peer"
+			config.Peers = append(config.Peers, Peer{})
+			peer = &config.Peers[len(config.Peers)-1]
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid line %q", line)
+		}
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.TrimSpace(value)
+
+		switch section {
+		case "interface":
+			switch key {
+			case "privatekey":
+				config.Interface.PrivateKey = value
+			case "address":
+				config.Interface.Address = value
+			case "listenport":
+				port, err := strconv.Atoi(value)
+				if err != nil {
+					return nil, fmt.Errorf("invalid ListenPort %q", value)
+				}
+				config.Interface.ListenPort = port
+			}
+		case "peer":
+			switch key {
+			case "publickey":
+				peer.PublicKey = value
+			case "allowedips":
+				peer.AllowedIPs = value
+			case "endpoint":
+				peer.Endpoint = value
+			case "persistentkeepalive":
+				keepalive, err := strconv.Atoi(value)
+				if err != nil {
+					return nil, fmt.Errorf("invalid PersistentKeepalive %q", value)
+				}
+				peer.PersistentKeepalive = keepalive
+			}
+		default:
+			return nil, fmt.Errorf("line %q outside of a section", line)
+		}
+	}
+
+	return config, scanner.Err()
+}
+
+// Diff lists what differs between two configurations. Peers are matched by public key.
+type Diff struct {
+	InterfaceChanged bool
+	Added            []string
+	Removed          []string
+	Updated          []string
+}
+
+// Empty reports whether both configurations are equivalent.
+func (d Diff) Empty() bool {
+	return !d.InterfaceChanged && len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Updated) == 0
+}
+
+// Compare computes what has to change to turn current into desired. A nil current is an empty configuration.
+func Compare(current, desired *Config) Diff {
+	if current == nil {
+		current = &Config{}
+	}
+
+	diff := Diff{
+		InterfaceChanged: current.Interface != desired.Interface,
+	}
+
+	existing := make(map[string]Peer, len(current.Peers))
+	for _, peer := range current.Peers {
+		existing[peer.PublicKey] = peer
+	}
+
+	wanted := make(map[string]bool, len(desired.Peers))
+	for _, peer := range desired.Peers {
+		wanted[peer.PublicKey] = true
+
+		old, ok := existing[peer.PublicKey]
+		switch {
+		case !ok:
+			diff.Added = append(diff.Added, peer.PublicKey)
+		case old.AllowedIPs != peer.AllowedIPs || old.Endpoint != peer.Endpoint || old.PersistentKeepalive != peer.PersistentKeepalive:
+			diff.Updated = append(diff.Updated, peer.PublicKey)
+		}
+	}
+
+	for _, peer := range current.Peers {
+		if !wanted[peer.PublicKey] {
+			diff.Removed = append(diff.Removed, peer.PublicKey)
+		}
+	}
+
+	return diff
+}
@@ -2,15 +2,52 @@ package workflow
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/zhinea/sylix/internal/common"
 	"github.com/zhinea/sylix/internal/common/workflow"
 )
 
+// DefaultAgentVersion is installed when neither a version is configured nor the controlplane is a release build.
+const DefaultAgentVersion = "0.1.1"
+
+const agentReleaseURL = "https://github.com/zhinea/sylix/releases/download/v%s/agent"
+
+// KindAgentInstall is the kind of the agent install workflow, which is resumed after a restart.
+const KindAgentInstall = "agent_install"
+
+// Stages of the agent install workflow, in the order they run.
+const (
+	StageInstall   = "install"   // stop the old agent and download the binary
+	StageConfigure = "configure" // write certificates, configuration and the systemd unit
+	StageFinalize  = "finalize"  // start the agent and install Docker
+)
+
+// Secrets of the agent install workflow, redacted from its logs and plans
+const (
+	varServerKey   = "server_key"
+	varAgentConfig = "agent_config" // holds the credentials of the backup storages
+)
+
 type AgentInstallParams struct {
 	DownloadURL   string
 	ServerCert    string
 	ServerKey     string
+	CACert        string // CA the controlplane's client certificate is signed by
 	ConfigContent string
+	AgentPort     int // port the agent listens on, as set in ConfigContent
+}
+
+// AgentDownloadURL returns where the release of the agent binary for version is downloaded from.
+// An empty version means the version of the controlplane itself.
+func AgentDownloadURL(version string) string {
+	if version == "" {
+		version = common.Version
+	}
+	if version == "0.0.0-dev" {
+		version = DefaultAgentVersion
+	}
+	return fmt.Sprintf(agentReleaseURL, version)
 }
 
 func NewAgentInstallWorkflow(params AgentInstallParams) workflow.Workflow {
@@ -67,71 +104,111 @@ echo "Docker installation complete."
 
 	return workflow.Workflow{
 		Name: "Install Sylix Agent",
+		Kind: KindAgentInstall,
+		Secrets: map[string]string{
+			varServerKey:   params.ServerKey,
+			varAgentConfig: params.ConfigContent,
+		},
 		Steps: []workflow.Step{
 			{
 				Name:        "Stop existing service",
 				Action:      workflow.ActionCommand,
 				Command:     "systemctl stop sylix-agent || true",
 				IgnoreError: true,
+				Stage:       StageInstall,
 			},
 			{
 				Name:    "Download agent binary",
 				Action:  workflow.ActionCommand,
+				Retry:   workflow.RetryPolicy{Attempts: 3, Backoff: 5 * time.Second},
 				Command: fmt.Sprintf("if command -v curl >/dev/null 2>&1; then curl -L -f -o %s %s; elif command -v wget >/dev/null 2>&1; then wget -O %s %s; else echo 'Error: neither curl nor wget found'; exit 1; fi", remoteBinaryPath, params.DownloadURL, remoteBinaryPath, params.DownloadURL),
+				Stage:   StageInstall,
 			},
 			{
 				Name:    "Make agent executable",
 				Action:  workflow.ActionCommand,
 				Command: "chmod +x " + remoteBinaryPath,
+				Stage:   StageInstall,
 			},
 			{
 				Name:    "Create certs directory",
 				Action:  workflow.ActionCommand,
 				Command: "mkdir -p /etc/sylix-agent/certs",
+				Stage:   StageConfigure,
 			},
 			{
 				Name:     "Write server certificate",
 				Action:   workflow.ActionWriteFile,
 				DestPath: "/etc/sylix-agent/certs/server.crt",
 				Content:  params.ServerCert,
+				Stage:    StageConfigure,
 			},
 			{
 				Name:     "Write server key",
 				Action:   workflow.ActionWriteFile,
 				DestPath: "/etc/sylix-agent/certs/server.key",
-				Content:  params.ServerKey,
+				Content:  "{{." + varServerKey + "}}",
+				Mode:     0600,
+				Stage:    StageConfigure,
+			},
+			{
+				Name:     "Write CA certificate",
+				Action:   workflow.ActionWriteFile,
+				DestPath: "/etc/sylix-agent/certs/ca.crt",
+				Content:  params.CACert,
+				Stage:    StageConfigure,
 			},
 			{
 				Name:    "Create config directory",
 				Action:  workflow.ActionCommand,
 				Command: "mkdir -p /etc/sylix-agent",
+				Stage:   StageConfigure,
 			},
 			{
 				Name:     "Write configuration file",
 				Action:   workflow.ActionWriteFile,
 				DestPath: "/etc/sylix-agent/config.yaml",
-				Content:  params.ConfigContent,
+				Content:  "{{." + varAgentConfig + "}}",
+				Mode:     0600,
+				Stage:    StageConfigure,
 			},
 			{
 				Name:     "Write systemd service file",
 				Action:   workflow.ActionWriteFile,
 				DestPath: "/etc/systemd/system/sylix-agent.service",
 				Content:  serviceContent,
+				Stage:    StageConfigure,
 			},
 			{
 				Name:    "Reload systemd daemon",
 				Action:  workflow.ActionCommand,
 				Command: "systemctl daemon-reload",
+				Stage:   StageFinalize,
 			},
 			{
 				Name:    "Enable sylix-agent service",
 				Action:  workflow.ActionCommand,
 				Command: "systemctl enable sylix-agent",
+				Stage:   StageFinalize,
 			},
 			{
 				Name:    "Restart sylix-agent service",
 				Action:  workflow.ActionCommand,
 				Command: "systemctl restart sylix-agent",
+				Stage:   StageFinalize,
+				// An agent that never came up healthy is not left running
+				Rollback: &workflow.Step{
+					Name:    "Stop sylix-agent service",
+					Action:  workflow.ActionCommand,
+					Command: "systemctl stop sylix-agent",
+				},
+			},
+			{
+				Name:    "Wait for sylix-agent to listen",
+				Action:  workflow.ActionWaitForPort,
+				Port:    params.AgentPort,
+				Timeout: 30 * time.Second,
+				Stage:   StageFinalize,
 			},
 			// Docker Installation Steps
 			{
@@ -140,18 +217,21 @@ echo "Docker installation complete."
 				DestPath:  "/tmp/install_docker.sh",
 				Content:   dockerInstallScript,
 				Condition: "! command -v docker",
+				Stage:     StageFinalize,
 			},
 			{
 				Name:      "Run Docker install script",
 				Action:    workflow.ActionCommand,
 				Command:   "chmod +x /tmp/install_docker.sh && sudo /tmp/install_docker.sh",
 				Condition: "! command -v docker",
+				Stage:     StageFinalize,
 			},
 			{
 				Name:      "Cleanup Docker install script",
 				Action:    workflow.ActionCommand,
 				Command:   "rm /tmp/install_docker.sh",
 				Condition: "! command -v docker",
+				Stage:     StageFinalize,
 			},
 		},
 	}
@@ -0,0 +1,31 @@
+package workflow
+
+import "github.com/zhinea/sylix/internal/common/workflow"
+
+// Variables the WireGuard keys workflow leaves its keys in.
+const (
+	WireGuardPrivateKey = "private_key"
+	WireGuardPublicKey  = "public_key"
+)
+
+// NewWireGuardKeysWorkflow generates a WireGuard key pair on the server. The private key is kept out of the logs.
+func NewWireGuardKeysWorkflow() workflow.Workflow {
+	return workflow.Workflow{
+		Name: "Generate WireGuard keys",
+		Steps: []workflow.Step{
+			{
+				Name:    "Generate private key",
+				Action:  workflow.ActionCommand,
+				Command: "wg genkey",
+				Output:  WireGuardPrivateKey,
+				Secret:  true,
+			},
+			{
+				Name:    "Derive public key",
+				Action:  workflow.ActionCommand,
+				Command: "echo '{{." + WireGuardPrivateKey + "}}' | wg pubkey",
+				Output:  WireGuardPublicKey,
+			},
+		},
+	}
+}
@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/zhinea/sylix/internal/common/model"
+)
+
+// BackupJob is a backup of a service node uploaded to a backup storage, scheduled by the node's
+// backup_schedule field or triggered by hand.
+type BackupJob struct {
+	model.Model
+	ServiceNodeID string        `json:"service_node_id" gorm:"index"`
+	StorageID     string        `json:"storage_id" gorm:"index"`
+	Kind          string        `json:"kind"`
+	Trigger       string        `json:"trigger"`
+	Status        int           `json:"status" gorm:"index"`
+	Error         string        `json:"error"`
+	Bucket        string        `json:"bucket"`
+	Key           string        `json:"key"`
+	Size          int64         `json:"size"`
+	Checksum      string        `json:"checksum"` // SHA-256 of the object, hex encoded
+	Duration      time.Duration `json:"duration"`
+	StartedAt     time.Time     `json:"started_at"`
+	FinishedAt    *time.Time    `json:"finished_at"`
+}
+
+const (
+	BackupJobStatusRunning   = 0
+	BackupJobStatusSucceeded = 1
+	BackupJobStatusFailed    = 2
+)
+
+// Kinds of backups, by the type of the node backed up
+const (
+	BackupKindWAL        = "wal"        // the WAL a safekeeper holds
+	BackupKindLayers     = "layers"     // the layer files of a pageserver
+	BackupKindBasebackup = "basebackup" // pg_basebackup of a compute
+)
+
+const (
+	BackupTriggerSchedule = "schedule"
+	BackupTriggerManual   = "manual"
+)
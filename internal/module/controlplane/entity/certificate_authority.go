@@ -0,0 +1,17 @@
+package entity
+
+import "github.com/zhinea/sylix/internal/common/model"
+
+// CertificateAuthority is the CA agents and the controlplane authenticate each other with, together
+// with the client certificate the controlplane presents to agents.
+type CertificateAuthority struct {
+	model.Model
+	Name       string `json:"name" gorm:"uniqueIndex"`
+	Cert       string `json:"cert"`
+	Key        string `json:"-"`
+	ClientCert string `json:"client_cert"`
+	ClientKey  string `json:"-"`
+}
+
+// ClusterCA is the name of the CA agents are issued certificates from.
+const ClusterCA = "cluster"
@@ -0,0 +1,47 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/zhinea/sylix/internal/common/model"
+)
+
+// Deployment is a run of a cluster's deployment plan. Its steps are persisted up front, so an
+// interrupted deployment resumes with the exact plan it started with.
+type Deployment struct {
+	model.Model
+	ClusterID  string            `json:"cluster_id" gorm:"index"`
+	Status     int               `json:"status"`
+	Error      string            `json:"error"`
+	Steps      []*DeploymentStep `json:"steps" gorm:"foreignKey:DeploymentID"`
+	FinishedAt *time.Time        `json:"finished_at"`
+}
+
+// DeploymentStep is one compose project of the plan, started with docker compose on ServerID.
+type DeploymentStep struct {
+	model.Model
+	DeploymentID string     `json:"deployment_id" gorm:"index"`
+	Position     int        `json:"position"`
+	Priority     int        `json:"priority"`
+	Project      string     `json:"project"`
+	ServerID     string     `json:"server_id"`
+	ServiceIDs   []string   `json:"service_ids" gorm:"serializer:json"`
+	Compose      string     `json:"compose"`
+	Status       int        `json:"status"`
+	Error        string     `json:"error"`
+	StartedAt    *time.Time `json:"started_at"`
+	FinishedAt   *time.Time `json:"finished_at"`
+}
+
+// Finished reports whether the deployment reached a final status.
+func (d *Deployment) Finished() bool {
+	return d.Status == DeploymentStatusSucceeded || d.Status == DeploymentStatusFailed || d.Status == DeploymentStatusCancelled
+}
+
+const (
+	DeploymentStatusPending   = 0
+	DeploymentStatusRunning   = 1
+	DeploymentStatusSucceeded = 2
+	DeploymentStatusFailed    = 3
+	DeploymentStatusCancelled = 4
+)
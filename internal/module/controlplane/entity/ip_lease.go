@@ -0,0 +1,10 @@
+package entity
+
+import "github.com/zhinea/sylix/internal/common/model"
+
+// IPLease assigns an address of the WireGuard pool to a server.
+type IPLease struct {
+	model.Model
+	Address  string `json:"address" gorm:"uniqueIndex"`
+	ServerID string `json:"server_id" gorm:"uniqueIndex"`
+}
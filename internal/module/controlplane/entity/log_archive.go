@@ -0,0 +1,21 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/zhinea/sylix/internal/common/model"
+)
+
+// LogArchive is a compressed log file uploaded to backup storage. The local copy may be deleted
+// once uploaded, reads then download it again.
+type LogArchive struct {
+	model.Model
+	Path       string    `json:"path" gorm:"uniqueIndex"` // path of the file under logs/
+	StorageID  string    `json:"storage_id" gorm:"index"`
+	Bucket     string    `json:"bucket"`
+	Key        string    `json:"key"`
+	Size       int64     `json:"size"`
+	Local      bool      `json:"local"`       // whether the file is still under logs/
+	ModifiedAt time.Time `json:"modified_at"` // when the file was last written
+	ArchivedAt time.Time `json:"archived_at"`
+}
@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/zhinea/sylix/internal/common/model"
+)
+
+// MeshSyncReport is the outcome of one WireGuard mesh sync, with a result per server.
+type MeshSyncReport struct {
+	model.Model
+	Status     int               `json:"status"`
+	Results    []*MeshSyncResult `json:"results" gorm:"foreignKey:ReportID"`
+	FinishedAt time.Time         `json:"finished_at"`
+}
+
+// MeshSyncResult tells what a mesh sync did on a server.
+type MeshSyncResult struct {
+	model.Model
+	ReportID     string `json:"report_id" gorm:"index"`
+	ServerID     string `json:"server_id"`
+	ServerName   string `json:"server_name"`
+	Action       string `json:"action"`
+	PeersAdded   int    `json:"peers_added"`
+	PeersRemoved int    `json:"peers_removed"`
+	PeersUpdated int    `json:"peers_updated"`
+	Error        string `json:"error"`
+	DurationMs   int64  `json:"duration_ms"`
+}
+
+const (
+	MeshSyncStatusSucceeded = 0
+	MeshSyncStatusPartial   = 1
+	MeshSyncStatusFailed    = 2
+)
+
+const (
+	// MeshSyncActionUnchanged means the server already had the desired configuration.
+	MeshSyncActionUnchanged = "unchanged"
+	// MeshSyncActionSynced means peers were applied live with wg syncconf.
+	MeshSyncActionSynced = "synced"
+	// MeshSyncActionRestarted means the interface changed or was down and wg-quick was restarted.
+	MeshSyncActionRestarted = "restarted"
+	MeshSyncActionFailed    = "failed"
+)
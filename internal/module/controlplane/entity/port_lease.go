@@ -0,0 +1,14 @@
+package entity
+
+import "github.com/zhinea/sylix/internal/common/model"
+
+// PortLease reserves a host port on a server for a published container port of a service.
+// A host port can only be leased once per server and protocol.
+type PortLease struct {
+	model.Model
+	ServerID      string `json:"server_id" gorm:"uniqueIndex:idx_port_leases_port"`
+	Port          int    `json:"port" gorm:"uniqueIndex:idx_port_leases_port"`
+	Protocol      string `json:"protocol" gorm:"uniqueIndex:idx_port_leases_port"`
+	ServiceNodeID string `json:"service_node_id" gorm:"index"`
+	ContainerPort int    `json:"container_port"`
+}
@@ -23,6 +23,7 @@ type Server struct {
 	InternalIP     string           `json:"internal_ip"`
 	Port           int              `json:"port"`
 	Protocol       string           `json:"protocol"`
+	HostKey        string           `json:"-"` // SSH host key pinned on first connection, authorized_keys format
 	Credential     ServerCredential `json:"credential" gorm:"embedded;embeddedPrefix:credential_"`
 	Agent          ServerAgent      `json:"agent" gorm:"embedded;embeddedPrefix:agent_"`
 	WireGuard      ServerWireGuard  `json:"wire_guard" gorm:"embedded;embeddedPrefix:wg_"`
@@ -0,0 +1,32 @@
+package entity
+
+import "github.com/zhinea/sylix/internal/common/model"
+
+// ServiceEdge is an undirected connection between two service nodes.
+// NodeAID always holds the smaller id of the pair so each pair is stored once.
+type ServiceEdge struct {
+	model.Model
+	ClusterID string `json:"cluster_id" gorm:"index"`
+	NodeAID   string `json:"node_a_id" gorm:"uniqueIndex:idx_service_edges_pair"`
+	NodeBID   string `json:"node_b_id" gorm:"uniqueIndex:idx_service_edges_pair"`
+}
+
+// NewServiceEdge orders the pair so (a, b) and (b, a) produce the same edge.
+func NewServiceEdge(clusterID, a, b string) *ServiceEdge {
+	if b < a {
+		a, b = b, a
+	}
+	return &ServiceEdge{
+		ClusterID: clusterID,
+		NodeAID:   a,
+		NodeBID:   b,
+	}
+}
+
+// Other returns the node on the opposite end of the edge.
+func (e *ServiceEdge) Other(id string) string {
+	if e.NodeAID == id {
+		return e.NodeBID
+	}
+	return e.NodeAID
+}
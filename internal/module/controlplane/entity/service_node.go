@@ -0,0 +1,109 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/zhinea/sylix/internal/common/model"
+)
+
+type ServiceApp struct {
+	App     string `json:"app" validate:"required"`
+	Version string `json:"version"`
+	Service string `json:"service"`
+}
+
+type ServiceContainer struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Image     string    `json:"image"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime:false"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime:false"`
+}
+
+type ServiceNodeField struct {
+	model.Model
+	ServiceNodeID string `json:"service_node_id" gorm:"index"`
+	Key           string `json:"key" validate:"required"`
+	Value         string `json:"value"`
+	Type          string `json:"type"`
+}
+
+type ServicePort struct {
+	model.Model
+	ServiceNodeID string `json:"service_node_id" gorm:"index"`
+	Type          int    `json:"type"`
+	Port          int    `json:"port"`
+	Protocol      string `json:"protocol"`
+	Host          string `json:"host"`
+	EnabledExpose bool   `json:"enabled_expose"`
+	PublishedPort int    `json:"published_port"`
+}
+
+type ServiceNode struct {
+	model.Model
+	Name      string              `json:"name" validate:"required,max=100"`
+	Type      int                 `json:"type"`
+	Status    int                 `json:"status"`
+	App       ServiceApp          `json:"app" gorm:"embedded;embeddedPrefix:app_"`
+	Fields    []*ServiceNodeField `json:"fields" gorm:"foreignKey:ServiceNodeID" validate:"dive"`
+	ServerID  string              `json:"server_id" gorm:"index"`
+	Nodes     []*ServiceNode      `json:"nodes" gorm:"foreignKey:ParentID" validate:"-"`
+	Ports     []*ServicePort      `json:"ports" gorm:"foreignKey:ServiceNodeID"`
+	Container ServiceContainer    `json:"container" gorm:"embedded;embeddedPrefix:container_"`
+	ParentID  *string             `json:"parent_id" gorm:"index"`
+	// Ordinal numbers the node among the nodes of its type in its cluster from 1, assigned once on
+	// creation and never reused, so it can serve as a stable id such as the node id of Neon.
+	Ordinal int `json:"ordinal"`
+}
+
+// Field returns the value of the field with the given key, or an empty string.
+func (n *ServiceNode) Field(key string) string {
+	for _, f := range n.Fields {
+		if f.Key == key {
+			return f.Value
+		}
+	}
+	return ""
+}
+
+// OutPort returns the exported port with the given container port, if any.
+func (n *ServiceNode) OutPort(port int, protocol string) *ServicePort {
+	for _, p := range n.Ports {
+		if p.Type == ServicePortTypeOut && p.Port == port && p.Protocol == protocol {
+			return p
+		}
+	}
+	return nil
+}
+
+// ContainerRef returns the identifier docker commands should target.
+func (n *ServiceNode) ContainerRef() string {
+	if n.Container.Name != "" {
+		return n.Container.Name
+	}
+	return n.Container.ID
+}
+
+const (
+	ServiceTypeCluster = 0
+	ServiceTypeNode    = 1
+)
+
+const (
+	ServiceStatusOffline      = 0
+	ServiceStatusProvisioning = 1
+	ServiceStatusRunning      = 2
+	ServiceStatusDeleting     = 3
+	ServiceStatusError        = 4
+)
+
+const (
+	ServicePortTypeIn  = 0
+	ServicePortTypeOut = 1
+)
+
+const (
+	ServiceActionStart   = 0
+	ServiceActionStop    = 1
+	ServiceActionRestart = 2
+)
@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/zhinea/sylix/internal/common/model"
+)
+
+// WorkflowRun is a recorded execution of a workflow, kept so runs interrupted by a restart can be
+// resumed or failed.
+type WorkflowRun struct {
+	model.Model
+	Kind       string             `json:"kind" gorm:"index"`
+	Name       string             `json:"name"`
+	Target     string             `json:"target" gorm:"index"`
+	Status     string             `json:"status" gorm:"index"`
+	Error      string             `json:"error"`
+	Steps      []*WorkflowStepRun `json:"steps" gorm:"foreignKey:RunID"`
+	StartedAt  time.Time          `json:"started_at"`
+	FinishedAt time.Time          `json:"finished_at"`
+}
+
+// WorkflowStepRun is the state of one step of a workflow run.
+type WorkflowStepRun struct {
+	model.Model
+	RunID      string    `json:"run_id" gorm:"index"`
+	Position   int       `json:"position"`
+	Name       string    `json:"name"`
+	Host       string    `json:"host"` // the host of the workflow the step ran on, empty for the run's target
+	Status     string    `json:"status"`
+	Attempts   int       `json:"attempts"`
+	Error      string    `json:"error"`
+	Output     string    `json:"-"` // may hold a secret, the value of the step's output variable
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+}
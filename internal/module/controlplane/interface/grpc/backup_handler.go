@@ -2,22 +2,29 @@ package grpc
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/zhinea/sylix/internal/common/model"
 	pbCommon "github.com/zhinea/sylix/internal/infra/proto/common"
 	pbControlPlane "github.com/zhinea/sylix/internal/infra/proto/controlplane"
+	"github.com/zhinea/sylix/internal/module/controlplane/app"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/repository"
 	"github.com/zhinea/sylix/internal/module/controlplane/domain/services"
 	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+	"gorm.io/gorm"
 )
 
 type BackupStorageService struct {
 	pbControlPlane.UnimplementedBackupStorageServiceServer
-	service *services.BackupService
+	service   *services.BackupService
+	scheduler *app.BackupScheduler
 }
 
-func NewBackupStorageService(service *services.BackupService) *BackupStorageService {
+func NewBackupStorageService(service *services.BackupService, scheduler *app.BackupScheduler) *BackupStorageService {
 	return &BackupStorageService{
-		service: service,
+		service:   service,
+		scheduler: scheduler,
 	}
 }
 
@@ -116,6 +123,55 @@ func (s *BackupStorageService) TestConnection(ctx context.Context, req *pbContro
 	}, nil
 }
 
+func (s *BackupStorageService) ListJobs(ctx context.Context, req *pbControlPlane.ListBackupJobsRequest) (*pbControlPlane.BackupJobsResponse, error) {
+	jobs, total, err := s.scheduler.Jobs(ctx, repository.BackupJobQuery{
+		ServiceNodeID: req.ServiceId,
+		StorageID:     req.StorageId,
+		Page:          int(req.Page),
+		Limit:         int(req.Limit),
+	})
+	if err != nil {
+		errStr := err.Error()
+		return &pbControlPlane.BackupJobsResponse{
+			Status: pbControlPlane.BackupStatusCode_BACKUP_INTERNAL_ERROR,
+			Error:  &errStr,
+		}, nil
+	}
+
+	pbJobs := make([]*pbControlPlane.BackupJob, 0, len(jobs))
+	for _, job := range jobs {
+		pbJobs = append(pbJobs, s.jobToProto(job))
+	}
+
+	return &pbControlPlane.BackupJobsResponse{
+		Status: pbControlPlane.BackupStatusCode_BACKUP_OK,
+		Data:   pbJobs,
+		Total:  total,
+	}, nil
+}
+
+func (s *BackupStorageService) TriggerJob(ctx context.Context, req *pbControlPlane.TriggerBackupJobRequest) (*pbControlPlane.BackupJobResponse, error) {
+	job, err := s.scheduler.Trigger(ctx, req.ServiceId)
+	if err != nil {
+		status := pbControlPlane.BackupStatusCode_BACKUP_INTERNAL_ERROR
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			status = pbControlPlane.BackupStatusCode_BACKUP_NOT_FOUND
+		case errors.Is(err, app.ErrBackupNotConfigured), errors.Is(err, app.ErrBackupInProgress):
+			status = pbControlPlane.BackupStatusCode_BACKUP_BAD_REQUEST
+		}
+		errStr := err.Error()
+		return &pbControlPlane.BackupJobResponse{
+			Status: status,
+			Error:  &errStr,
+		}, nil
+	}
+	return &pbControlPlane.BackupJobResponse{
+		Status: pbControlPlane.BackupStatusCode_BACKUP_CREATED,
+		Data:   s.jobToProto(job),
+	}, nil
+}
+
 func (s *BackupStorageService) protoToEntity(pb *pbControlPlane.BackupStorage) *entity.BackupStorage {
 	return &entity.BackupStorage{
 		Model: model.Model{
@@ -150,3 +206,22 @@ func (s *BackupStorageService) entityToProto(e *entity.BackupStorage) *pbControl
 		ServerIds:    serverIds,
 	}
 }
+
+func (s *BackupStorageService) jobToProto(e *entity.BackupJob) *pbControlPlane.BackupJob {
+	return &pbControlPlane.BackupJob{
+		Id:         e.Id,
+		ServiceId:  e.ServiceNodeID,
+		StorageId:  e.StorageID,
+		Kind:       e.Kind,
+		Trigger:    e.Trigger,
+		Status:     pbControlPlane.BackupJobStatus(e.Status),
+		Error:      e.Error,
+		Bucket:     e.Bucket,
+		Key:        e.Key,
+		Size:       e.Size,
+		Checksum:   e.Checksum,
+		DurationMs: e.Duration.Milliseconds(),
+		StartedAt:  e.StartedAt.Format(time.RFC3339),
+		FinishedAt: formatTime(e.FinishedAt),
+	}
+}
@@ -2,11 +2,16 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"os"
+	"time"
 
 	pbCommon "github.com/zhinea/sylix/internal/infra/proto/common"
 	pbControlPlane "github.com/zhinea/sylix/internal/infra/proto/controlplane"
 	"github.com/zhinea/sylix/internal/module/controlplane/app"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/services"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type LogsService struct {
@@ -21,40 +26,30 @@ func NewLogsService(useCase *app.LogsUseCase) *LogsService {
 }
 
 func (s *LogsService) GetServerLogs(ctx context.Context, req *pbControlPlane.GetServerLogsRequest) (*pbControlPlane.GetServerLogsResponse, error) {
-	entries, err := s.useCase.GetServerLogs(ctx, req.ServerId)
+	logFiles, err := s.useCase.GetServerLogs(ctx, req.ServerId)
 	if err != nil {
-		return nil, err
-	}
-
-	var files []*pbControlPlane.LogFile
-	for _, entry := range entries {
-		info, err := entry.Info()
-		if err != nil {
-			continue
+		if errors.Is(err, app.ErrInvalidLogPath) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
 		}
-		files = append(files, &pbControlPlane.LogFile{
-			Name:         entry.Name(),
-			Size:         info.Size(),
-			LastModified: info.ModTime().String(),
-		})
+		return nil, err
 	}
 
 	return &pbControlPlane.GetServerLogsResponse{
-		Files: files,
+		Files: logFilesToProto(logFiles),
 	}, nil
 }
 
 func (s *LogsService) ReadServerLog(ctx context.Context, req *pbControlPlane.ReadServerLogRequest) (*pbControlPlane.ReadServerLogResponse, error) {
-	lines, totalLines, totalPages, currentPage, err := s.useCase.ReadServerLog(ctx, req.ServerId, req.Filename, int(req.Page), int(req.PageSize))
+	page, err := s.useCase.ReadServerLog(ctx, req.ServerId, req.Filename, int(req.Page), int(req.PageSize), req.Reverse)
 	if err != nil {
 		return nil, err
 	}
 
 	return &pbControlPlane.ReadServerLogResponse{
-		Lines:       lines,
-		TotalLines:  int32(totalLines),
-		CurrentPage: int32(currentPage),
-		TotalPages:  int32(totalPages),
+		Lines:       page.Lines,
+		TotalLines:  int32(page.TotalLines),
+		CurrentPage: int32(page.Page),
+		TotalPages:  int32(page.TotalPages),
 	}, nil
 }
 
@@ -64,34 +59,208 @@ func (s *LogsService) GetSystemLogs(ctx context.Context, _ *pbCommon.Empty) (*pb
 		return nil, err
 	}
 
+	return &pbControlPlane.GetSystemLogsResponse{
+		Files: logFilesToProto(logFiles),
+	}, nil
+}
+
+func logFilesToProto(logFiles []app.LogFile) []*pbControlPlane.LogFile {
 	var files []*pbControlPlane.LogFile
-	for _, path := range logFiles {
-		info, err := os.Stat(path)
-		if err != nil {
-			continue
-		}
+	for _, file := range logFiles {
 		files = append(files, &pbControlPlane.LogFile{
-			Name:         path,
-			Size:         info.Size(),
-			LastModified: info.ModTime().String(),
+			Name:         file.Name,
+			Size:         file.Size,
+			LastModified: file.LastModified.String(),
+			Archived:     file.Archived,
 		})
 	}
-
-	return &pbControlPlane.GetSystemLogsResponse{
-		Files: files,
-	}, nil
+	return files
 }
 
 func (s *LogsService) ReadSystemLog(ctx context.Context, req *pbControlPlane.ReadSystemLogRequest) (*pbControlPlane.ReadSystemLogResponse, error) {
-	lines, totalLines, totalPages, currentPage, err := s.useCase.ReadSystemLog(ctx, req.Filename, int(req.Page), int(req.PageSize))
+	page, err := s.useCase.ReadSystemLog(ctx, req.Filename, int(req.Page), int(req.PageSize), req.Reverse)
 	if err != nil {
 		return nil, err
 	}
 
 	return &pbControlPlane.ReadSystemLogResponse{
-		Lines:       lines,
-		TotalLines:  int32(totalLines),
-		CurrentPage: int32(currentPage),
-		TotalPages:  int32(totalPages),
+		Lines:       page.Lines,
+		TotalLines:  int32(page.TotalLines),
+		CurrentPage: int32(page.Page),
+		TotalPages:  int32(page.TotalPages),
 	}, nil
 }
+
+func (s *LogsService) SearchLog(ctx context.Context, req *pbControlPlane.SearchLogRequest) (*pbControlPlane.SearchLogResponse, error) {
+	filter := services.LogFilter{
+		Query:      req.Query,
+		Regex:      req.Regex,
+		IgnoreCase: req.IgnoreCase,
+		Level:      req.Level,
+	}
+	result, err := s.useCase.SearchLog(ctx, req.Filename, filter, req.IncludeRotated, int(req.Page), int(req.PageSize), req.Reverse)
+	if err != nil {
+		switch {
+		case errors.Is(err, app.ErrInvalidLogPath), errors.Is(err, services.ErrInvalidLogFilter):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case os.IsNotExist(err):
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	matches := make([]*pbControlPlane.LogMatch, 0, len(result.Matches))
+	for _, match := range result.Matches {
+		matches = append(matches, &pbControlPlane.LogMatch{
+			File: match.File,
+			Line: int32(match.Line),
+			Text: match.Text,
+		})
+	}
+
+	return &pbControlPlane.SearchLogResponse{
+		Matches:      matches,
+		TotalMatches: int32(result.TotalMatches),
+		CurrentPage:  int32(result.Page),
+		TotalPages:   int32(result.TotalPages),
+	}, nil
+}
+
+func (s *LogsService) QueryAppLog(ctx context.Context, req *pbControlPlane.QueryAppLogRequest) (*pbControlPlane.QueryAppLogResponse, error) {
+	query := services.LogQuery{
+		Level:   req.Level,
+		Fields:  req.Fields,
+		Message: req.Message,
+	}
+	var err error
+	if req.Since != "" {
+		if query.Since, err = time.Parse(time.RFC3339, req.Since); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "since: "+err.Error())
+		}
+	}
+	if req.Until != "" {
+		if query.Until, err = time.Parse(time.RFC3339, req.Until); err != nil {
+			return nil, status.Error(codes.InvalidArgument, "until: "+err.Error())
+		}
+	}
+
+	result, err := s.useCase.QueryAppLog(ctx, query, int(req.Page), int(req.PageSize), req.Reverse)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidLogFilter) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		if os.IsNotExist(err) {
+			return &pbControlPlane.QueryAppLogResponse{CurrentPage: 1, TotalPages: 1}, nil
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	entries := make([]*pbControlPlane.AppLogEntry, 0, len(result.Records))
+	for _, record := range result.Records {
+		entries = append(entries, &pbControlPlane.AppLogEntry{
+			Time:       record.Time.Format(time.RFC3339Nano),
+			Level:      record.Level,
+			Message:    record.Message,
+			Logger:     record.Logger,
+			Caller:     record.Caller,
+			Stacktrace: record.Stacktrace,
+			Fields:     record.Fields,
+			File:       record.File,
+			Line:       int32(record.Line),
+		})
+	}
+
+	return &pbControlPlane.QueryAppLogResponse{
+		Entries:      entries,
+		TotalEntries: int32(result.TotalRecords),
+		CurrentPage:  int32(result.Page),
+		TotalPages:   int32(result.TotalPages),
+	}, nil
+}
+
+func (s *LogsService) ListArchivedLogs(ctx context.Context, req *pbControlPlane.ListArchivedLogsRequest) (*pbControlPlane.ListArchivedLogsResponse, error) {
+	archives, err := s.useCase.Archives(ctx, req.ServerId)
+	if err != nil {
+		if errors.Is(err, app.ErrInvalidLogPath) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	logs := make([]*pbControlPlane.ArchivedLog, 0, len(archives))
+	for _, archive := range archives {
+		logs = append(logs, &pbControlPlane.ArchivedLog{
+			Path:       archive.Path,
+			StorageId:  archive.StorageID,
+			Bucket:     archive.Bucket,
+			Key:        archive.Key,
+			Size:       archive.Size,
+			Local:      archive.Local,
+			ModifiedAt: archive.ModifiedAt.Format(time.RFC3339),
+			ArchivedAt: archive.ArchivedAt.Format(time.RFC3339),
+		})
+	}
+
+	return &pbControlPlane.ListArchivedLogsResponse{
+		Logs: logs,
+	}, nil
+}
+
+func (s *LogsService) TailLog(req *pbControlPlane.TailLogRequest, stream pbControlPlane.LogsService_TailLogServer) error {
+	var sub *services.LogSubscription
+	var err error
+	switch {
+	case req.ServerId != "" && req.DeploymentId != "":
+		return status.Error(codes.InvalidArgument, "only one of server_id and deployment_id can be set")
+	case req.ServerId != "":
+		sub, err = s.useCase.TailServerLog(req.ServerId, req.Filename, req.Offset)
+	case req.DeploymentId != "":
+		sub, err = s.useCase.TailDeploymentLog(req.DeploymentId, req.Offset)
+	default:
+		return status.Error(codes.InvalidArgument, "server_id or deployment_id is required")
+	}
+	if err != nil {
+		if errors.Is(err, app.ErrInvalidLogPath) {
+			return status.Error(codes.InvalidArgument, err.Error())
+		}
+		return status.Error(codes.Internal, err.Error())
+	}
+	defer sub.Close()
+
+	ctx := stream.Context()
+	for {
+		event, err := sub.Next(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			if errors.Is(err, services.ErrLogViewerTooSlow) {
+				return status.Error(codes.ResourceExhausted, err.Error())
+			}
+			return status.Error(codes.Internal, err.Error())
+		}
+		if err := stream.Send(logEventToProto(event)); err != nil {
+			return err
+		}
+	}
+}
+
+func logEventToProto(event services.LogEvent) *pbControlPlane.TailLogEvent {
+	msg := &pbControlPlane.TailLogEvent{Offset: event.Offset}
+	switch {
+	case event.Reset:
+		msg.Event = &pbControlPlane.TailLogEvent_Reset_{Reset_: true}
+	case event.Step != nil:
+		msg.Event = &pbControlPlane.TailLogEvent_Step{Step: &pbControlPlane.LogStepEvent{
+			Index:  int32(event.Step.Index),
+			Name:   event.Step.Name,
+			Host:   event.Step.Host,
+			Status: event.Step.Status,
+			Error:  event.Step.Error,
+			Time:   event.Step.Time.Format(time.RFC3339),
+		}}
+	default:
+		msg.Event = &pbControlPlane.TailLogEvent_Line{Line: event.Line}
+	}
+	return msg
+}
@@ -2,14 +2,17 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"time"
 
+	"github.com/zhinea/sylix/internal/common/util"
 	"github.com/zhinea/sylix/internal/module/controlplane/app"
 	"github.com/zhinea/sylix/internal/module/controlplane/entity"
 	"github.com/zhinea/sylix/internal/module/controlplane/interface/grpc/validator"
 
 	pbCommon "github.com/zhinea/sylix/internal/infra/proto/common"
 	pbControlPlane "github.com/zhinea/sylix/internal/infra/proto/controlplane"
+	"gorm.io/gorm"
 )
 
 type ServerService struct {
@@ -107,6 +110,63 @@ func (s *ServerService) Update(ctx context.Context, pb *pbControlPlane.Server) (
 	}, nil
 }
 
+func (s *ServerService) RetryConnection(ctx context.Context, id *pbControlPlane.Id) (*pbControlPlane.ServerResponse, error) {
+	server, err := s.useCase.RetryConnection(ctx, id.Id)
+	if err != nil {
+		errStr := err.Error()
+		resp := &pbControlPlane.ServerResponse{
+			Status: pbControlPlane.StatusCode_INTERNAL_ERROR,
+			Error:  &errStr,
+		}
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			resp.Status = pbControlPlane.StatusCode_NOT_FOUND
+		case errors.Is(err, util.ErrHostKeyMismatch):
+			resp.Status = pbControlPlane.StatusCode_BAD_REQUEST
+		}
+		if server != nil {
+			resp.Server = s.entityToProto(server)
+		}
+		return resp, nil
+	}
+
+	return &pbControlPlane.ServerResponse{
+		Status: pbControlPlane.StatusCode_OK,
+		Server: s.entityToProto(server),
+	}, nil
+}
+
+func (s *ServerService) TrustHostKey(ctx context.Context, req *pbControlPlane.TrustHostKeyRequest) (*pbControlPlane.ServerResponse, error) {
+	if req.Fingerprint == "" {
+		errStr := "fingerprint of the new host key is required"
+		return &pbControlPlane.ServerResponse{
+			Status: pbControlPlane.StatusCode_BAD_REQUEST,
+			Error:  &errStr,
+		}, nil
+	}
+
+	server, err := s.useCase.TrustHostKey(ctx, req.Id, req.Fingerprint)
+	if err != nil {
+		errStr := err.Error()
+		status := pbControlPlane.StatusCode_INTERNAL_ERROR
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			status = pbControlPlane.StatusCode_NOT_FOUND
+		case errors.Is(err, util.ErrHostKeyMismatch):
+			status = pbControlPlane.StatusCode_BAD_REQUEST
+		}
+		return &pbControlPlane.ServerResponse{
+			Status: status,
+			Error:  &errStr,
+		}, nil
+	}
+
+	return &pbControlPlane.ServerResponse{
+		Status: pbControlPlane.StatusCode_OK,
+		Server: s.entityToProto(server),
+	}, nil
+}
+
 func (s *ServerService) GetStats(ctx context.Context, req *pbControlPlane.GetStatsRequest) (*pbControlPlane.GetStatsResponse, error) {
 	stats, err := s.useCase.GetStats(ctx, req.ServerId)
 	if err != nil {
@@ -179,7 +239,12 @@ func (s *ServerService) InstallAgent(ctx context.Context, id *pbControlPlane.Id)
 		}, nil
 	}
 
-	s.useCase.ProvisionNode(ctx, server)
+	if err := s.useCase.ProvisionNode(ctx, server); err != nil {
+		return &pbControlPlane.MessageResponse{
+			Status:  pbControlPlane.StatusCode_BAD_REQUEST,
+			Message: err.Error(),
+		}, nil
+	}
 
 	return &pbControlPlane.MessageResponse{
 		Status:  pbControlPlane.StatusCode_OK,
@@ -187,6 +252,97 @@ func (s *ServerService) InstallAgent(ctx context.Context, id *pbControlPlane.Id)
 	}, nil
 }
 
+func (s *ServerService) PlanInstallAgent(ctx context.Context, id *pbControlPlane.Id) (*pbControlPlane.WorkflowPlanResponse, error) {
+	server, err := s.useCase.Get(ctx, id.Id)
+	if err != nil {
+		errStr := err.Error()
+		return &pbControlPlane.WorkflowPlanResponse{
+			Status: pbCommon.StatusCode_NOT_FOUND,
+			Error:  &errStr,
+		}, nil
+	}
+
+	plan, err := s.useCase.PlanProvisionNode(ctx, server)
+	if err != nil {
+		errStr := err.Error()
+		return &pbControlPlane.WorkflowPlanResponse{
+			Status: pbCommon.StatusCode_BAD_REQUEST,
+			Error:  &errStr,
+		}, nil
+	}
+
+	return &pbControlPlane.WorkflowPlanResponse{
+		Status: pbCommon.StatusCode_OK,
+		Plan:   planToProto(plan),
+	}, nil
+}
+
+func (s *ServerService) SyncMesh(ctx context.Context, _ *pbCommon.Empty) (*pbControlPlane.MeshSyncResponse, error) {
+	report, err := s.useCase.SyncMesh(ctx)
+	if err != nil {
+		errStr := err.Error()
+		return &pbControlPlane.MeshSyncResponse{
+			Status: pbControlPlane.StatusCode_INTERNAL_ERROR,
+			Report: s.meshSyncReportToProto(report),
+			Error:  &errStr,
+		}, nil
+	}
+
+	return &pbControlPlane.MeshSyncResponse{
+		Status: pbControlPlane.StatusCode_OK,
+		Report: s.meshSyncReportToProto(report),
+	}, nil
+}
+
+func (s *ServerService) GetMeshSyncReport(ctx context.Context, _ *pbCommon.Empty) (*pbControlPlane.MeshSyncResponse, error) {
+	report, err := s.useCase.GetMeshSyncReport(ctx)
+	if err != nil {
+		errStr := err.Error()
+		status := pbControlPlane.StatusCode_INTERNAL_ERROR
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			status = pbControlPlane.StatusCode_NOT_FOUND
+			errStr = "no mesh sync has run yet"
+		}
+		return &pbControlPlane.MeshSyncResponse{
+			Status: status,
+			Error:  &errStr,
+		}, nil
+	}
+
+	return &pbControlPlane.MeshSyncResponse{
+		Status: pbControlPlane.StatusCode_OK,
+		Report: s.meshSyncReportToProto(report),
+	}, nil
+}
+
+func (s *ServerService) GetConnectionPoolStats(ctx context.Context, _ *pbCommon.Empty) (*pbControlPlane.ConnectionPoolStatsResponse, error) {
+	stats := s.useCase.GetConnectionPoolStats()
+
+	pbStats := &pbControlPlane.ConnectionPoolStats{
+		Connections:       int32(stats.Connections),
+		Leases:            int32(stats.Leases),
+		Sessions:          int32(stats.Sessions),
+		Hits:              stats.Hits,
+		Dials:             stats.Dials,
+		DialFailures:      stats.DialFailures,
+		Evictions:         stats.Evictions,
+		KeepaliveFailures: stats.KeepaliveFailures,
+	}
+	for _, host := range stats.Hosts {
+		pbStats.Hosts = append(pbStats.Hosts, &pbControlPlane.ConnectionPoolHost{
+			ServerId: host.Key,
+			Leases:   int32(host.Leases),
+			Sessions: int32(host.Sessions),
+			LastUsed: host.LastUsed.Format(time.RFC3339),
+		})
+	}
+
+	return &pbControlPlane.ConnectionPoolStatsResponse{
+		Status: pbControlPlane.StatusCode_OK,
+		Stats:  pbStats,
+	}, nil
+}
+
 // Helper functions for conversion
 var errStr = "Internal Server Error" // Placeholder for error string pointer
 
@@ -208,6 +364,7 @@ func (s *ServerService) entityToProto(server *entity.Server) *pbControlPlane.Ser
 			Status: pbControlPlane.AgentStatusServer(server.Agent.Status),
 			Logs:   server.Agent.Logs,
 		},
+		HostKeyFingerprint: util.HostKeyFingerprint(server.HostKey),
 	}
 }
 
@@ -234,3 +391,29 @@ func (s *ServerService) protoToEntity(pb *pbControlPlane.Server) *entity.Server
 	server.Id = pb.Id
 	return server
 }
+
+func (s *ServerService) meshSyncReportToProto(report *entity.MeshSyncReport) *pbControlPlane.MeshSyncReport {
+	if report == nil {
+		return nil
+	}
+
+	pb := &pbControlPlane.MeshSyncReport{
+		Id:         report.Id,
+		Status:     pbControlPlane.MeshSyncStatus(report.Status),
+		CreatedAt:  report.CreatedAt.Format(time.RFC3339),
+		FinishedAt: report.FinishedAt.Format(time.RFC3339),
+	}
+	for _, result := range report.Results {
+		pb.Results = append(pb.Results, &pbControlPlane.MeshSyncResult{
+			ServerId:     result.ServerID,
+			ServerName:   result.ServerName,
+			Action:       result.Action,
+			PeersAdded:   int32(result.PeersAdded),
+			PeersRemoved: int32(result.PeersRemoved),
+			PeersUpdated: int32(result.PeersUpdated),
+			Error:        result.Error,
+			DurationMs:   result.DurationMs,
+		})
+	}
+	return pb
+}
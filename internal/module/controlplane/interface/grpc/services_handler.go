@@ -0,0 +1,593 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/zhinea/sylix/internal/common/model"
+	"github.com/zhinea/sylix/internal/common/util"
+	pbCommon "github.com/zhinea/sylix/internal/infra/proto/common"
+	pbControlPlane "github.com/zhinea/sylix/internal/infra/proto/controlplane"
+	"github.com/zhinea/sylix/internal/module/controlplane/app"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/nodetype"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/repository"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/services"
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+	"github.com/zhinea/sylix/internal/module/controlplane/interface/grpc/validator"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"gorm.io/gorm"
+)
+
+type ServicesService struct {
+	pbControlPlane.UnimplementedServicesServiceServer
+	validator    *validator.ServiceNodeValidator
+	useCase      *app.ServicesUseCase
+	orchestrator *app.DeploymentOrchestrator
+}
+
+func NewServicesService(useCase *app.ServicesUseCase, orchestrator *app.DeploymentOrchestrator) *ServicesService {
+	return &ServicesService{
+		validator:    validator.NewServiceNodeValidator(useCase.NodeTypes(), useCase),
+		useCase:      useCase,
+		orchestrator: orchestrator,
+	}
+}
+
+func (s *ServicesService) All(ctx context.Context, req *pbControlPlane.QueryServices) (*pbControlPlane.ServiceNodesResponse, error) {
+	nodes, err := s.useCase.GetAll(ctx, repository.ServiceNodeQuery{
+		Page:   int(req.Page),
+		Limit:  int(req.Limit),
+		Search: req.Search,
+		Sort:   req.Sort,
+		Order:  req.Order,
+	})
+	if err != nil {
+		errStr := err.Error()
+		return &pbControlPlane.ServiceNodesResponse{
+			Status: pbCommon.StatusCode_INTERNAL_ERROR,
+			Error:  &errStr,
+		}, nil
+	}
+
+	var pbNodes []*pbControlPlane.ServiceNode
+	for _, node := range nodes {
+		pbNodes = append(pbNodes, s.entityToProto(node))
+	}
+
+	return &pbControlPlane.ServiceNodesResponse{
+		Status:   pbCommon.StatusCode_OK,
+		Services: pbNodes,
+	}, nil
+}
+
+func (s *ServicesService) One(ctx context.Context, req *pbControlPlane.QueryId) (*pbControlPlane.ServiceNode, error) {
+	node, err := s.useCase.Get(ctx, req.ServiceId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return s.entityToProto(node), nil
+}
+
+func (s *ServicesService) Create(ctx context.Context, req *pbControlPlane.CreateServiceRequest) (*pbControlPlane.ServiceNode, error) {
+	node := s.protoToEntity(req)
+	node.Id = ""
+
+	if errs := s.validator.Validate(ctx, node); len(errs) > 0 {
+		return nil, validationStatusError(errs)
+	}
+
+	created, err := s.useCase.Create(ctx, node)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return s.entityToProto(created), nil
+}
+
+func (s *ServicesService) Update(ctx context.Context, req *pbControlPlane.CreateServiceRequest) (*pbControlPlane.ServiceNode, error) {
+	if req.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "id is required")
+	}
+
+	node := s.protoToEntity(req)
+
+	if errs := s.validator.Validate(ctx, node); len(errs) > 0 {
+		return nil, validationStatusError(errs)
+	}
+
+	updated, err := s.useCase.Update(ctx, node)
+	if err != nil {
+		if errors.Is(err, app.ErrServiceParentChanged) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return nil, toStatusError(err)
+	}
+
+	return s.entityToProto(updated), nil
+}
+
+func (s *ServicesService) Delete(ctx context.Context, req *pbControlPlane.QueryId) (*pbCommon.MessageResponse, error) {
+	if err := s.useCase.Delete(ctx, req.ServiceId); err != nil {
+		statusCode := pbCommon.StatusCode_INTERNAL_ERROR
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			statusCode = pbCommon.StatusCode_NOT_FOUND
+		}
+		return &pbCommon.MessageResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pbCommon.MessageResponse{
+		Status:  pbCommon.StatusCode_OK,
+		Message: "Service deleted successfully",
+	}, nil
+}
+
+func (s *ServicesService) TakeActions(ctx context.Context, req *pbControlPlane.QueryTakeActions) (*pbControlPlane.ServiceNodesResponse, error) {
+	nodes, failures, err := s.useCase.TakeActions(ctx, int(req.Action), req.ServiceIds)
+	if err != nil {
+		errStr := err.Error()
+		return &pbControlPlane.ServiceNodesResponse{
+			Status: pbCommon.StatusCode_INTERNAL_ERROR,
+			Error:  &errStr,
+		}, nil
+	}
+
+	var pbNodes []*pbControlPlane.ServiceNode
+	for _, node := range nodes {
+		pbNodes = append(pbNodes, s.entityToProto(node))
+	}
+
+	var errs []*pbCommon.ValidationError
+	for id, failure := range failures {
+		errs = append(errs, &pbCommon.ValidationError{
+			Field:   id,
+			Message: failure.Error(),
+		})
+	}
+
+	statusCode := pbCommon.StatusCode_OK
+	if len(errs) > 0 {
+		statusCode = pbCommon.StatusCode_BAD_REQUEST
+	}
+
+	return &pbControlPlane.ServiceNodesResponse{
+		Status:   statusCode,
+		Services: pbNodes,
+		Errors:   errs,
+	}, nil
+}
+
+func (s *ServicesService) GetLogs(ctx context.Context, req *pbControlPlane.QueryLog) (*pbControlPlane.LogsResponse, error) {
+	if req.ServiceId == "" {
+		return nil, status.Error(codes.InvalidArgument, "service_id is required")
+	}
+
+	page, err := s.useCase.GetLogs(ctx, req.ServiceId, int(req.Page), int(req.Limit), req.Since, req.Until)
+	if err != nil {
+		return nil, logsStatusError(err)
+	}
+
+	return &pbControlPlane.LogsResponse{
+		Logs:      page.Lines,
+		ServiceId: page.Node.Id,
+		Status:    pbControlPlane.ServiceStatus(page.Node.Status),
+		Page:      int32(page.Page),
+		HasMore:   page.HasMore,
+	}, nil
+}
+
+func (s *ServicesService) FollowLogs(req *pbControlPlane.QueryLog, stream pbControlPlane.ServicesService_FollowLogsServer) error {
+	if req.ServiceId == "" {
+		return status.Error(codes.InvalidArgument, "service_id is required")
+	}
+
+	err := s.useCase.FollowLogs(stream.Context(), req.ServiceId, int(req.Limit), func(name, line string) error {
+		return stream.Send(&pbControlPlane.ServiceLogLine{Stream: name, Line: line})
+	})
+	// a follow ends when the client goes away
+	if err != nil && stream.Context().Err() == nil {
+		return logsStatusError(err)
+	}
+	return nil
+}
+
+func (s *ServicesService) NodeTypes(ctx context.Context, _ *pbCommon.Empty) (*pbControlPlane.NodeTypesResponse, error) {
+	registry := s.useCase.NodeTypes()
+
+	var pbTypes []*pbControlPlane.NodeType
+	for _, t := range registry.All() {
+		pbType := &pbControlPlane.NodeType{
+			Type:            t.Type,
+			Name:            t.Name,
+			Description:     t.Description,
+			PriorityStartup: int32(t.PriorityStartup),
+			Imports:         nodeTypePortsToProto(t.Imports),
+			Exports:         nodeTypePortsToProto(t.Exports),
+		}
+		for _, key := range t.FieldKeys() {
+			field := t.Fields[key]
+			pbType.Fields = append(pbType.Fields, &pbControlPlane.NodeTypeField{
+				Key:          key,
+				Description:  field.Description,
+				Type:         field.Type,
+				Required:     field.Required,
+				Options:      field.Options,
+				RelatedTable: field.RelatedTable,
+				DefaultValue: field.Default,
+			})
+		}
+		pbTypes = append(pbTypes, pbType)
+	}
+
+	return &pbControlPlane.NodeTypesResponse{
+		Status:    pbCommon.StatusCode_OK,
+		App:       registry.App(),
+		NodeTypes: pbTypes,
+	}, nil
+}
+
+func (s *ServicesService) Connect(ctx context.Context, req *pbControlPlane.ConnectRequest) (*pbCommon.MessageResponse, error) {
+	if _, err := s.useCase.Connect(ctx, req.SourceId, req.TargetId); err != nil {
+		return &pbCommon.MessageResponse{
+			Status:  topologyStatusCode(err),
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pbCommon.MessageResponse{
+		Status:  pbCommon.StatusCode_CREATED,
+		Message: "Nodes connected successfully",
+	}, nil
+}
+
+func (s *ServicesService) Disconnect(ctx context.Context, req *pbControlPlane.ConnectRequest) (*pbCommon.MessageResponse, error) {
+	if err := s.useCase.Disconnect(ctx, req.SourceId, req.TargetId); err != nil {
+		return &pbCommon.MessageResponse{
+			Status:  topologyStatusCode(err),
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pbCommon.MessageResponse{
+		Status:  pbCommon.StatusCode_OK,
+		Message: "Nodes disconnected successfully",
+	}, nil
+}
+
+func (s *ServicesService) Topology(ctx context.Context, req *pbControlPlane.QueryId) (*pbControlPlane.TopologyResponse, error) {
+	edges, resolution, err := s.useCase.Topology(ctx, req.ServiceId)
+	if err != nil {
+		errStr := err.Error()
+		return &pbControlPlane.TopologyResponse{
+			Status: topologyStatusCode(err),
+			Error:  &errStr,
+		}, nil
+	}
+
+	resp := &pbControlPlane.TopologyResponse{
+		Status: pbCommon.StatusCode_OK,
+	}
+
+	for _, edge := range edges {
+		resp.Edges = append(resp.Edges, &pbControlPlane.ServiceEdge{
+			Id:       edge.Id,
+			SourceId: edge.NodeAID,
+			TargetId: edge.NodeBID,
+		})
+	}
+
+	for _, imp := range resolution.Imports {
+		pbImport := &pbControlPlane.ResolvedImport{
+			ServiceId: imp.ServiceID,
+			From:      imp.From,
+			Port:      int32(imp.Port),
+			Protocol:  imp.Protocol,
+		}
+		for _, upstream := range imp.Upstreams {
+			pbImport.Upstreams = append(pbImport.Upstreams, &pbControlPlane.ServiceEndpoint{
+				ServiceId: upstream.ServiceID,
+				Host:      upstream.Host,
+				Port:      int32(upstream.Port),
+			})
+		}
+		resp.Imports = append(resp.Imports, pbImport)
+	}
+
+	for _, problem := range resolution.Problems {
+		field := problem.ServiceID
+		if problem.Port != "" {
+			field += "." + problem.Port
+		}
+		resp.Errors = append(resp.Errors, &pbCommon.ValidationError{
+			Field:   field,
+			Message: problem.Message,
+		})
+	}
+
+	if len(resp.Errors) > 0 {
+		resp.Status = pbCommon.StatusCode_VALIDATION_FAILED
+	}
+
+	return resp, nil
+}
+
+func (s *ServicesService) Deploy(ctx context.Context, req *pbControlPlane.QueryId) (*pbControlPlane.DeploymentResponse, error) {
+	deployment, err := s.orchestrator.Deploy(ctx, req.ServiceId)
+	if err != nil {
+		errStr := err.Error()
+		resp := &pbControlPlane.DeploymentResponse{
+			Status: deploymentStatusCode(err),
+			Error:  &errStr,
+		}
+
+		var topologyErr *app.TopologyError
+		if errors.As(err, &topologyErr) {
+			for _, problem := range topologyErr.Problems {
+				field := problem.ServiceID
+				if problem.Port != "" {
+					field += "." + problem.Port
+				}
+				resp.Errors = append(resp.Errors, &pbCommon.ValidationError{
+					Field:   field,
+					Message: problem.Message,
+				})
+			}
+		}
+
+		return resp, nil
+	}
+
+	return &pbControlPlane.DeploymentResponse{
+		Status:     pbCommon.StatusCode_CREATED,
+		Deployment: deploymentToProto(deployment),
+	}, nil
+}
+
+func (s *ServicesService) GetDeployment(ctx context.Context, req *pbControlPlane.QueryDeployment) (*pbControlPlane.DeploymentResponse, error) {
+	deployment, err := s.orchestrator.Get(ctx, req.DeploymentId, req.ServiceId)
+	if err != nil {
+		errStr := err.Error()
+		return &pbControlPlane.DeploymentResponse{
+			Status: deploymentStatusCode(err),
+			Error:  &errStr,
+		}, nil
+	}
+
+	return &pbControlPlane.DeploymentResponse{
+		Status:     pbCommon.StatusCode_OK,
+		Deployment: deploymentToProto(deployment),
+	}, nil
+}
+
+func (s *ServicesService) CancelDeployment(ctx context.Context, req *pbControlPlane.QueryDeployment) (*pbCommon.MessageResponse, error) {
+	if err := s.orchestrator.Cancel(ctx, req.DeploymentId); err != nil {
+		return &pbCommon.MessageResponse{
+			Status:  deploymentStatusCode(err),
+			Message: err.Error(),
+		}, nil
+	}
+
+	return &pbCommon.MessageResponse{
+		Status:  pbCommon.StatusCode_OK,
+		Message: "Deployment cancelled",
+	}, nil
+}
+
+func (s *ServicesService) entityToProto(node *entity.ServiceNode) *pbControlPlane.ServiceNode {
+	pb := &pbControlPlane.ServiceNode{
+		Id:   node.Id,
+		Name: node.Name,
+		App: &pbControlPlane.ServiceApp{
+			App:     node.App.App,
+			Version: node.App.Version,
+			Service: node.App.Service,
+		},
+		Type:      pbControlPlane.ServiceType(node.Type),
+		Status:    pbControlPlane.ServiceStatus(node.Status),
+		ServerId:  node.ServerID,
+		CreatedAt: node.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: node.UpdatedAt.Format(time.RFC3339),
+	}
+
+	if node.ParentID != nil {
+		pb.ParentId = *node.ParentID
+	}
+
+	for _, field := range node.Fields {
+		pb.Fields = append(pb.Fields, &pbControlPlane.ServiceNodeField{
+			Key:   field.Key,
+			Value: field.Value,
+			Type:  field.Type,
+		})
+	}
+
+	for _, port := range node.Ports {
+		pb.Ports = append(pb.Ports, &pbControlPlane.ServicePort{
+			Type:          pbControlPlane.ServicePortType(port.Type),
+			Port:          int32(port.Port),
+			Protocol:      port.Protocol,
+			Host:          port.Host,
+			EnabledExpose: port.EnabledExpose,
+			PublishedPort: int32(port.PublishedPort),
+		})
+	}
+
+	if node.Container.ID != "" || node.Container.Name != "" {
+		pb.Container = &pbControlPlane.ServiceContainer{
+			Id:        node.Container.ID,
+			Name:      node.Container.Name,
+			Image:     node.Container.Image,
+			CreatedAt: node.Container.CreatedAt.Format(time.RFC3339),
+			UpdatedAt: node.Container.UpdatedAt.Format(time.RFC3339),
+		}
+	}
+
+	for _, child := range node.Nodes {
+		pb.Nodes = append(pb.Nodes, s.entityToProto(child))
+	}
+
+	return pb
+}
+
+func (s *ServicesService) protoToEntity(pb *pbControlPlane.CreateServiceRequest) *entity.ServiceNode {
+	node := &entity.ServiceNode{
+		Model: model.Model{
+			Id: pb.Id,
+		},
+		Name:     pb.Name,
+		Type:     int(pb.Type),
+		ServerID: pb.ServerId,
+		ParentID: pb.ParentId,
+	}
+
+	if pb.App != nil {
+		node.App = entity.ServiceApp{
+			App:     pb.App.App,
+			Version: pb.App.Version,
+			Service: pb.App.Service,
+		}
+	}
+
+	for _, field := range pb.Fields {
+		node.Fields = append(node.Fields, &entity.ServiceNodeField{
+			Key:   field.Key,
+			Value: field.Value,
+			Type:  field.Type,
+		})
+	}
+
+	return node
+}
+
+func nodeTypePortsToProto(bindings []nodetype.PortBinding) []*pbControlPlane.NodeTypePort {
+	var ports []*pbControlPlane.NodeTypePort
+	for _, binding := range bindings {
+		peer := binding.From
+		if peer == "" {
+			peer = binding.To
+		}
+
+		keys := make([]string, 0, len(binding.Ports))
+		for key := range binding.Ports {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			port, protocol, err := nodetype.ParsePort(key)
+			if err != nil {
+				continue
+			}
+			ports = append(ports, &pbControlPlane.NodeTypePort{
+				Peer:        peer,
+				Port:        int32(port),
+				Protocol:    protocol,
+				Description: binding.Ports[key],
+			})
+		}
+	}
+	return ports
+}
+
+func topologyStatusCode(err error) pbCommon.StatusCode {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return pbCommon.StatusCode_NOT_FOUND
+	case errors.Is(err, services.ErrSelfEdge),
+		errors.Is(err, services.ErrEdgeExists),
+		errors.Is(err, services.ErrNotSameCluster),
+		errors.Is(err, services.ErrNodesUnrelated),
+		errors.Is(err, services.ErrEdgeNotNodeToNode):
+		return pbCommon.StatusCode_BAD_REQUEST
+	default:
+		return pbCommon.StatusCode_INTERNAL_ERROR
+	}
+}
+
+func deploymentStatusCode(err error) pbCommon.StatusCode {
+	var topologyErr *app.TopologyError
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return pbCommon.StatusCode_NOT_FOUND
+	case errors.As(err, &topologyErr):
+		return pbCommon.StatusCode_VALIDATION_FAILED
+	case errors.Is(err, app.ErrDeploymentInProgress),
+		errors.Is(err, app.ErrDeploymentFinished),
+		errors.Is(err, app.ErrNotACluster),
+		errors.Is(err, app.ErrClusterEmpty):
+		return pbCommon.StatusCode_BAD_REQUEST
+	default:
+		return pbCommon.StatusCode_INTERNAL_ERROR
+	}
+}
+
+func deploymentToProto(deployment *entity.Deployment) *pbControlPlane.Deployment {
+	pb := &pbControlPlane.Deployment{
+		Id:         deployment.Id,
+		ClusterId:  deployment.ClusterID,
+		Status:     pbControlPlane.DeploymentStatus(deployment.Status),
+		Error:      deployment.Error,
+		CreatedAt:  deployment.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  deployment.UpdatedAt.Format(time.RFC3339),
+		FinishedAt: formatTime(deployment.FinishedAt),
+	}
+
+	for _, step := range deployment.Steps {
+		pb.Steps = append(pb.Steps, &pbControlPlane.DeploymentStep{
+			Project:    step.Project,
+			ServerId:   step.ServerID,
+			ServiceIds: step.ServiceIDs,
+			Priority:   int32(step.Priority),
+			Status:     pbControlPlane.DeploymentStatus(step.Status),
+			Error:      step.Error,
+			StartedAt:  formatTime(step.StartedAt),
+			FinishedAt: formatTime(step.FinishedAt),
+		})
+	}
+
+	return pb
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+// toStatusError maps use case errors to gRPC status errors for RPCs that return bare messages.
+func toStatusError(err error) error {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+func logsStatusError(err error) error {
+	switch {
+	case errors.Is(err, app.ErrServiceNodeHasNoContainer):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, app.ErrServiceLogsTooFarBack):
+		return status.Error(codes.OutOfRange, err.Error())
+	case errors.Is(err, util.ErrSSHDedicatedExhausted):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	return toStatusError(err)
+}
+
+// validationStatusError flattens validation errors into an InvalidArgument status.
+func validationStatusError(errs []*pbCommon.ValidationError) error {
+	messages := make([]string, 0, len(errs))
+	for _, e := range errs {
+		messages = append(messages, fmt.Sprintf("%s: %s", e.Field, e.Message))
+	}
+	return status.Error(codes.InvalidArgument, strings.Join(messages, "; "))
+}
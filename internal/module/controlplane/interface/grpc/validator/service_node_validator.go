@@ -0,0 +1,207 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	baseValidator "github.com/zhinea/sylix/internal/common/validator"
+	pbValidation "github.com/zhinea/sylix/internal/infra/proto/common"
+
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/nodetype"
+	"github.com/zhinea/sylix/internal/module/controlplane/entity"
+)
+
+// RelationChecker reports whether the record a relation field points to exists.
+type RelationChecker interface {
+	RelationExists(ctx context.Context, table, id string) (bool, error)
+}
+
+type ServiceNodeValidator struct {
+	*baseValidator.BaseValidator
+	registry  *nodetype.Registry
+	relations RelationChecker
+}
+
+func NewServiceNodeValidator(registry *nodetype.Registry, relations RelationChecker) *ServiceNodeValidator {
+	base := baseValidator.NewBaseValidator()
+
+	_ = base.RegisterValidation("port", validPort)
+	base.RegisterTagMessage("port", func(e validator.FieldError) string {
+		return fmt.Sprintf("%s must be a port between 1 and 65535", e.Field())
+	})
+
+	_ = base.RegisterValidation("schedule", validSchedule)
+	base.RegisterTagMessage("schedule", func(e validator.FieldError) string {
+		return fmt.Sprintf("%s must be a cron expression such as 0 3 * * * or @daily", e.Field())
+	})
+
+	return &ServiceNodeValidator{
+		BaseValidator: base,
+		registry:      registry,
+		relations:     relations,
+	}
+}
+
+func (v *ServiceNodeValidator) Validate(ctx context.Context, node *entity.ServiceNode) []*pbValidation.ValidationError {
+	if errors := v.ValidateStruct(node); len(errors) > 0 {
+		return errors
+	}
+
+	if errors := v.validateBusinessRules(node); len(errors) > 0 {
+		return errors
+	}
+
+	return v.validateFields(ctx, node)
+}
+
+func (v *ServiceNodeValidator) validateBusinessRules(node *entity.ServiceNode) []*pbValidation.ValidationError {
+	var errors []*pbValidation.ValidationError
+
+	if node.Type != entity.ServiceTypeCluster && node.Type != entity.ServiceTypeNode {
+		errors = append(errors, &pbValidation.ValidationError{
+			Field:   "Type",
+			Message: "type must be either cluster or node",
+		})
+	}
+
+	if node.Type == entity.ServiceTypeNode {
+		if node.ServerID == "" {
+			errors = append(errors, &pbValidation.ValidationError{
+				Field:   "ServerID",
+				Message: "server_id is required for a node",
+			})
+		}
+		if node.App.Service == "" {
+			errors = append(errors, &pbValidation.ValidationError{
+				Field:   "App.Service",
+				Message: "service is required for a node",
+			})
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, field := range node.Fields {
+		if seen[field.Key] {
+			errors = append(errors, &pbValidation.ValidationError{
+				Field:   field.Key,
+				Message: "field is defined more than once",
+			})
+		}
+		seen[field.Key] = true
+	}
+
+	return errors
+}
+
+// validateFields checks the fields of a node against its node type in the catalog.
+func (v *ServiceNodeValidator) validateFields(ctx context.Context, node *entity.ServiceNode) []*pbValidation.ValidationError {
+	if node.Type != entity.ServiceTypeNode {
+		return nil
+	}
+
+	if node.App.App != v.registry.App() {
+		return []*pbValidation.ValidationError{{
+			Field:   "App.App",
+			Message: fmt.Sprintf("unsupported app %q", node.App.App),
+		}}
+	}
+
+	nodeType, ok := v.registry.Get(node.App.Service)
+	if !ok {
+		return []*pbValidation.ValidationError{{
+			Field:   "App.Service",
+			Message: fmt.Sprintf("unknown node type %q", node.App.Service),
+		}}
+	}
+
+	var errors []*pbValidation.ValidationError
+
+	values := make(map[string]string, len(node.Fields))
+	for _, field := range node.Fields {
+		if _, ok := nodeType.Fields[field.Key]; !ok {
+			errors = append(errors, &pbValidation.ValidationError{
+				Field:   field.Key,
+				Message: fmt.Sprintf("%s is not a field of %s", field.Key, nodeType.Type),
+			})
+			continue
+		}
+		values[field.Key] = field.Value
+	}
+
+	// server_id is carried on the request itself; a field, when sent, must agree with it
+	if value, ok := values["server_id"]; ok && value != node.ServerID {
+		errors = append(errors, &pbValidation.ValidationError{
+			Field:   "server_id",
+			Message: "server_id field must match the service server_id",
+		})
+	} else if !ok {
+		values["server_id"] = node.ServerID
+	}
+
+	for _, key := range nodeType.FieldKeys() {
+		field := nodeType.Fields[key]
+		value := values[key]
+
+		if value == "" {
+			if field.Required && field.Default == "" {
+				errors = append(errors, v.ValidateVar(key, value, "required")...)
+			}
+			continue
+		}
+
+		switch field.Type {
+		case nodetype.FieldTypeNumber:
+			errors = append(errors, v.ValidateVar(key, value, "numeric")...)
+		case nodetype.FieldTypePort:
+			errors = append(errors, v.ValidateVar(key, value, "port")...)
+		case nodetype.FieldTypeBoolean:
+			errors = append(errors, v.ValidateVar(key, value, "boolean")...)
+		case nodetype.FieldTypeOptions:
+			errors = append(errors, v.ValidateVar(key, value, "oneof="+strings.Join(field.Options, " "))...)
+		case nodetype.FieldTypeRelation:
+			errors = append(errors, v.validateRelation(ctx, field, value)...)
+		case nodetype.FieldTypeSchedule:
+			errors = append(errors, v.ValidateVar(key, value, "schedule")...)
+		}
+	}
+
+	// scheduled backups go to the node's backup storage, without one they could never run
+	if values["backup_schedule"] != "" && values["backup_storage_id"] == "" {
+		errors = append(errors, &pbValidation.ValidationError{
+			Field:   "backup_schedule",
+			Message: "backup_schedule requires a backup_storage_id",
+		})
+	}
+
+	return errors
+}
+
+func (v *ServiceNodeValidator) validateRelation(ctx context.Context, field *nodetype.Field, id string) []*pbValidation.ValidationError {
+	exists, err := v.relations.RelationExists(ctx, field.RelatedTable, id)
+	if err != nil {
+		return []*pbValidation.ValidationError{{
+			Field:   field.Key,
+			Message: fmt.Sprintf("failed to check %s: %v", field.RelatedTable, err),
+		}}
+	}
+	if !exists {
+		return []*pbValidation.ValidationError{{
+			Field:   field.Key,
+			Message: fmt.Sprintf("%s does not reference an existing record in %s", field.Key, field.RelatedTable),
+		}}
+	}
+	return nil
+}
+
+func validPort(fl validator.FieldLevel) bool {
+	port, err := strconv.Atoi(fl.Field().String())
+	return err == nil && port >= 1 && port <= 65535
+}
+
+func validSchedule(fl validator.FieldLevel) bool {
+	_, err := nodetype.ParseSchedule(fl.Field().String())
+	return err == nil
+}
@@ -0,0 +1,168 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/zhinea/sylix/internal/common/workflow"
+	pbCommon "github.com/zhinea/sylix/internal/infra/proto/common"
+	pbControlPlane "github.com/zhinea/sylix/internal/infra/proto/controlplane"
+	"github.com/zhinea/sylix/internal/module/controlplane/domain/services"
+	"gorm.io/gorm"
+)
+
+type WorkflowService struct {
+	pbControlPlane.UnimplementedWorkflowServiceServer
+	service *services.WorkflowService
+}
+
+func NewWorkflowService(service *services.WorkflowService) *WorkflowService {
+	return &WorkflowService{
+		service: service,
+	}
+}
+
+func (s *WorkflowService) All(ctx context.Context, _ *pbCommon.Empty) (*pbControlPlane.WorkflowDefinitionsResponse, error) {
+	var data []*pbControlPlane.WorkflowDefinition
+	for _, def := range s.service.All() {
+		data = append(data, definitionToProto(def))
+	}
+
+	return &pbControlPlane.WorkflowDefinitionsResponse{
+		Status: pbCommon.StatusCode_OK,
+		Data:   data,
+	}, nil
+}
+
+func (s *WorkflowService) Run(ctx context.Context, req *pbControlPlane.RunWorkflowRequest) (*pbControlPlane.RunWorkflowResponse, error) {
+	var errs []*pbCommon.ValidationError
+	if req.Name == "" {
+		errs = append(errs, &pbCommon.ValidationError{Field: "name", Message: "Name is required"})
+	}
+	if req.ServerId == "" {
+		errs = append(errs, &pbCommon.ValidationError{Field: "server_id", Message: "Server is required"})
+	}
+	if len(errs) > 0 {
+		return &pbControlPlane.RunWorkflowResponse{
+			Status: pbCommon.StatusCode_VALIDATION_FAILED,
+			Errors: errs,
+		}, nil
+	}
+
+	run, logFile, err := s.service.Run(ctx, req.Name, req.ServerId, req.Params, req.Hosts)
+	if err != nil {
+		errStr := err.Error()
+		return &pbControlPlane.RunWorkflowResponse{
+			Status: workflowStatusCode(err),
+			Error:  &errStr,
+		}, nil
+	}
+
+	return &pbControlPlane.RunWorkflowResponse{
+		Status:  pbCommon.StatusCode_CREATED,
+		RunId:   run.ID,
+		LogFile: logFile,
+	}, nil
+}
+
+func (s *WorkflowService) Plan(ctx context.Context, req *pbControlPlane.RunWorkflowRequest) (*pbControlPlane.WorkflowPlanResponse, error) {
+	var errs []*pbCommon.ValidationError
+	if req.Name == "" {
+		errs = append(errs, &pbCommon.ValidationError{Field: "name", Message: "Name is required"})
+	}
+	if req.ServerId == "" {
+		errs = append(errs, &pbCommon.ValidationError{Field: "server_id", Message: "Server is required"})
+	}
+	if len(errs) > 0 {
+		return &pbControlPlane.WorkflowPlanResponse{
+			Status: pbCommon.StatusCode_VALIDATION_FAILED,
+			Errors: errs,
+		}, nil
+	}
+
+	plan, err := s.service.Plan(ctx, req.Name, req.ServerId, req.Params, req.Hosts)
+	if err != nil {
+		errStr := err.Error()
+		return &pbControlPlane.WorkflowPlanResponse{
+			Status: workflowStatusCode(err),
+			Error:  &errStr,
+		}, nil
+	}
+
+	return &pbControlPlane.WorkflowPlanResponse{
+		Status: pbCommon.StatusCode_OK,
+		Plan:   planToProto(plan),
+	}, nil
+}
+
+func workflowStatusCode(err error) pbCommon.StatusCode {
+	switch {
+	case errors.Is(err, services.ErrWorkflowNotFound), errors.Is(err, gorm.ErrRecordNotFound):
+		return pbCommon.StatusCode_NOT_FOUND
+	case errors.Is(err, services.ErrInvalidWorkflowRun), errors.Is(err, services.ErrWorkflowRunning):
+		return pbCommon.StatusCode_BAD_REQUEST
+	default:
+		return pbCommon.StatusCode_INTERNAL_ERROR
+	}
+}
+
+func definitionToProto(def *workflow.Definition) *pbControlPlane.WorkflowDefinition {
+	pb := &pbControlPlane.WorkflowDefinition{
+		Name:        def.Name,
+		Description: def.Description,
+		Hosts:       def.Hosts,
+	}
+	for _, param := range def.Params {
+		pb.Params = append(pb.Params, &pbControlPlane.WorkflowParam{
+			Name:        param.Name,
+			Description: param.Description,
+			Required:    param.Required,
+			Default:     param.Default,
+			Secret:      param.Secret,
+		})
+	}
+	for _, step := range def.Workflow.Steps {
+		pb.Steps = append(pb.Steps, &pbControlPlane.WorkflowStepDefinition{
+			Id:        step.ID,
+			Name:      step.Name,
+			Action:    string(step.Action),
+			DependsOn: step.DependsOn,
+			Hosts:     step.Hosts,
+		})
+	}
+	return pb
+}
+
+func planToProto(plan *workflow.Plan) *pbControlPlane.WorkflowPlan {
+	pb := &pbControlPlane.WorkflowPlan{
+		Name: plan.Name,
+	}
+	for _, step := range plan.Steps {
+		pb.Steps = append(pb.Steps, plannedStepToProto(step))
+	}
+	return pb
+}
+
+func plannedStepToProto(step *workflow.PlannedStep) *pbControlPlane.PlannedStep {
+	pb := &pbControlPlane.PlannedStep{
+		Index:      int32(step.Index),
+		Name:       step.Name,
+		Host:       step.Host,
+		Stage:      step.Stage,
+		Action:     string(step.Action),
+		DependsOn:  step.DependsOn,
+		Decision:   string(step.Decision),
+		Condition:  step.Condition,
+		Command:    step.Command,
+		SourcePath: step.SourcePath,
+		DestPath:   step.DestPath,
+		Content:    step.Content,
+		Mode:       step.Mode,
+		Detail:     step.Detail,
+		Error:      step.Error,
+	}
+	if step.Rollback != nil {
+		pb.Rollback = plannedStepToProto(step.Rollback)
+	}
+	return pb
+}